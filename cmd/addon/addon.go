@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package addon
+
+import (
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	addonExamples = templates.Examples(i18n.T(`
+		# List the addons and which are enabled on the configured cluster
+		blitzctl addon list
+
+		# Enable metrics-server and ingress-nginx on a kind cluster
+		blitzctl addon enable metrics-server ingress-nginx --provider kind --cluster-name mycluster
+
+		# Disable the dashboard of a minikube cluster
+		blitzctl addon disable dashboard --cluster-name mycluster
+
+		# Enable addons on every new cluster
+		blitzctl config set addons metrics-server,cert-manager
+	`))
+
+	addonCmd = &cobra.Command{
+		Use:     "addon",
+		Aliases: []string{"addons"},
+		Short:   "Manage cluster addons like metrics-server and ingress-nginx",
+		Long: `Enable and disable addons, the components installed into a cluster after it
+is created. Each addon is a version pinned manifest bundle or Helm chart.
+Minikube clusters use minikube's own addon when there is one.
+
+Enabled addons are recorded with the cluster. 'blitzctl create cluster
+--addons' and the addons configuration key enable them on new clusters.`,
+		Example: addonExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmd.Help(); err != nil {
+				cmd.PrintErrln("❌ Error displaying help:", err)
+			}
+		},
+	}
+
+	clusterProvider string
+)
+
+// GetAddonCmd returns the addon command
+func GetAddonCmd() *cobra.Command {
+	return addonCmd
+}
+
+func init() {
+	addonCmd.PersistentFlags().StringVarP(&clusterProvider, "provider", "p", string(provider.Minikube), i18n.T("Cluster provider (minikube or kind)."))
+	addonCmd.PersistentFlags().String("cluster-name", "", i18n.T("Cluster Name (default: the configured cluster_name)."))
+	config.BindFlag(addonCmd.PersistentFlags().Lookup("cluster-name"), "cluster_name")
+
+	addonCmd.AddCommand(enableCmd)
+	addonCmd.AddCommand(disableCmd)
+	addonCmd.AddCommand(listCmd)
+}
+
+// targetCluster returns the provider and name of the cluster selected by
+// --provider and --cluster-name
+func targetCluster() (provider.ClusterProvider, string, error) {
+	providerType, err := provider.ParseProvider(clusterProvider)
+	if err != nil {
+		return nil, "", err
+	}
+	clusterProviderInstance, ok := provider.GetProviderByType(providerType)
+	if !ok {
+		return nil, "", errdefs.Unsupported("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
+	}
+	return clusterProviderInstance, config.GetManager().GetDefaults().ClusterName, nil
+}
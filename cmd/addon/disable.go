@@ -0,0 +1,25 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package addon
+
+import (
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/internal/addons"
+	"github.com/spf13/cobra"
+)
+
+var disableCmd = &cobra.Command{
+	Use:       "disable <addon>...",
+	Short:     "Disable addons on a cluster",
+	Long:      `Remove addons from a cluster in the reverse order given, deleting what enabling them created.`,
+	Args:      cobra.MinimumNArgs(1),
+	ValidArgs: addons.Names(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterProviderInstance, clusterName, err := targetCluster()
+		if err != nil {
+			return err
+		}
+		return provider.DisableAddons(cmd.Context(), clusterProviderInstance, clusterName, args)
+	},
+}
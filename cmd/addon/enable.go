@@ -0,0 +1,34 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package addon
+
+import (
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/addons"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+var enableCmd = &cobra.Command{
+	Use:       "enable <addon>...",
+	Short:     "Enable addons on a cluster",
+	Long:      `Install addons into a cluster in the order given and record them with the cluster. Enabling an addon again upgrades it to the pinned version.`,
+	Args:      cobra.MinimumNArgs(1),
+	ValidArgs: addons.Names(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterProviderInstance, clusterName, err := targetCluster()
+		if err != nil {
+			return err
+		}
+		return provider.EnableAddons(cmd.Context(), clusterProviderInstance, clusterName, args, time.Duration(config.GetManager().GetDefaults().WaitTimeout))
+	},
+}
+
+func init() {
+	enableCmd.Flags().Duration("timeout", 0, i18n.T("How long to wait for each addon to be ready (default: the configured wait_timeout)."))
+	config.BindFlag(enableCmd.Flags().Lookup("timeout"), "wait_timeout")
+}
@@ -0,0 +1,59 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package addon
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/addons"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List addons and those enabled on a cluster",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterProviderInstance, clusterName, err := targetCluster()
+		if err != nil {
+			return err
+		}
+		providerType := clusterProviderInstance.GetProviderType()
+
+		enabled := map[string]config.AddonInfo{}
+		if cluster, err := config.GetManager().GetCluster(clusterName, string(providerType)); err == nil {
+			for _, info := range cluster.Addons {
+				enabled[info.Name] = info
+			}
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "NAME\tVERSION\tSOURCE\tENABLED\tDESCRIPTION")
+		for _, addon := range addons.Addons() {
+			version, source := addon.Version, addon.Method()
+			if native, ok := clusterProviderInstance.(provider.AddonProvider); ok {
+				if name, ok := native.NativeAddon(addon); ok {
+					version, source = "-", fmt.Sprintf("%s (%s)", providerType, name)
+				}
+			}
+
+			state := "-"
+			if info, ok := enabled[addon.Name]; ok {
+				state = "✅ " + info.EnabledAt.Format("2006-01-02 15:04")
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", addon.Name, version, source, state, addon.Description)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Printf("\nCluster: %s (%s)\n", clusterName, providerType)
+		return nil
+	},
+}
@@ -0,0 +1,41 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package charts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Install or upgrade the configured charts on a cluster",
+	Long: `Install the configured charts on a cluster in order, or upgrade the releases
+already installed, waiting for the resources of each release to be ready
+before the next one. The first failure stops the run.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		providerType, err := provider.ParseProvider(clusterProvider)
+		if err != nil {
+			return err
+		}
+
+		defaults := config.GetManager().GetDefaults()
+		if len(defaults.Charts) == 0 {
+			fmt.Println("No charts configured, add them under charts with 'blitzctl config edit'")
+			return nil
+		}
+		return provider.ApplyCharts(cmd.Context(), providerType, defaults.ClusterName, defaults.Charts, time.Duration(defaults.WaitTimeout))
+	},
+}
+
+func init() {
+	applyCmd.Flags().Duration("timeout", 0, i18n.T("How long to wait for the resources of each release to be ready (default: the configured wait_timeout)."))
+	config.BindFlag(applyCmd.Flags().Lookup("timeout"), "wait_timeout")
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package charts
+
+import (
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	chartsExamples = templates.Examples(i18n.T(`
+		# Install or upgrade the configured charts on a kind cluster
+		blitzctl charts apply --provider kind --cluster-name mycluster
+
+		# Apply the charts of the "platform" profile, waiting up to 10 minutes for each
+		blitzctl charts apply --cluster-name mycluster --profile platform --timeout 10m
+	`))
+
+	chartsCmd = &cobra.Command{
+		Use:     "charts",
+		Aliases: []string{"chart"},
+		Short:   "Install the configured Helm charts on a cluster",
+		Long: `Install the Helm charts listed under the charts configuration key, a team's
+standard platform stack, with the Helm SDK against the cluster's kubeconfig
+context. No helm binary is needed.
+
+Each chart gives the chart name with its repo URL (or an oci:// reference or
+a local path), and optionally a version, release name, namespace and values
+file:
+
+  defaults:
+    charts:
+      - repo: https://charts.jetstack.io
+        chart: cert-manager
+        version: v1.18.2
+        namespace: cert-manager
+        values_file: k8s/cert-manager.yaml
+
+'blitzctl create cluster' applies them to every new cluster too.`,
+		Example: chartsExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmd.Help(); err != nil {
+				cmd.PrintErrln("❌ Error displaying help:", err)
+			}
+		},
+	}
+
+	clusterProvider string
+)
+
+// GetChartsCmd returns the charts command
+func GetChartsCmd() *cobra.Command {
+	return chartsCmd
+}
+
+func init() {
+	chartsCmd.PersistentFlags().StringVarP(&clusterProvider, "provider", "p", string(provider.Minikube), i18n.T("Cluster provider (minikube or kind)."))
+	chartsCmd.PersistentFlags().String("cluster-name", "", i18n.T("Cluster Name (default: the configured cluster_name)."))
+	config.BindFlag(chartsCmd.PersistentFlags().Lookup("cluster-name"), "cluster_name")
+
+	chartsCmd.AddCommand(applyCmd)
+}
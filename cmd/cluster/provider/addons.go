@@ -0,0 +1,142 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/addons"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/kube"
+)
+
+// AddonProvider is implemented by providers shipping addons of their own,
+// which are used instead of installing the blitzctl addon
+type AddonProvider interface {
+	// NativeAddon returns the provider's name for addon, false when it has
+	// no equivalent
+	NativeAddon(addon *addons.Addon) (string, bool)
+	EnableAddon(ctx context.Context, clusterName, name string) error
+	DisableAddon(ctx context.Context, clusterName, name string) error
+}
+
+// LookupAddons resolves addon names, so a typo is reported before a cluster
+// is created
+func LookupAddons(names []string) ([]*addons.Addon, error) {
+	var list []*addons.Addon
+	for _, name := range names {
+		addon, err := addons.Lookup(name)
+		if err != nil {
+			return nil, fmt.Errorf("❌ %w", err)
+		}
+		if !slices.Contains(list, addon) {
+			list = append(list, addon)
+		}
+	}
+	return list, nil
+}
+
+// EnableAddons enables the addons on a cluster in order, through the
+// provider's own addons where it has them, and records them with the cluster.
+// Each installed addon is waited on for up to timeout.
+func EnableAddons(ctx context.Context, p ClusterProvider, clusterName string, names []string, timeout time.Duration) error {
+	list, err := LookupAddons(names)
+	if err != nil {
+		return err
+	}
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+
+	providerType := p.GetProviderType()
+	for _, addon := range list {
+		fmt.Printf("🧩 Enabling addon %s...\n", addon.Name)
+
+		info := config.AddonInfo{Name: addon.Name, EnabledAt: time.Now()}
+		if native, ok := nativeAddon(p, addon); ok {
+			info.Method = string(providerType)
+			err = p.(AddonProvider).EnableAddon(ctx, clusterName, native)
+		} else {
+			info.Method = addon.Method()
+			info.Version = addon.Version
+			err = addon.Install(ctx, kube.ContextName(string(providerType), clusterName), timeout)
+		}
+		if err != nil {
+			return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error enabling addon %s on cluster '%s'", addon.Name, clusterName).
+				WithHint("retry with 'blitzctl addon enable %s --provider %s --cluster-name %s'", addon.Name, providerType, clusterName)
+		}
+
+		updateAddons(providerType, clusterName, func(enabled []config.AddonInfo) []config.AddonInfo {
+			enabled = slices.DeleteFunc(enabled, func(a config.AddonInfo) bool { return a.Name == info.Name })
+			return append(enabled, info)
+		})
+		fmt.Printf("✅ Addon %s enabled (%s)\n", addon.Name, describeMethod(info))
+	}
+	return nil
+}
+
+// DisableAddons removes the addons from a cluster in reverse order
+func DisableAddons(ctx context.Context, p ClusterProvider, clusterName string, names []string) error {
+	list, err := LookupAddons(names)
+	if err != nil {
+		return err
+	}
+
+	providerType := p.GetProviderType()
+	for _, addon := range slices.Backward(list) {
+		fmt.Printf("🧩 Disabling addon %s...\n", addon.Name)
+
+		if native, ok := nativeAddon(p, addon); ok {
+			err = p.(AddonProvider).DisableAddon(ctx, clusterName, native)
+		} else {
+			err = addon.Uninstall(ctx, kube.ContextName(string(providerType), clusterName))
+		}
+		if err != nil {
+			return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error disabling addon %s on cluster '%s'", addon.Name, clusterName)
+		}
+
+		updateAddons(providerType, clusterName, func(enabled []config.AddonInfo) []config.AddonInfo {
+			return slices.DeleteFunc(enabled, func(a config.AddonInfo) bool { return a.Name == addon.Name })
+		})
+		fmt.Printf("✅ Addon %s disabled\n", addon.Name)
+	}
+	return nil
+}
+
+// nativeAddon returns the provider's own name for addon when it has one
+func nativeAddon(p ClusterProvider, addon *addons.Addon) (string, bool) {
+	native, ok := p.(AddonProvider)
+	if !ok {
+		return "", false
+	}
+	return native.NativeAddon(addon)
+}
+
+// describeMethod tells how an addon was installed
+func describeMethod(info config.AddonInfo) string {
+	if info.Version == "" {
+		return info.Method + " addon"
+	}
+	return info.Method + " " + info.Version
+}
+
+// updateAddons rewrites the addons recorded with a tracked cluster
+func updateAddons(providerType ProviderType, clusterName string, mutate func(enabled []config.AddonInfo) []config.AddonInfo) {
+	err := config.GetManager().UpdateCluster(clusterName, string(providerType), func(cluster *config.ClusterInfo) error {
+		cluster.Addons = mutate(cluster.Addons)
+		return nil
+	})
+	if errdefs.Is(err, errdefs.KindNotFound) {
+		slog.Debug("cluster not tracked, addons not recorded", "cluster", clusterName)
+		return
+	}
+	if err != nil {
+		slog.Warn("Failed to save cluster addons", "error", err)
+	}
+}
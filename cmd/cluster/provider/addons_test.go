@@ -0,0 +1,130 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/addons"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// nativeProvider has addons of its own for every addon with a minikube
+// equivalent and records the calls made to them
+type nativeProvider struct {
+	ClusterProvider
+	failOn string
+	calls  []string
+}
+
+func (p *nativeProvider) GetProviderType() ProviderType {
+	return Minikube
+}
+
+func (p *nativeProvider) NativeAddon(addon *addons.Addon) (string, bool) {
+	return addon.Minikube, addon.Minikube != ""
+}
+
+func (p *nativeProvider) EnableAddon(ctx context.Context, clusterName, name string) error {
+	p.calls = append(p.calls, "enable "+name)
+	if name == p.failOn {
+		return errors.New("minikube addons enable failed")
+	}
+	return nil
+}
+
+func (p *nativeProvider) DisableAddon(ctx context.Context, clusterName, name string) error {
+	p.calls = append(p.calls, "disable "+name)
+	return nil
+}
+
+// trackCluster points the global manager at a temporary state tracking
+// cluster dev on providerType
+func trackCluster(t *testing.T, providerType ProviderType) *config.Manager {
+	t.Helper()
+	manager := config.GetManager()
+	previous, err := manager.StateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager.SetStateStore(config.NewFileStateStore(filepath.Join(t.TempDir(), config.StateFileName)))
+	t.Cleanup(func() { manager.SetStateStore(previous) })
+
+	if err := manager.AddCluster(config.ClusterInfo{Name: "dev", Provider: string(providerType)}); err != nil {
+		t.Fatal(err)
+	}
+	return manager
+}
+
+func TestEnableAndDisableAddons(t *testing.T) {
+	tests := []struct {
+		name        string
+		enable      []string
+		disable     []string
+		failOn      string
+		wantKind    errdefs.Kind
+		wantCalls   []string
+		wantEnabled []string
+	}{
+		{
+			name:        "enabled in order",
+			enable:      []string{"metrics-server", "ingress-nginx", "metrics-server"},
+			wantCalls:   []string{"enable metrics-server", "enable ingress"},
+			wantEnabled: []string{"metrics-server", "ingress-nginx"},
+		},
+		{
+			name:        "disabled in reverse order",
+			enable:      []string{"metrics-server", "ingress-nginx", "dashboard"},
+			disable:     []string{"metrics-server", "dashboard"},
+			wantCalls:   []string{"enable metrics-server", "enable ingress", "enable dashboard", "disable dashboard", "disable metrics-server"},
+			wantEnabled: []string{"ingress-nginx"},
+		},
+		{
+			name:        "failure stops the rest",
+			enable:      []string{"metrics-server", "ingress-nginx", "dashboard"},
+			failOn:      "ingress",
+			wantKind:    errdefs.KindUnknown,
+			wantCalls:   []string{"enable metrics-server", "enable ingress"},
+			wantEnabled: []string{"metrics-server"},
+		},
+		{name: "unknown addon before any change", enable: []string{"metrics-server", "istio"}, wantKind: errdefs.KindNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := trackCluster(t, Minikube)
+			p := &nativeProvider{failOn: tt.failOn}
+
+			err := EnableAddons(context.Background(), p, "dev", tt.enable, 0)
+			if err == nil && tt.disable != nil {
+				err = DisableAddons(context.Background(), p, "dev", tt.disable)
+			}
+			if got := errdefs.KindOf(err); got != tt.wantKind {
+				t.Fatalf("error = %v, want kind %q", err, tt.wantKind)
+			}
+			if !slices.Equal(p.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", p.calls, tt.wantCalls)
+			}
+
+			cluster, err := manager.GetCluster("dev", string(Minikube))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var enabled []string
+			for _, addon := range cluster.Addons {
+				enabled = append(enabled, addon.Name)
+				if addon.Method != string(Minikube) || addon.Version != "" {
+					t.Errorf("addon %s recorded as %s %s, want the minikube addon", addon.Name, addon.Method, addon.Version)
+				}
+			}
+			if !slices.Equal(enabled, tt.wantEnabled) {
+				t.Errorf("recorded addons = %v, want %v", enabled, tt.wantEnabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/updater"
+)
+
+// installBinary installs a provider CLI through the verified updater,
+// leaving an existing installation alone unless a version is requested
+func installBinary(ctx context.Context, name string, options *InstallOptions) error {
+	u, err := updater.New(name)
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+
+	if path, ok := u.Installed(); ok && options.Version == "" && options.BinDir == "" {
+		fmt.Printf("✅ %s is already installed at %s\n", name, path)
+		fmt.Printf("💡 Use 'blitzctl upgrade cluster --provider %s' to upgrade it\n", name)
+		return nil
+	}
+
+	result, err := u.Update(ctx, updater.Options{
+		Version: options.Version,
+		BinDir:  options.BinDir,
+	})
+	if err != nil {
+		return fmt.Errorf("❌ Error installing %s: %w", name, err)
+	}
+
+	fmt.Printf("✅ %s %s installed to %s\n", name, result.Version, result.Path)
+	return nil
+}
+
+// upgradeBinary upgrades, or rolls back, a provider CLI through the verified updater
+func upgradeBinary(ctx context.Context, name string, options *UpgradeOptions) error {
+	u, err := updater.New(name)
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+
+	opts := updater.Options{
+		Version: options.Version,
+		BinDir:  options.BinDir,
+	}
+
+	if options.Rollback {
+		path, err := u.Rollback(opts)
+		if err != nil {
+			return fmt.Errorf("❌ Error rolling back %s: %w", name, err)
+		}
+		fmt.Printf("✅ %s rolled back (%s)\n", name, path)
+		return nil
+	}
+
+	if _, ok := u.Installed(); !ok && options.BinDir == "" {
+		return errdefs.ToolMissing("❌ %s is not installed", name).WithHint("blitzctl install cluster --provider %s", name)
+	}
+
+	result, err := u.Update(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("❌ Error upgrading %s: %w", name, err)
+	}
+
+	fmt.Printf("✅ %s upgraded to %s (%s)\n", name, result.Version, result.Path)
+	if result.Backup != "" {
+		fmt.Printf("💾 Previous version saved to %s, use --rollback to restore it\n", result.Backup)
+	}
+	return nil
+}
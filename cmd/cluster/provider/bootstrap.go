@@ -0,0 +1,53 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/bootstrap"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/kube"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// LoadBootstrap reads the manifests of the bootstrap directory, so a
+// malformed one is reported before a cluster is created
+func LoadBootstrap(dir string) ([]*unstructured.Unstructured, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	objects, err := bootstrap.Load(dir)
+	if err != nil {
+		return nil, errdefs.Wrap(errdefs.KindInvalid, err, "❌ Invalid bootstrap directory %s", dir)
+	}
+	return objects, nil
+}
+
+// ApplyBootstrap server-side applies the objects loaded from the bootstrap
+// directory dir to a cluster, reporting the result of each
+func ApplyBootstrap(ctx context.Context, providerType ProviderType, clusterName, dir string, objects []*unstructured.Unstructured, timeout time.Duration) error {
+	if len(objects) == 0 {
+		return nil
+	}
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+
+	fmt.Printf("📄 Applying %d objects from %s...\n", len(objects), dir)
+	kubeContext := kube.ContextName(string(providerType), clusterName)
+	if err := bootstrap.Apply(ctx, kubeContext, objects, timeout, os.Stdout); err != nil {
+		source := "-R -f " + dir
+		if bootstrap.IsKustomization(dir) {
+			source = "-k " + dir
+		}
+		return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error bootstrapping cluster '%s' from %s", clusterName, dir).
+			WithHint("fix the manifests and apply them with 'kubectl --context %s apply --server-side %s'", kubeContext, source)
+	}
+	fmt.Printf("✅ Cluster '%s' bootstrapped from %s\n", clusterName, dir)
+	return nil
+}
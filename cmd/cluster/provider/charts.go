@@ -0,0 +1,70 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/helm"
+	"github.com/OneideLuizSchneider/blitzctl/internal/kube"
+)
+
+// ChartReleases turns the configured charts into Helm releases, reading
+// their values files, so a missing file is reported before a cluster is
+// created
+func ChartReleases(charts []config.Chart) ([]helm.Release, error) {
+	releases := make([]helm.Release, 0, len(charts))
+	for _, chart := range charts {
+		if err := chart.Validate(); err != nil {
+			return nil, errdefs.Invalid("❌ Invalid chart: %s", err)
+		}
+
+		release := helm.Release{
+			Name:      chart.ReleaseName(),
+			Namespace: chart.ReleaseNamespace(),
+			Repo:      chart.Repo,
+			Chart:     chart.Chart,
+			Version:   chart.Version,
+		}
+		if chart.ValuesFile != "" {
+			values, err := helm.ReadValues(chart.ValuesFile)
+			if err != nil {
+				return nil, errdefs.Wrap(errdefs.KindInvalid, err, "❌ Invalid chart %s", chart)
+			}
+			release.Values = values
+		}
+		releases = append(releases, release)
+	}
+	return releases, nil
+}
+
+// ApplyCharts installs the charts on a cluster in order, or upgrades their
+// releases, with Helm against the cluster's kubeconfig context. Each
+// release is waited on for up to timeout.
+func ApplyCharts(ctx context.Context, providerType ProviderType, clusterName string, charts []config.Chart, timeout time.Duration) error {
+	releases, err := ChartReleases(charts)
+	if err != nil {
+		return err
+	}
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+
+	kubeContext := kube.ContextName(string(providerType), clusterName)
+	for i, release := range releases {
+		fmt.Printf("📦 Applying chart %s as release %s/%s...\n", charts[i], release.Namespace, release.Name)
+
+		revision, err := helm.Upgrade(ctx, kubeContext, release, timeout)
+		if err != nil {
+			return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error applying chart %s on cluster '%s'", charts[i], clusterName).
+				WithHint("retry with 'blitzctl charts apply --provider %s --cluster-name %s'", providerType, clusterName)
+		}
+		fmt.Printf("✅ Release %s/%s deployed (revision %d)\n", release.Namespace, release.Name, revision)
+	}
+	return nil
+}
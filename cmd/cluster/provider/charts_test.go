@@ -0,0 +1,72 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/helm"
+)
+
+func TestChartReleases(t *testing.T) {
+	dir := t.TempDir()
+	valuesFile := filepath.Join(dir, "values.yaml")
+	if err := os.WriteFile(valuesFile, []byte("replicas: 2\nservice:\n  type: NodePort\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	invalidValues := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalidValues, []byte("replicas: [2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		charts   []config.Chart
+		want     []helm.Release
+		wantKind errdefs.Kind
+	}{
+		{name: "no charts", want: []helm.Release{}},
+		{
+			name: "releases in order",
+			charts: []config.Chart{
+				{Repo: "https://charts.jetstack.io", Chart: "cert-manager", Version: "v1.18.2", Namespace: "cert-manager"},
+				{Chart: "oci://ghcr.io/traefik/helm/traefik", Release: "ingress"},
+				{Chart: "./charts/platform", ValuesFile: valuesFile},
+			},
+			want: []helm.Release{
+				{Name: "cert-manager", Namespace: "cert-manager", Repo: "https://charts.jetstack.io", Chart: "cert-manager", Version: "v1.18.2"},
+				{Name: "ingress", Namespace: "default", Chart: "oci://ghcr.io/traefik/helm/traefik"},
+				{
+					Name:      "platform",
+					Namespace: "default",
+					Chart:     "./charts/platform",
+					Values:    map[string]interface{}{"replicas": float64(2), "service": map[string]interface{}{"type": "NodePort"}},
+				},
+			},
+		},
+		{name: "chart missing", charts: []config.Chart{{Repo: "https://charts.jetstack.io"}}, wantKind: errdefs.KindInvalid},
+		{name: "repo with an oci chart", charts: []config.Chart{{Repo: "https://ghcr.io", Chart: "oci://ghcr.io/traefik/helm/traefik"}}, wantKind: errdefs.KindInvalid},
+		{name: "repo not a URL", charts: []config.Chart{{Repo: "charts.jetstack.io", Chart: "cert-manager"}}, wantKind: errdefs.KindInvalid},
+		{name: "invalid release name", charts: []config.Chart{{Chart: "./charts/Platform"}}, wantKind: errdefs.KindInvalid},
+		{name: "invalid namespace", charts: []config.Chart{{Chart: "cert-manager", Namespace: "Cert_Manager"}}, wantKind: errdefs.KindInvalid},
+		{name: "missing values file", charts: []config.Chart{{Chart: "cert-manager", ValuesFile: filepath.Join(dir, "missing.yaml")}}, wantKind: errdefs.KindInvalid},
+		{name: "invalid values file", charts: []config.Chart{{Chart: "cert-manager", ValuesFile: invalidValues}}, wantKind: errdefs.KindInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ChartReleases(tt.charts)
+			if kind := errdefs.KindOf(err); kind != tt.wantKind {
+				t.Fatalf("ChartReleases() error = %v, want kind %q", err, tt.wantKind)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChartReleases() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/command"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/kube"
+)
+
+// Hook events, the name a hook finds in BLITZCTL_HOOK
+const (
+	HookPreCreate  = "pre-create"
+	HookPostCreate = "post-create"
+	HookPreDelete  = "pre-delete"
+	HookPostDelete = "post-delete"
+	HookPostStart  = "post-start"
+)
+
+// RunHooks runs the hooks of event in order, with the cluster described in
+// their environment. The first hook failing with the abort policy stops the
+// others and is returned, the ones with the warn policy are logged.
+func RunHooks(ctx context.Context, event string, providerType ProviderType, clusterName string, hooks []config.Hook) error {
+	for _, hook := range hooks {
+		fmt.Printf("🪝 Running %s hook %s...\n", event, hook.Label())
+
+		err := runHook(ctx, event, providerType, clusterName, hook)
+		if err == nil {
+			continue
+		}
+		if hook.OnFailure == config.HookWarn {
+			slog.Warn(fmt.Sprintf("%s hook %s failed", event, hook.Label()), "error", err)
+			continue
+		}
+		return errdefs.Wrap(errdefs.KindOf(err), err, "❌ %s hook %s failed for cluster '%s'", event, hook.Label(), clusterName).
+			WithHint("set on_failure: warn on the hook to carry on when it fails")
+	}
+	return nil
+}
+
+// runHook runs hook through the shell within its timeout, logging its
+// output line by line
+func runHook(ctx context.Context, event string, providerType ProviderType, clusterName string, hook config.Hook) error {
+	timeout := time.Duration(hook.Timeout)
+	if timeout <= 0 {
+		timeout = time.Duration(config.DefaultHookTimeout)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	hookCmd := command.Context(ctx, shell, flag, hook.Run)
+	hookCmd.Env = append(os.Environ(),
+		"BLITZCTL_HOOK="+event,
+		"BLITZCTL_CLUSTER="+clusterName,
+		"BLITZCTL_PROVIDER="+string(providerType),
+		"BLITZCTL_CONTEXT="+kube.ContextName(string(providerType), clusterName),
+		"KUBECONFIG="+kube.KubeconfigPath(),
+	)
+	// Each stream keeps its own partial line
+	stdout := &hookOutput{attrs: []any{"hook", hook.Label(), "event", event}}
+	stderr := &hookOutput{attrs: []any{"hook", hook.Label(), "event", event, "stream", "stderr"}}
+	hookCmd.Stdout = stdout
+	hookCmd.Stderr = stderr
+
+	err := command.Run(hookCmd)
+	stdout.flush()
+	stderr.flush()
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return errdefs.Wrap(errdefs.KindTimeout, err, "timed out after %s", timeout)
+	}
+	return err
+}
+
+// hookOutput logs what a hook writes, a line per record
+type hookOutput struct {
+	mu      sync.Mutex
+	attrs   []any
+	pending []byte
+}
+
+func (o *hookOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.pending = append(o.pending, p...)
+	for {
+		line, rest, found := bytes.Cut(o.pending, []byte("\n"))
+		if !found {
+			break
+		}
+		slog.Info(string(bytes.TrimRight(line, "\r")), o.attrs...)
+		o.pending = rest
+	}
+	return len(p), nil
+}
+
+// flush logs the last line when it didn't end with a newline
+func (o *hookOutput) flush() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.pending) > 0 {
+		slog.Info(string(o.pending), o.attrs...)
+		o.pending = nil
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// hookOutputFile points $HOOK_OUT at a file the hooks of a test write to
+func hookOutputFile(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are shell commands")
+	}
+	path := filepath.Join(t.TempDir(), "out")
+	t.Setenv("HOOK_OUT", path)
+	return path
+}
+
+// readHookOutput returns what the hooks wrote, empty when none ran
+func readHookOutput(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func TestRunHooks(t *testing.T) {
+	tests := []struct {
+		name         string
+		hooks        []config.Hook
+		want         string
+		wantErr      string
+		wantExitCode int
+	}{
+		{name: "no hooks"},
+		{
+			name:  "run in order",
+			hooks: []config.Hook{{Run: `echo one >> "$HOOK_OUT"`}, {Run: `echo two >> "$HOOK_OUT"`}},
+			want:  "one\ntwo",
+		},
+		{
+			name: "abort stops the others",
+			hooks: []config.Hook{
+				{Run: `echo one >> "$HOOK_OUT"`},
+				{Name: "seed", Run: "exit 3"},
+				{Run: `echo three >> "$HOOK_OUT"`},
+			},
+			want:         "one",
+			wantErr:      "post-create hook seed failed for cluster 'dev'",
+			wantExitCode: 7,
+		},
+		{
+			name: "warn carries on",
+			hooks: []config.Hook{
+				{Name: "seed", Run: "exit 3", OnFailure: config.HookWarn},
+				{Run: `echo two >> "$HOOK_OUT"`},
+			},
+			want: "two",
+		},
+		{
+			name: "timeout",
+			hooks: []config.Hook{
+				{Name: "slow", Run: "exec sleep 5", Timeout: config.Duration(50 * time.Millisecond)},
+				{Run: `echo two >> "$HOOK_OUT"`},
+			},
+			wantErr:      "timed out after 50ms",
+			wantExitCode: 10,
+		},
+		{
+			name: "timeout with warn",
+			hooks: []config.Hook{
+				{Run: "exec sleep 5", Timeout: config.Duration(50 * time.Millisecond), OnFailure: config.HookWarn},
+				{Run: `echo two >> "$HOOK_OUT"`},
+			},
+			want: "two",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := hookOutputFile(t)
+
+			err := RunHooks(context.Background(), HookPostCreate, Kind, "dev", tt.hooks)
+			if (err != nil) != (tt.wantErr != "") {
+				t.Fatalf("RunHooks() error = %v, want %q", err, tt.wantErr)
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("RunHooks() error = %v, want %q", err, tt.wantErr)
+				}
+				if got := errdefs.ExitCode(err); got != tt.wantExitCode {
+					t.Errorf("RunHooks() exit code = %d, want %d", got, tt.wantExitCode)
+				}
+			}
+			if got := readHookOutput(t, out); got != tt.want {
+				t.Errorf("hooks wrote %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunHooksEnvironment(t *testing.T) {
+	out := hookOutputFile(t)
+	t.Setenv("KUBECONFIG", "/tmp/kubeconfig")
+
+	hook := config.Hook{Run: `echo "$BLITZCTL_HOOK $BLITZCTL_CLUSTER $BLITZCTL_PROVIDER $BLITZCTL_CONTEXT $KUBECONFIG" > "$HOOK_OUT"`}
+	if err := RunHooks(context.Background(), HookPreDelete, Minikube, "dev", []config.Hook{hook}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := readHookOutput(t, out), "pre-delete dev minikube dev /tmp/kubeconfig"; got != want {
+		t.Errorf("hook environment = %q, want %q", got, want)
+	}
+}
+
+func TestRunHooksLogsOutput(t *testing.T) {
+	hookOutputFile(t)
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	hook := config.Hook{Name: "seed", Run: `echo created; echo warning >&2; printf partial`}
+	if err := RunHooks(context.Background(), HookPostCreate, Kind, "dev", []config.Hook{hook}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"msg=created hook=seed event=post-create\n",
+		"msg=partial hook=seed event=post-create\n",
+		"msg=warning hook=seed event=post-create stream=stderr\n",
+	} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("logs lack %q:\n%s", want, logs.String())
+		}
+	}
+}
@@ -3,7 +3,12 @@ Copyright © 2025 Oneide Luiz Schneider
 */
 package provider
 
-import "github.com/spf13/cobra"
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+)
 
 // ProviderType represents the type of cluster provider
 type ProviderType string
@@ -28,37 +33,78 @@ type ClusterOptions struct {
 	K8sVersion  string
 }
 
+// DefaultWaitTimeout bounds how long create and start wait for readiness
+const DefaultWaitTimeout = 5 * time.Minute
+
+// WaitOptions control waiting for the cluster to become ready
+type WaitOptions struct {
+	// Wait blocks until nodes are Ready and kube-system workloads available
+	Wait bool
+	// Timeout bounds the wait, DefaultWaitTimeout when zero
+	Timeout time.Duration
+}
+
 type CreateOptions struct {
 	ClusterOptions
+	WaitOptions
+	// SkipPreflight creates the cluster without running the preflight checks
+	SkipPreflight bool
+	// KeepOnFailure leaves a cluster that failed to be created in place for
+	// debugging instead of deleting it
+	KeepOnFailure bool
+	// Nodes is the number of nodes, the control plane included. Zero or one
+	// creates a single node cluster.
+	Nodes int
 	// Provider-specific options will be handled via composition or type assertions
 	ProviderOptions map[string]interface{}
 }
 
+type StartOptions struct {
+	Default
+	WaitOptions
+}
+
 type ListOptions struct {
 	// Currently no specific options needed, but keeping for future extensibility
 }
 
+// BinaryOptions select the provider CLI version to install or upgrade to
+type BinaryOptions struct {
+	// Version of the provider CLI; empty means the configured pin, "latest"
+	// the latest release
+	Version string
+	// BinDir overrides where the provider CLI is installed
+	BinDir string
+}
+
 type UpgradeOptions struct {
 	ClusterOptions
+	BinaryOptions
+	// Rollback restores the binary replaced by the previous upgrade
+	Rollback        bool
 	ProviderOptions map[string]interface{}
 }
 
 type InstallOptions struct {
 	ClusterOptions
+	BinaryOptions
 	ProviderOptions map[string]interface{}
 }
 
 // ClusterProvider defines the interface that all cluster providers must implement
 type ClusterProvider interface {
 	GetProviderType() ProviderType
-	Create(options *CreateOptions) error
-	Delete(options *Default) error
-	List(options *ListOptions) error
-	Upgrade(options *UpgradeOptions) error
-	Install(options *InstallOptions) error
-	Start(options *Default) error
-	Stop(options *Default) error
+	// Cancelling ctx stops the provider CLI and any download in progress
+	Create(ctx context.Context, options *CreateOptions) error
+	Delete(ctx context.Context, options *Default) error
+	List(ctx context.Context, options *ListOptions) error
+	Upgrade(ctx context.Context, options *UpgradeOptions) error
+	Install(ctx context.Context, options *InstallOptions) error
+	Start(ctx context.Context, options *StartOptions) error
+	Stop(ctx context.Context, options *Default) error
 	Validate() error
+	// Preflight checks that a cluster can be created before shelling out
+	Preflight(ctx context.Context, options *CreateOptions) error
 
 	GetCreateCommand() *cobra.Command
 	GetDeleteCommand() *cobra.Command
@@ -4,13 +4,18 @@ Copyright © 2025 Oneide Luiz Schneider
 package provider
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/exec"
-	"runtime"
+	"strings"
 	"time"
 
 	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/command"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
 	"github.com/spf13/cobra"
 	"k8s.io/kubectl/pkg/util/i18n"
 )
@@ -30,44 +35,68 @@ func (p *KindProvider) GetProviderType() ProviderType {
 func (p *KindProvider) Validate() error {
 	_, err := exec.LookPath("kind")
 	if err != nil {
-		return fmt.Errorf("❌ Kind is not installed. Please install Kind to use this command")
+		return errdefs.ToolMissing("❌ Kind is not installed").WithHint("blitzctl install cluster --provider kind")
 	}
 
 	_, err = exec.LookPath("docker")
 	if err != nil {
-		return fmt.Errorf("❌ Docker is not installed. Please install Docker to use this command")
+		return errdefs.ToolMissing("❌ Docker is not installed").WithHint("blitzctl install container --driver docker")
 	}
 
 	return nil
 }
 
-func (p *KindProvider) Create(options *CreateOptions) error {
+func (p *KindProvider) Create(ctx context.Context, options *CreateOptions) error {
 	if err := p.Validate(); err != nil {
 		return err
 	}
 
 	if options.ClusterName == "" {
-		return fmt.Errorf("❌ The Cluster Name is required")
+		return errdefs.Invalid("❌ The Cluster Name is required")
 	}
 
-	createCmd := exec.Command(
-		"kind",
-		"create",
-		"cluster",
-		"--image=kindest/node:v"+options.K8sVersion,
-		"--name="+options.ClusterName,
-	)
+	cni, err := kindCNI(options)
+	if err != nil {
+		return err
+	}
+
+	if !options.SkipPreflight {
+		if err := p.Preflight(ctx, options); err != nil {
+			return err
+		}
+	}
+
+	// Only a cluster this create brings into existence is rolled back
+	existed := clusterExists(ctx, options.ClusterName, kindClusters)
+
+	// Use the digest-pinned node image from the project lockfile when it matches
+	image := "kindest/node:v" + options.K8sVersion
+	if lock, err := toolchain.LoadProjectLockfile(); err != nil {
+		slog.Warn("Failed to read lockfile", "error", err)
+	} else if locked := lock.KindImage(options.K8sVersion); locked != "" {
+		image = locked
+	}
+
+	args := []string{"create", "cluster", "--image=" + image, "--name=" + options.ClusterName}
+	if clusterConfig := kindClusterConfig(options, cni); clusterConfig != nil {
+		configPath, cleanup, err := writeKindClusterConfig(clusterConfig)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		args = append(args, "--config="+configPath)
+	}
+	createCmd := command.Context(ctx, "kind", args...)
 
 	// Set up real-time output streaming
 	createCmd.Stdout = os.Stdout
 	createCmd.Stderr = os.Stderr
 
-	fmt.Printf("Debug: Running command: %s\n", createCmd.String())
-	fmt.Printf("Debug: Command Args: %v\n", createCmd.Args)
 	fmt.Printf("🔄 Running...\n")
+	fmt.Printf("🔌 CNI: %s\n", cni)
 
-	if err := createCmd.Run(); err != nil {
-		return fmt.Errorf("❌ Error creating Kind cluster: %v", err)
+	if err := command.Run(createCmd); err != nil {
+		return rollbackCreate(p, options, existed, fmt.Errorf("❌ Error creating Kind cluster: %w", err))
 	}
 
 	fmt.Printf("✅ Kind cluster '%s' created successfully\n", options.ClusterName)
@@ -78,9 +107,12 @@ func (p *KindProvider) Create(options *CreateOptions) error {
 		Name:       options.ClusterName,
 		Provider:   string(Kind),
 		K8sVersion: options.K8sVersion,
-		Status:     "running",
+		CNI:        cni,
+		Status:     StatusRunning,
 		CreatedAt:  time.Now(),
 		Options:    make(map[string]string),
+		Profile:    configManager.ActiveProfile(),
+		Nodes:      options.Nodes,
 	}
 
 	// Add provider-specific options to the cluster info
@@ -93,22 +125,76 @@ func (p *KindProvider) Create(options *CreateOptions) error {
 	}
 
 	if err := configManager.AddCluster(clusterInfo); err != nil {
-		fmt.Printf("⚠️ Warning: Failed to save cluster information: %v\n", err)
+		slog.Warn("Failed to save cluster information", "error", err)
+	}
+
+	waitOptions := options.WaitOptions
+	if cni == kindNoCNI {
+		fmt.Printf("⚠️ No CNI installed, nodes stay NotReady until one is\n")
+	} else if cni != kindDefaultCNI {
+		if err := installKindCNI(ctx, options.ClusterName, cni, waitOptions.Timeout); err != nil {
+			return rollbackCreate(p, options, existed, err)
+		}
+		// The CNI only works once every node is Ready
+		waitOptions.Wait = true
 	}
 
+	if err := waitReady(ctx, Kind, options.ClusterName, waitOptions); err != nil {
+		return rollbackUnready(ctx, p, options, existed, err)
+	}
 	return nil
 }
 
-func (p *KindProvider) Delete(options *Default) error {
+func (p *KindProvider) Preflight(ctx context.Context, options *CreateOptions) error {
+	return runPreflight(ctx, []preflightCheck{
+		checkClusterName(options.ClusterName),
+		checkNotTracked(options.ClusterName, Kind),
+		checkEngine(kindEngine()),
+		checkNotExisting(options.ClusterName, Kind, kindClusters),
+		checkK8sVersion(options.K8sVersion, kindNodeImageExists),
+		checkDoctor("memory"),
+		checkDoctor("disk"),
+	})
+}
+
+// kindEngine returns the container engine kind runs nodes on
+func kindEngine() string {
+	if os.Getenv("KIND_EXPERIMENTAL_PROVIDER") == string(Podman) {
+		return string(Podman)
+	}
+	return string(Docker)
+}
+
+// kindClusters returns the names of the existing kind clusters
+func kindClusters(ctx context.Context) ([]string, error) {
+	output, err := command.Output(exec.CommandContext(ctx, "kind", "get", "clusters"))
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// kindNodeImageExists checks a kind node image is published for version,
+// trusting the project lockfile when it pins one
+func kindNodeImageExists(ctx context.Context, version string) error {
+	if lock, err := toolchain.LoadProjectLockfile(); err == nil && lock.KindImage(version) != "" {
+		return nil
+	}
+	_, err := preflightInstaller().ResolveKindNodeImage(ctx, version)
+	return err
+}
+
+func (p *KindProvider) Delete(ctx context.Context, options *Default) error {
 	if err := p.Validate(); err != nil {
 		return err
 	}
 
 	if options.ClusterName == "" {
-		return fmt.Errorf("❌ The ClusterName is required")
+		return errdefs.Invalid("❌ The Cluster Name is required")
 	}
 
-	deleteCmd := exec.Command(
+	deleteCmd := command.Context(
+		ctx,
 		"kind",
 		"delete",
 		"cluster",
@@ -118,12 +204,10 @@ func (p *KindProvider) Delete(options *Default) error {
 	deleteCmd.Stdout = os.Stdout
 	deleteCmd.Stderr = os.Stderr
 
-	fmt.Printf("Debug: Delete command: %s\n", deleteCmd.String())
-	fmt.Printf("Debug: Command Args: %v\n", deleteCmd.Args)
 	fmt.Printf("🔄 Deleting...\n")
 
-	if err := deleteCmd.Run(); err != nil {
-		return fmt.Errorf("❌ Error deleting Kind cluster: %v", err)
+	if err := command.Run(deleteCmd); err != nil {
+		return fmt.Errorf("❌ Error deleting Kind cluster: %w", err)
 	}
 
 	fmt.Printf("✅ Kind cluster '%s' deleted successfully\n", options.ClusterName)
@@ -131,18 +215,22 @@ func (p *KindProvider) Delete(options *Default) error {
 	// Remove cluster information from config
 	configManager := config.GetManager()
 	if err := configManager.RemoveCluster(options.ClusterName, string(Kind)); err != nil {
-		fmt.Printf("⚠️ Warning: Failed to remove cluster from configuration: %v\n", err)
+		if errdefs.Is(err, errdefs.KindNotFound) {
+			slog.Debug("cluster not tracked in configuration", "cluster", options.ClusterName)
+		} else {
+			slog.Warn("Failed to remove cluster from configuration", "error", err)
+		}
 	}
 
 	return nil
 }
 
-func (p *KindProvider) Start(options *Default) error {
-	return fmt.Errorf("❌ kind doesn't support cluster start. Please delete and recreate the cluster")
+func (p *KindProvider) Start(ctx context.Context, options *StartOptions) error {
+	return errdefs.Unsupported("❌ kind doesn't support cluster start. Please delete and recreate the cluster")
 }
 
-func (p *KindProvider) Stop(options *Default) error {
-	return fmt.Errorf("❌ kind doesn't support cluster stop. Please delete and recreate the cluster")
+func (p *KindProvider) Stop(ctx context.Context, options *Default) error {
+	return errdefs.Unsupported("❌ kind doesn't support cluster stop. Please delete and recreate the cluster")
 }
 
 func (p *KindProvider) GetStartCommand() *cobra.Command {
@@ -153,7 +241,7 @@ func (p *KindProvider) GetStartCommand() *cobra.Command {
 		Example: `blitzctl start cluster --provider kind`,
 		Aliases: []string{"kind", "k"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return p.Start(&Default{})
+			return p.Start(cmd.Context(), &StartOptions{})
 		},
 	}
 }
@@ -166,119 +254,40 @@ func (p *KindProvider) GetStopCommand() *cobra.Command {
 		Example: `blitzctl stop cluster --provider kind`,
 		Aliases: []string{"kind", "k"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return p.Stop(&Default{})
+			return p.Stop(cmd.Context(), &Default{})
 		},
 	}
 }
 
-func (p *KindProvider) List(options *ListOptions) error {
+func (p *KindProvider) List(ctx context.Context, options *ListOptions) error {
 	if err := p.Validate(); err != nil {
 		return err
 	}
 
-	getCmd := exec.Command("kind", "get", "clusters")
+	getCmd := command.Context(ctx, "kind", "get", "clusters")
 	getCmd.Stdout = os.Stdout
 	getCmd.Stderr = os.Stderr
 
-	if err := getCmd.Run(); err != nil {
-		return fmt.Errorf("❌ Error listing Kind clusters: %v", err)
+	if err := command.Run(getCmd); err != nil {
+		return fmt.Errorf("❌ Error listing Kind clusters: %w", err)
 	}
 
 	return nil
 }
 
-func (p *KindProvider) Upgrade(options *UpgradeOptions) error {
-	_, err := exec.LookPath("kind")
-	if err != nil {
-		fmt.Println("❌ kind is not installed. Please install kind to use this command.")
-		os.Exit(1)
-	}
-	switch runtime.GOOS {
-	case "darwin":
-		upgradeCmd := exec.Command("brew", "upgrade", "kind")
-		// Set up real-time output streaming
-		upgradeCmd.Stdout = os.Stdout
-		upgradeCmd.Stderr = os.Stderr
-		if err := upgradeCmd.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "❗Error Upgrading kind\n")
-			os.Exit(1)
-		}
-	case "linux":
-		upgradeCmd := exec.Command("sh", "-c", `
-            curl -Lo /usr/local/bin/kind https://kind.sigs.k8s.io/dl/latest/kind-linux-amd64 &&
-            chmod +x /usr/local/bin/kind
-        `)
-		// Set up real-time output streaming
-		upgradeCmd.Stdout = os.Stdout
-		upgradeCmd.Stderr = os.Stderr
-		if err := upgradeCmd.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "❗Error Upgrading kind\n")
-			os.Exit(1)
-		}
-	case "windows":
-		fmt.Println("❌ Running on an unsupported OS")
-		os.Exit(1)
-	default:
-		fmt.Println("❌ Running on an unsupported OS")
-		os.Exit(1)
-	}
-	fmt.Printf("✅ kind Upgraded successfully!")
-
-	return nil
+func (p *KindProvider) Upgrade(ctx context.Context, options *UpgradeOptions) error {
+	return upgradeBinary(ctx, string(Kind), options)
 }
 
-func (p *KindProvider) Install(options *InstallOptions) error {
-	_, err := exec.LookPath("docker")
-	if err != nil {
-		fmt.Println("❌ Docker is not installed. Please install Docker to use this command.")
-		os.Exit(1)
+func (p *KindProvider) Install(ctx context.Context, options *InstallOptions) error {
+	if _, err := exec.LookPath("docker"); err != nil {
+		slog.Warn("Docker is not installed, kind needs Docker to create clusters")
 	}
-
-	switch runtime.GOOS {
-	case "darwin":
-		fmt.Println("Installing kind on macOS...")
-		fmt.Println("Please make sure you have Brew installed.")
-		fmt.Println("You can install Brew by running the following command:")
-		fmt.Println("https://brew.sh/")
-		// Check if Minikube is installed
-		_, err := exec.LookPath("brew")
-		if err != nil {
-			fmt.Println("❌ Brew is not installed. Please install Brew to use this command.")
-			os.Exit(1)
-		}
-		getCmd := exec.Command("brew", "install", "kind")
-		output, err := getCmd.Output()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error installing kind: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Println("Installing kind...")
-		fmt.Println(string(output))
-	case "linux":
-		fmt.Println("Installing kind on Linux...")
-		fmt.Println("Please make sure you have curl installed.")
-		fmt.Println("You can install curl by running the following command:")
-		fmt.Println("sudo apt-get install curl")
-		getCmd := exec.Command("curl", "-Lo", "/usr/local/bin/kind", "https://kind.sigs.k8s.io/dl/v0.11.0/kind-$(uname)-amd64")
-		output, err := getCmd.Output()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error installing kind: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Println(string(output))
-	case "windows":
-		fmt.Println("❌ Running on an unsupported OS")
-	default:
-		fmt.Println("❌ Running on an unsupported OS")
-	}
-
-	return nil
+	return installBinary(ctx, string(Kind), options)
 }
 
 // Command builders - these create cobra commands that use the provider
 func (p *KindProvider) GetCreateCommand() *cobra.Command {
-	var clusterName, k8sVersion string
-
 	cmd := &cobra.Command{
 		Use:     "kind",
 		Short:   "Create a kind cluster",
@@ -286,29 +295,28 @@ func (p *KindProvider) GetCreateCommand() *cobra.Command {
 		Example: `blitzctl create cluster --provider kind --cluster-name=mycluster`,
 		Aliases: []string{"kind", "k"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defaults := config.GetManager().GetDefaults()
 			options := &CreateOptions{
 				ClusterOptions: ClusterOptions{
-					ClusterName: clusterName,
-					K8sVersion:  k8sVersion,
+					ClusterName: defaults.ClusterName,
+					K8sVersion:  defaults.K8sVersion,
 				},
 			}
-			return p.Create(options)
+			return p.Create(cmd.Context(), options)
 		},
 	}
 
-	cmd.Flags().StringVar(&clusterName, "cluster-name", config.DefaultClusterName, i18n.T("Cluster Name."))
-	cmd.Flags().StringVar(&k8sVersion, "k8s-version", config.DefaultK8sVersion, i18n.T("K8s Version."))
-
-	if err := cmd.MarkFlagRequired("cluster-name"); err != nil {
-		panic(fmt.Sprintf("❌ Failed to mark 'cluster-name' flag as required: %v", err))
-	}
+	cmd.Flags().String("cluster-name", "", i18n.T("Cluster Name (default: the configured cluster_name)."))
+	config.BindFlag(cmd.Flags().Lookup("cluster-name"), "cluster_name")
+	cmd.Flags().String("k8s-version", "", i18n.T("K8s Version (default: the configured k8s_version)."))
+	config.BindFlag(cmd.Flags().Lookup("k8s-version"), "k8s_version")
+	cmd.Flags().String("cni", "", i18n.T("CNI: kindnet, cilium, calico, flannel, none or a manifest path (default: the configured cni)."))
+	config.BindFlag(cmd.Flags().Lookup("cni"), "cni")
 
 	return cmd
 }
 
 func (p *KindProvider) GetDeleteCommand() *cobra.Command {
-	var clusterName string
-
 	cmd := &cobra.Command{
 		Use:     "kind",
 		Short:   "Delete a kind cluster",
@@ -316,18 +324,16 @@ func (p *KindProvider) GetDeleteCommand() *cobra.Command {
 		Example: `blitzctl delete cluster --provider kind --cluster-name=mycluster`,
 		Aliases: []string{"kind", "k"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defaults := config.GetManager().GetDefaults()
 			options := &Default{
-				ClusterName: clusterName,
+				ClusterName: defaults.ClusterName,
 			}
-			return p.Delete(options)
+			return p.Delete(cmd.Context(), options)
 		},
 	}
 
-	cmd.Flags().StringVar(&clusterName, "cluster-name", config.DefaultClusterName, i18n.T("Cluster Name."))
-
-	if err := cmd.MarkFlagRequired("cluster-name"); err != nil {
-		panic(fmt.Sprintf("❌ Failed to mark 'cluster-name' flag as required: %v", err))
-	}
+	cmd.Flags().String("cluster-name", "", i18n.T("Cluster Name (default: the configured cluster_name)."))
+	config.BindFlag(cmd.Flags().Lookup("cluster-name"), "cluster_name")
 
 	return cmd
 }
@@ -341,7 +347,7 @@ func (p *KindProvider) GetListCommand() *cobra.Command {
 		Aliases: []string{"kind", "k"},
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return p.List(&ListOptions{})
+			return p.List(cmd.Context(), &ListOptions{})
 		},
 	}
 }
@@ -354,7 +360,7 @@ func (p *KindProvider) GetUpgradeCommand() *cobra.Command {
 		Example: `blitzctl upgrade cluster --provider kind`,
 		Aliases: []string{"kind", "k"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return p.Upgrade(&UpgradeOptions{})
+			return p.Upgrade(cmd.Context(), &UpgradeOptions{})
 		},
 	}
 }
@@ -367,7 +373,7 @@ func (p *KindProvider) GetInstallCommand() *cobra.Command {
 		Example: `blitzctl install cluster --provider kind`,
 		Aliases: []string{"kind", "k"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return p.Install(&InstallOptions{})
+			return p.Install(cmd.Context(), &InstallOptions{})
 		},
 	}
 }
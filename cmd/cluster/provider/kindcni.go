@@ -0,0 +1,102 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/addons"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/kube"
+)
+
+const (
+	// kindDefaultCNI is the CNI kind installs itself
+	kindDefaultCNI = "kindnet"
+	// kindNoCNI leaves a kind cluster without a CNI
+	kindNoCNI = "none"
+)
+
+// kindCNI resolves the cni option of a kind cluster, falling back to the
+// configured CNI. The built-in default is minikube's, without a CNI
+// configured kind keeps kindnet, its own minimal CNI, so a plain cluster
+// downloads nothing. auto and bridge keep kindnet too, false and none leave
+// the cluster without a CNI.
+func kindCNI(options *CreateOptions) (string, error) {
+	cni := kindDefaultCNI
+	if manager := config.GetManager(); manager.Configured("cni") {
+		cni = manager.GetDefaults().CNI
+	}
+	if c, ok := options.ProviderOptions["cni"].(string); ok && c != "" {
+		cni = c
+	}
+
+	switch cni {
+	case "", "auto", "bridge", kindDefaultCNI:
+		return kindDefaultCNI, nil
+	case "false", kindNoCNI:
+		return kindNoCNI, nil
+	}
+	if _, err := addons.LookupCNI(cni); err == nil {
+		return cni, nil
+	}
+	if strings.ContainsRune(cni, os.PathSeparator) {
+		if _, err := os.Stat(cni); err != nil {
+			return "", errdefs.Wrap(errdefs.KindNotFound, err, "❌ CNI manifest %s not found", cni)
+		}
+		return cni, nil
+	}
+	return "", errdefs.Unsupported("❌ Unsupported CNI for kind: %s (supported: %s, %s, %s or a path to a manifest)",
+		cni, kindDefaultCNI, strings.Join(addons.CNINames(), ", "), kindNoCNI)
+}
+
+// installKindCNI installs cni, a registered CNI or a manifest file, on a kind
+// cluster created without kindnet
+func installKindCNI(ctx context.Context, clusterName, cni string, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+	kubeContext := kube.ContextName(string(Kind), clusterName)
+
+	var err error
+	if addon, lookupErr := addons.LookupCNI(cni); lookupErr == nil {
+		fmt.Printf("🔌 Installing CNI %s %s (%s)...\n", addon.Name, addon.Version, addon.Method())
+		err = addon.Install(ctx, kubeContext, timeout)
+	} else {
+		fmt.Printf("🔌 Installing CNI from %s...\n", cni)
+		err = applyManifestFile(ctx, kubeContext, cni)
+	}
+	if err != nil {
+		return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error installing CNI %s on cluster '%s'", cni, clusterName)
+	}
+	return nil
+}
+
+// applyManifestFile server-side applies the objects of a manifest file
+func applyManifestFile(ctx context.Context, kubeContext, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	objects, err := kube.DecodeManifests(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	applier, err := kube.NewApplier(kubeContext)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		if err := applier.Apply(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+)
+
+func TestKindCNI(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "cni.yaml")
+	if err := os.WriteFile(manifest, []byte("kind: List\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		env     string
+		option  string
+		want    string
+		wantErr bool
+	}{
+		{name: "built-in default keeps kindnet", want: kindDefaultCNI},
+		{name: "configured CNI", env: "calico", want: "calico"},
+		{name: "option over the configured CNI", env: "calico", option: "flannel", want: "flannel"},
+		{name: "auto keeps kindnet", option: "auto", want: kindDefaultCNI},
+		{name: "bridge keeps kindnet", env: "bridge", want: kindDefaultCNI},
+		{name: "false leaves none", option: "false", want: kindNoCNI},
+		{name: "none", option: "none", want: kindNoCNI},
+		{name: "manifest path", option: manifest, want: manifest},
+		{name: "missing manifest", option: filepath.Join(filepath.Dir(manifest), "missing.yaml"), wantErr: true},
+		{name: "unknown CNI", option: "weave", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv(config.EnvVar("cni"), tt.env)
+			}
+			options := &CreateOptions{}
+			if tt.option != "" {
+				options.ProviderOptions = map[string]interface{}{"cni": tt.option}
+			}
+
+			got, err := kindCNI(options)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("kindCNI() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("kindCNI() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"gopkg.in/yaml.v3"
+)
+
+// kindCluster is the subset of the kind cluster configuration
+// (kind.x-k8s.io/v1alpha4) blitzctl generates
+type kindCluster struct {
+	Kind       string          `yaml:"kind"`
+	APIVersion string          `yaml:"apiVersion"`
+	Networking *kindNetworking `yaml:"networking,omitempty"`
+	Nodes      []kindNode      `yaml:"nodes"`
+}
+
+type kindNetworking struct {
+	DisableDefaultCNI bool `yaml:"disableDefaultCNI,omitempty"`
+}
+
+type kindNode struct {
+	Role  string `yaml:"role"`
+	Image string `yaml:"image,omitempty"`
+}
+
+// kindClusterConfig returns the kind cluster configuration for options and
+// the resolved cni, nil when kind's defaults fit
+func kindClusterConfig(options *CreateOptions, cni string) *kindCluster {
+	if options.Nodes <= 1 && cni == kindDefaultCNI {
+		return nil
+	}
+
+	cluster := &kindCluster{
+		Kind:       "Cluster",
+		APIVersion: "kind.x-k8s.io/v1alpha4",
+		Nodes:      []kindNode{{Role: "control-plane"}},
+	}
+	if cni != kindDefaultCNI {
+		// kindnet is left out, the CNI is installed once the cluster is up
+		cluster.Networking = &kindNetworking{DisableDefaultCNI: true}
+	}
+	for range options.Nodes - 1 {
+		cluster.Nodes = append(cluster.Nodes, kindNode{Role: "worker"})
+	}
+	return cluster
+}
+
+// writeKindClusterConfig writes cluster to a temporary file for kind
+// create cluster --config, returning its path and the function removing it
+func writeKindClusterConfig(cluster *kindCluster) (string, func(), error) {
+	data, err := yaml.Marshal(cluster)
+	if err != nil {
+		return "", nil, errdefs.Wrap(errdefs.KindUnknown, err, "❌ Failed to encode the kind cluster configuration")
+	}
+
+	f, err := os.CreateTemp("", "blitzctl-kind-*.yaml")
+	if err != nil {
+		return "", nil, errdefs.Wrap(errdefs.KindUnknown, err, "❌ Failed to write the kind cluster configuration")
+	}
+	cleanup := func() {
+		if err := os.Remove(f.Name()); err != nil {
+			slog.Debug("Failed to remove the kind cluster configuration", "file", f.Name(), "error", err)
+		}
+	}
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		cleanup()
+		return "", nil, errdefs.Wrap(errdefs.KindUnknown, err, "❌ Failed to write the kind cluster configuration")
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, errdefs.Wrap(errdefs.KindUnknown, err, "❌ Failed to write the kind cluster configuration")
+	}
+	return f.Name(), cleanup, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/command"
+	"github.com/OneideLuizSchneider/blitzctl/internal/container"
+	"gopkg.in/yaml.v3"
+)
+
+// kindSnapshotConfigFile is the kind cluster configuration recorded with a
+// snapshot, its nodes using the committed images
+const kindSnapshotConfigFile = "kind-config.yaml"
+
+// SnapshotNodes implements SnapshotProvider with the node containers kind
+// labels with the cluster name
+func (p *KindProvider) SnapshotNodes(ctx context.Context, clusterName string) (string, []string, error) {
+	if err := p.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	output, err := command.Output(command.Context(ctx, "kind", "get", "nodes", "--name="+clusterName))
+	if err != nil {
+		return "", nil, err
+	}
+	var nodes []string
+	for _, line := range strings.Split(string(output), "\n") {
+		// kind reports a cluster without nodes on stderr
+		if node := strings.TrimSpace(line); node != "" {
+			nodes = append(nodes, node)
+		}
+	}
+	slices.SortStableFunc(nodes, func(a, b string) int {
+		return kindNodeRank(a) - kindNodeRank(b)
+	})
+	return kindEngine(), nodes, nil
+}
+
+// kindNodeRank orders the control plane before the workers
+func kindNodeRank(node string) int {
+	if strings.Contains(node, "-control-plane") {
+		return 0
+	}
+	return 1
+}
+
+// SuspendCluster implements SnapshotProvider by stopping the node containers
+func (p *KindProvider) SuspendCluster(ctx context.Context, clusterName string) error {
+	engine, nodes, err := p.SnapshotNodes(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	return container.New(engine).Stop(ctx, nodes...)
+}
+
+// ResumeCluster implements SnapshotProvider by starting the node containers
+// and exporting the kubeconfig of the cluster again
+func (p *KindProvider) ResumeCluster(ctx context.Context, clusterName string) error {
+	engine, nodes, err := p.SnapshotNodes(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	if err := container.New(engine).Start(ctx, nodes...); err != nil {
+		return err
+	}
+	_, err = command.Output(command.Context(ctx, "kind", "export", "kubeconfig", "--name="+clusterName))
+	return err
+}
+
+// SaveSnapshotFiles implements SnapshotProvider. kind keeps everything in the
+// nodes, the cluster configuration is recorded so the snapshot's images can
+// also seed a fresh cluster with kind create cluster --config.
+func (p *KindProvider) SaveSnapshotFiles(snapshot *config.SnapshotInfo) error {
+	cluster := &kindCluster{Kind: "Cluster", APIVersion: "kind.x-k8s.io/v1alpha4"}
+	if cni := snapshot.Cluster.CNI; cni != "" && cni != kindDefaultCNI {
+		cluster.Networking = &kindNetworking{DisableDefaultCNI: true}
+	}
+	for _, node := range snapshot.Nodes {
+		role := "worker"
+		if kindNodeRank(node.Name) == 0 {
+			role = "control-plane"
+		}
+		cluster.Nodes = append(cluster.Nodes, kindNode{Role: role, Image: node.Image})
+	}
+
+	data, err := yaml.Marshal(cluster)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(snapshot.Dir, kindSnapshotConfigFile), data, 0o644)
+}
+
+// RestoreSnapshotFiles implements SnapshotProvider, kind has nothing outside
+// the nodes to restore
+func (p *KindProvider) RestoreSnapshotFiles(snapshot *config.SnapshotInfo) error {
+	return nil
+}
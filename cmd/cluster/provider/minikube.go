@@ -4,13 +4,20 @@ Copyright © 2025 Oneide Luiz Schneider
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/exec"
-	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/addons"
+	"github.com/OneideLuizSchneider/blitzctl/internal/command"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
 	"github.com/spf13/cobra"
 	"k8s.io/kubectl/pkg/util/i18n"
 )
@@ -30,59 +37,55 @@ func (p *MinikubeProvider) GetProviderType() ProviderType {
 func (p *MinikubeProvider) Validate() error {
 	_, err := exec.LookPath("minikube")
 	if err != nil {
-		return fmt.Errorf("❌ Minikube is not installed. Please install Minikube to use this command")
+		return errdefs.ToolMissing("❌ Minikube is not installed").WithHint("blitzctl install cluster --provider minikube")
 	}
 	return nil
 }
 
-func (p *MinikubeProvider) Create(options *CreateOptions) error {
+func (p *MinikubeProvider) Create(ctx context.Context, options *CreateOptions) error {
 	if err := p.Validate(); err != nil {
 		return err
 	}
 
 	if options.ClusterName == "" {
-		return fmt.Errorf("❌ The Cluster Name is required")
+		return errdefs.Invalid("❌ The Cluster Name is required")
 	}
 
-	// Get configuration manager for defaults
 	configManager := config.GetManager()
-	defaults := configManager.GetDefaults()
+	driver, cni := minikubeDriverAndCNI(options)
 
-	// Extract minikube-specific options from ProviderOptions with config defaults
-	driver := defaults.Driver
-	cni := defaults.CNI
-	if options.ProviderOptions != nil {
-		if d, ok := options.ProviderOptions["driver"].(string); ok && d != "" {
-			driver = d
-		}
-		if c, ok := options.ProviderOptions["cni"].(string); ok && c != "" {
-			cni = c
-		}
+	if driver == "" {
+		return errdefs.Invalid("❌ The Driver is required")
 	}
 
-	if driver == "" {
-		return fmt.Errorf("❌ The Driver is required")
+	if !options.SkipPreflight {
+		if err := p.Preflight(ctx, options); err != nil {
+			return err
+		}
 	}
 
-	createCmd := exec.Command(
-		"minikube",
+	// Only a profile this create brings into existence is rolled back,
+	// minikube start happily reuses an existing one
+	existed := clusterExists(ctx, options.ClusterName, minikubeProfiles)
+
+	args := []string{
 		"start",
-		"--profile="+options.ClusterName,
-		"--driver="+driver,
-		"--kubernetes-version="+options.K8sVersion,
+		"--profile=" + options.ClusterName,
+		"--driver=" + driver,
+		"--kubernetes-version=" + options.K8sVersion,
 		"--extra-config=kubelet.max-pods=100",
-		"--cni="+cni,
-	)
+		"--cni=" + cni,
+	}
+	args = append(args, minikubeShapeArgs(options)...)
+	createCmd := command.Context(ctx, "minikube", args...)
 
 	createCmd.Stdout = os.Stdout
 	createCmd.Stderr = os.Stderr
 
-	fmt.Printf("Debug: Running command: %s\n", createCmd.String())
-	fmt.Printf("Debug: Command Args: %v\n", createCmd.Args)
 	fmt.Printf("🔄 Running...\n")
 
-	if err := createCmd.Run(); err != nil {
-		return fmt.Errorf("❌ Error creating minikube cluster: %v", err)
+	if err := command.Run(createCmd); err != nil {
+		return rollbackCreate(p, options, existed, fmt.Errorf("❌ Error creating minikube cluster: %w", err))
 	}
 
 	fmt.Printf("✅ Minikube cluster '%s' created successfully with %s and %s\n", options.ClusterName, options.K8sVersion, driver)
@@ -93,39 +96,154 @@ func (p *MinikubeProvider) Create(options *CreateOptions) error {
 		Name:       options.ClusterName,
 		Provider:   string(Minikube),
 		K8sVersion: options.K8sVersion,
-		Status:     "running",
+		Status:     StatusRunning,
 		CreatedAt:  time.Now(),
 		Driver:     driver,
 		CNI:        cni,
 		Options:    make(map[string]string),
+		Profile:    configManager.ActiveProfile(),
+		Nodes:      options.Nodes,
 	}
 
 	// Add provider-specific options to the cluster info
 	if options.ProviderOptions != nil {
 		for k, v := range options.ProviderOptions {
-			if str, ok := v.(string); ok {
-				clusterInfo.Options[k] = str
+			switch v := v.(type) {
+			case string:
+				clusterInfo.Options[k] = v
+			case int:
+				if v > 0 {
+					clusterInfo.Options[k] = strconv.Itoa(v)
+				}
 			}
 		}
 	}
+	if extraConfig, ok := options.ProviderOptions["extra_config"].([]string); ok {
+		clusterInfo.ExtraConfig = extraConfig
+	}
 
 	if err := configManager.AddCluster(clusterInfo); err != nil {
-		fmt.Printf("⚠️ Warning: Failed to save cluster information: %v\n", err)
+		slog.Warn("Failed to save cluster information", "error", err)
 	}
 
+	if err := waitReady(ctx, Minikube, options.ClusterName, options.WaitOptions); err != nil {
+		return rollbackUnready(ctx, p, options, existed, err)
+	}
 	return nil
 }
 
-func (p *MinikubeProvider) Delete(options *Default) error {
+func (p *MinikubeProvider) Preflight(ctx context.Context, options *CreateOptions) error {
+	driver, _ := minikubeDriverAndCNI(options)
+
+	checks := []preflightCheck{
+		checkClusterName(options.ClusterName),
+		checkNotTracked(options.ClusterName, Minikube),
+		checkNotExisting(options.ClusterName, Minikube, minikubeProfiles),
+		checkK8sVersion(options.K8sVersion, k8sReleaseExists),
+	}
+
+	switch driver {
+	case string(Docker), string(Podman):
+		checks = append(checks, checkEngine(driver))
+	case "none":
+		// The none driver runs the control plane directly on the host
+		checks = append(checks, checkPorts(minikubeHostPorts))
+	}
+
+	checks = append(checks, checkDoctor("memory"), checkDoctor("disk"))
+	return runPreflight(ctx, checks)
+}
+
+// minikubeHostPorts are bound on the host by the none driver
+var minikubeHostPorts = []int{8443, 10250}
+
+// minikubeDriverAndCNI extracts the minikube-specific options from
+// ProviderOptions, falling back to the configured defaults
+func minikubeDriverAndCNI(options *CreateOptions) (string, string) {
+	defaults := config.GetManager().GetDefaults()
+
+	driver := defaults.Driver
+	cni := defaults.CNI
+	if options.ProviderOptions != nil {
+		if d, ok := options.ProviderOptions["driver"].(string); ok && d != "" {
+			driver = d
+		}
+		if c, ok := options.ProviderOptions["cni"].(string); ok && c != "" {
+			cni = c
+		}
+	}
+	if cni == "none" {
+		// minikube spells it false
+		cni = "false"
+	}
+	return driver, cni
+}
+
+// minikubeShapeArgs returns the minikube start flags for the node count,
+// resources and component configuration of options
+func minikubeShapeArgs(options *CreateOptions) []string {
+	var args []string
+	if options.Nodes > 1 {
+		args = append(args, fmt.Sprintf("--nodes=%d", options.Nodes))
+	}
+	if cpus, ok := options.ProviderOptions["cpus"].(int); ok && cpus > 0 {
+		args = append(args, fmt.Sprintf("--cpus=%d", cpus))
+	}
+	if memory, ok := options.ProviderOptions["memory"].(string); ok && memory != "" {
+		args = append(args, "--memory="+memory)
+	}
+	if extraConfig, ok := options.ProviderOptions["extra_config"].([]string); ok {
+		for _, extra := range extraConfig {
+			args = append(args, "--extra-config="+extra)
+		}
+	}
+	return args
+}
+
+// minikubeProfiles returns the names of the existing minikube profiles
+func minikubeProfiles(ctx context.Context) ([]string, error) {
+	// minikube exits non-zero, still printing JSON, when no profile exists yet
+	output, runErr := command.Output(exec.CommandContext(ctx, "minikube", "profile", "list", "--output=json"))
+
+	var profiles struct {
+		Valid   []struct{ Name string } `json:"valid"`
+		Invalid []struct{ Name string } `json:"invalid"`
+	}
+	if err := json.Unmarshal(output, &profiles); err != nil {
+		if runErr != nil {
+			return nil, runErr
+		}
+		return nil, fmt.Errorf("failed to parse minikube profiles: %w", err)
+	}
+
+	var names []string
+	for _, profile := range append(profiles.Valid, profiles.Invalid...) {
+		names = append(names, profile.Name)
+	}
+	return names, nil
+}
+
+// k8sReleaseExists checks version was released upstream, minikube downloads
+// the Kubernetes binaries from the same location as kubectl
+func k8sReleaseExists(ctx context.Context, version string) error {
+	kubectl, err := toolchain.Lookup("kubectl")
+	if err != nil {
+		return err
+	}
+	return preflightInstaller().Available(ctx, kubectl, version)
+}
+
+func (p *MinikubeProvider) Delete(ctx context.Context, options *Default) error {
 	if err := p.Validate(); err != nil {
 		return err
 	}
 
 	if options.ClusterName == "" {
-		return fmt.Errorf("❌ The ClusterName is required")
+		return errdefs.Invalid("❌ The Cluster Name is required")
 	}
 
-	deleteCmd := exec.Command(
+	deleteCmd := command.Context(
+		ctx,
 		"minikube",
 		"delete",
 		"--profile="+options.ClusterName,
@@ -134,12 +252,10 @@ func (p *MinikubeProvider) Delete(options *Default) error {
 	deleteCmd.Stdout = os.Stdout
 	deleteCmd.Stderr = os.Stderr
 
-	fmt.Printf("Debug: Delete command: %s\n", deleteCmd.String())
-	fmt.Printf("Debug: Command Args: %v\n", deleteCmd.Args)
 	fmt.Printf("🔄 Deleting...\n")
 
-	if err := deleteCmd.Run(); err != nil {
-		return fmt.Errorf("❌ Error deleting minikube cluster: %v", err)
+	if err := command.Run(deleteCmd); err != nil {
+		return fmt.Errorf("❌ Error deleting minikube cluster: %w", err)
 	}
 
 	fmt.Printf("✅ Minikube cluster '%s' deleted successfully\n", options.ClusterName)
@@ -147,22 +263,26 @@ func (p *MinikubeProvider) Delete(options *Default) error {
 	// Remove cluster information from config
 	configManager := config.GetManager()
 	if err := configManager.RemoveCluster(options.ClusterName, string(Minikube)); err != nil {
-		fmt.Printf("⚠️ Warning: Failed to remove cluster from configuration: %v\n", err)
+		if errdefs.Is(err, errdefs.KindNotFound) {
+			slog.Debug("cluster not tracked in configuration", "cluster", options.ClusterName)
+		} else {
+			slog.Warn("Failed to remove cluster from configuration", "error", err)
+		}
 	}
 
 	return nil
 }
 
-func (p *MinikubeProvider) List(options *ListOptions) error {
+func (p *MinikubeProvider) List(ctx context.Context, options *ListOptions) error {
 	if err := p.Validate(); err != nil {
 		return err
 	}
 
-	getCmd := exec.Command("minikube", "profile", "list")
+	getCmd := command.Context(ctx, "minikube", "profile", "list")
 	getCmd.Stdout = os.Stdout
 	getCmd.Stderr = os.Stderr
 
-	if err := getCmd.Run(); err != nil {
+	if err := command.Run(getCmd); err != nil {
 		fmt.Fprintf(os.Stderr, "❗No Minikube clusters\n")
 		return nil // Don't return error for empty list
 	}
@@ -170,174 +290,101 @@ func (p *MinikubeProvider) List(options *ListOptions) error {
 	return nil
 }
 
-func (p *MinikubeProvider) Upgrade(options *UpgradeOptions) error {
-	_, err := exec.LookPath("minikube")
-	if err != nil {
-		fmt.Println("❌ Minikube is not installed. Please install Minikube to use this command.")
-		os.Exit(1)
-	}
-	checkCmd := exec.Command("minikube", "update-check")
-	// Set up real-time output streaming
-	checkCmd.Stdout = os.Stdout
-	checkCmd.Stderr = os.Stderr
-	// Start and wait for the command to complete
-	if err := checkCmd.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "❗Error Checking Minikube\n")
-		os.Exit(1)
-	}
-
-	switch runtime.GOOS {
-	case "darwin":
-		upgradeCmd := exec.Command("brew", "upgrade", "minikube")
-		// Set up real-time output streaming
-		upgradeCmd.Stdout = os.Stdout
-		upgradeCmd.Stderr = os.Stderr
-		if err := upgradeCmd.Run(); err != nil {
-			linkCmd := exec.Command("brew", "link", "--overwrite", "minikube")
-			linkCmd.Stdout = os.Stdout
-			linkCmd.Stderr = os.Stderr
-			if err := linkCmd.Run(); err != nil {
-				fmt.Fprintf(os.Stderr, "❗Error Linking Minikube\n")
-				os.Exit(1)
-			}
-		}
-	case "linux":
-		// Step 1: Download the latest Minikube binary
-		downloadCmd := exec.Command("curl", "-LO", "https://storage.googleapis.com/minikube/releases/latest/minikube-linux-amd64")
-		downloadCmd.Stdout = os.Stdout
-		downloadCmd.Stderr = os.Stderr
-		if err := downloadCmd.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "❗Error Downloading Minikube\n")
-			os.Exit(1)
-		}
-
-		// Step 2: Install the downloaded binary
-		installCmd := exec.Command("sudo", "install", "minikube-linux-amd64", "/usr/local/bin/minikube")
-		installCmd.Stdout = os.Stdout
-		installCmd.Stderr = os.Stderr
-		if err := installCmd.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "❗Error Installing Minikube\n")
-			os.Exit(1)
-		}
-	case "windows":
-		fmt.Println("❌ Running on an unsupported OS")
-		os.Exit(1)
-	default:
-		fmt.Println("❌ Running on an unsupported OS")
-		os.Exit(1)
-	}
-
-	return nil
+func (p *MinikubeProvider) Upgrade(ctx context.Context, options *UpgradeOptions) error {
+	return upgradeBinary(ctx, string(Minikube), options)
 }
 
-func (p *MinikubeProvider) Install(options *InstallOptions) error {
-	switch runtime.GOOS {
-	case "darwin":
-		fmt.Println("Installing minikube on macOS...")
-		fmt.Println("Please make sure you have Brew installed.")
-		fmt.Println("You can install Brew by running the following command:")
-		fmt.Println("https://brew.sh/")
-		_, err := exec.LookPath("brew")
-		if err != nil {
-			fmt.Println("❌ Brew is not installed. Please install Brew to use this command.")
-			os.Exit(1)
-		}
-		getCmd := exec.Command("brew", "install", "minikube")
-		output, err := getCmd.Output()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error installing minikube: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Println("Installing minikube...")
-		fmt.Println(string(output))
-	case "linux":
-		fmt.Println("Installing minikube on Linux...")
-		fmt.Println("Please make sure you have curl installed.")
-		fmt.Println("You can install curl by running the following command:")
-		fmt.Println("sudo apt-get install curl")
-		getCmd := exec.Command("curl", "-LO", "https://storage.googleapis.com/minikube/releases/latest/minikube-linux-amd64")
-		output, err := getCmd.Output()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error installing minikube: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Println("Installing minikube...")
-		fmt.Println(string(output))
-	case "windows":
-		fmt.Println("❌ Running on an unsupported OS")
-	default:
-		fmt.Println("❌ Running on an unsupported OS")
-	}
-
-	return nil
+func (p *MinikubeProvider) Install(ctx context.Context, options *InstallOptions) error {
+	return installBinary(ctx, string(Minikube), options)
 }
 
-func (p *MinikubeProvider) Stop(options *Default) error {
+func (p *MinikubeProvider) Stop(ctx context.Context, options *Default) error {
 	if err := p.Validate(); err != nil {
 		return err
 	}
 
 	if options.ClusterName == "" {
-		return fmt.Errorf("❌ The Cluster Name is required")
+		return errdefs.Invalid("❌ The Cluster Name is required")
 	}
 
-	createCmd := exec.Command(
+	stopCmd := command.Context(
+		ctx,
 		"minikube",
 		"stop",
 		"--profile="+options.ClusterName,
 	)
 
-	createCmd.Stdout = os.Stdout
-	createCmd.Stderr = os.Stderr
+	stopCmd.Stdout = os.Stdout
+	stopCmd.Stderr = os.Stderr
 
-	fmt.Printf("Debug: Running command: %s\n", createCmd.String())
-	fmt.Printf("Debug: Command Args: %v\n", createCmd.Args)
-	fmt.Printf("🔄 Running...\n")
+	fmt.Printf("🔄 Stopping...\n")
 
-	if err := createCmd.Run(); err != nil {
-		return fmt.Errorf("❌ Error creating minikube cluster: %v", err)
+	if err := command.Run(stopCmd); err != nil {
+		return fmt.Errorf("❌ Error stopping minikube cluster: %w", err)
 	}
 
-	fmt.Printf("✅ Minikube cluster '%s' created successfully\n", options.ClusterName)
+	fmt.Printf("✅ Minikube cluster '%s' stopped successfully\n", options.ClusterName)
 
 	return nil
 }
 
-func (p *MinikubeProvider) Start(options *Default) error {
+func (p *MinikubeProvider) Start(ctx context.Context, options *StartOptions) error {
 	if err := p.Validate(); err != nil {
 		return err
 	}
 
 	if options.ClusterName == "" {
-		return fmt.Errorf("❌ The Cluster Name is required")
+		return errdefs.Invalid("❌ The Cluster Name is required")
 	}
 
-	createCmd := exec.Command(
+	startCmd := command.Context(
+		ctx,
 		"minikube",
 		"start",
 		"--profile="+options.ClusterName,
 	)
 
-	createCmd.Stdout = os.Stdout
-	createCmd.Stderr = os.Stderr
+	startCmd.Stdout = os.Stdout
+	startCmd.Stderr = os.Stderr
 
-	fmt.Printf("Debug: Running command: %s\n", createCmd.String())
-	fmt.Printf("Debug: Command Args: %v\n", createCmd.Args)
-	fmt.Printf("🔄 Running...\n")
+	fmt.Printf("🔄 Starting...\n")
 
-	if err := createCmd.Run(); err != nil {
-		return fmt.Errorf("❌ Error creating minikube cluster: %v", err)
+	if err := command.Run(startCmd); err != nil {
+		return fmt.Errorf("❌ Error starting minikube cluster: %w", err)
 	}
 
-	fmt.Printf("✅ Minikube cluster '%s' created successfully\n", options.ClusterName)
+	fmt.Printf("✅ Minikube cluster '%s' started successfully\n", options.ClusterName)
 
-	return nil
+	return waitReady(ctx, Minikube, options.ClusterName, options.WaitOptions)
+}
+
+// NativeAddon implements AddonProvider with minikube's own addons
+func (p *MinikubeProvider) NativeAddon(addon *addons.Addon) (string, bool) {
+	return addon.Minikube, addon.Minikube != ""
+}
+
+func (p *MinikubeProvider) EnableAddon(ctx context.Context, clusterName, name string) error {
+	return p.runAddons(ctx, "enable", clusterName, name)
+}
+
+func (p *MinikubeProvider) DisableAddon(ctx context.Context, clusterName, name string) error {
+	return p.runAddons(ctx, "disable", clusterName, name)
+}
+
+// runAddons runs 'minikube addons <action>' for an addon of a cluster
+func (p *MinikubeProvider) runAddons(ctx context.Context, action, clusterName, name string) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	addonsCmd := command.Context(ctx, "minikube", "addons", action, name, "--profile="+clusterName)
+	addonsCmd.Stdout = os.Stdout
+	addonsCmd.Stderr = os.Stderr
+	return command.Run(addonsCmd)
 }
 
 // Command builders
 func (p *MinikubeProvider) GetCreateCommand() *cobra.Command {
-	var clusterName, k8sVersion, driver, cni string
-
 	cmd := &cobra.Command{
 		Use:     "minikube",
 		Short:   "Create a minikube cluster",
@@ -345,31 +392,34 @@ func (p *MinikubeProvider) GetCreateCommand() *cobra.Command {
 		Example: `blitzctl create cluster --provider minikube --cluster-name=mycluster --k8s-version=1.32.0 --driver=docker --cni=cilium`,
 		Aliases: []string{"mini", "m"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defaults := config.GetManager().GetDefaults()
 			options := &CreateOptions{
 				ClusterOptions: ClusterOptions{
-					ClusterName: clusterName,
-					K8sVersion:  k8sVersion,
+					ClusterName: defaults.ClusterName,
+					K8sVersion:  defaults.K8sVersion,
 				},
 				ProviderOptions: map[string]interface{}{
-					"driver": driver,
-					"cni":    cni,
+					"driver": defaults.Driver,
+					"cni":    defaults.CNI,
 				},
 			}
-			return p.Create(options)
+			return p.Create(cmd.Context(), options)
 		},
 	}
 
-	cmd.Flags().StringVar(&clusterName, "cluster-name", config.DefaultClusterName, i18n.T("Cluster Name."))
-	cmd.Flags().StringVar(&k8sVersion, "k8s-version", config.DefaultK8sVersion, i18n.T("K8s Version."))
-	cmd.Flags().StringVar(&driver, "driver", config.DefaultDriver, i18n.T("Driver."))
-	cmd.Flags().StringVar(&cni, "cni", config.DefaultCni, i18n.T("CNI."))
+	cmd.Flags().String("cluster-name", "", i18n.T("Cluster Name (default: the configured cluster_name)."))
+	config.BindFlag(cmd.Flags().Lookup("cluster-name"), "cluster_name")
+	cmd.Flags().String("k8s-version", "", i18n.T("K8s Version (default: the configured k8s_version)."))
+	config.BindFlag(cmd.Flags().Lookup("k8s-version"), "k8s_version")
+	cmd.Flags().String("driver", "", i18n.T("Driver (default: the configured driver)."))
+	config.BindFlag(cmd.Flags().Lookup("driver"), "driver")
+	cmd.Flags().String("cni", "", i18n.T("CNI (default: the configured cni)."))
+	config.BindFlag(cmd.Flags().Lookup("cni"), "cni")
 
 	return cmd
 }
 
 func (p *MinikubeProvider) GetDeleteCommand() *cobra.Command {
-	var clusterName string
-
 	cmd := &cobra.Command{
 		Use:     "minikube",
 		Short:   "Delete a minikube cluster",
@@ -377,18 +427,16 @@ func (p *MinikubeProvider) GetDeleteCommand() *cobra.Command {
 		Example: `blitzctl delete cluster --provider minikube --cluster-name=mycluster`,
 		Aliases: []string{"mini", "m"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defaults := config.GetManager().GetDefaults()
 			options := &Default{
-				ClusterName: clusterName,
+				ClusterName: defaults.ClusterName,
 			}
-			return p.Delete(options)
+			return p.Delete(cmd.Context(), options)
 		},
 	}
 
-	cmd.Flags().StringVar(&clusterName, "cluster-name", config.DefaultClusterName, i18n.T("Cluster Name."))
-
-	if err := cmd.MarkFlagRequired("cluster-name"); err != nil {
-		panic(fmt.Sprintf("❌ Failed to mark 'cluster-name' flag as required: %v", err))
-	}
+	cmd.Flags().String("cluster-name", "", i18n.T("Cluster Name (default: the configured cluster_name)."))
+	config.BindFlag(cmd.Flags().Lookup("cluster-name"), "cluster_name")
 
 	return cmd
 }
@@ -402,7 +450,7 @@ func (p *MinikubeProvider) GetListCommand() *cobra.Command {
 		Aliases: []string{"minikube", "mini", "m"},
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return p.List(&ListOptions{})
+			return p.List(cmd.Context(), &ListOptions{})
 		},
 	}
 }
@@ -423,7 +471,7 @@ func (p *MinikubeProvider) GetUpgradeCommand() *cobra.Command {
 					K8sVersion:  k8sVersion,
 				},
 			}
-			return p.Upgrade(options)
+			return p.Upgrade(cmd.Context(), options)
 		},
 	}
 
@@ -438,14 +486,12 @@ func (p *MinikubeProvider) GetInstallCommand() *cobra.Command {
 		Example: `blitzctl install cluster --provider minikube`,
 		Aliases: []string{"mini", "m"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return p.Install(&InstallOptions{})
+			return p.Install(cmd.Context(), &InstallOptions{})
 		},
 	}
 }
 
 func (p *MinikubeProvider) GetStartCommand() *cobra.Command {
-	var clusterName string
-
 	cmd := &cobra.Command{
 		Use:     "minikube",
 		Short:   "Start a minikube cluster",
@@ -453,23 +499,21 @@ func (p *MinikubeProvider) GetStartCommand() *cobra.Command {
 		Example: `blitzctl start cluster --provider minikube --cluster-name <cluster-name>`,
 		Aliases: []string{"mini", "m"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			options := &Default{
-				ClusterName: clusterName,
+			defaults := config.GetManager().GetDefaults()
+			options := &StartOptions{
+				Default: Default{
+					ClusterName: defaults.ClusterName,
+				},
 			}
-			return p.Start(options)
+			return p.Start(cmd.Context(), options)
 		},
 	}
-	cmd.Flags().StringVar(&clusterName, "cluster-name", config.DefaultClusterName, i18n.T("Cluster Name."))
-	if err := cmd.MarkFlagRequired("cluster-name"); err != nil {
-		panic(fmt.Sprintf("❌ Failed to mark 'cluster-name' flag as required: %v", err))
-	}
-
+	cmd.Flags().String("cluster-name", "", i18n.T("Cluster Name (default: the configured cluster_name)."))
+	config.BindFlag(cmd.Flags().Lookup("cluster-name"), "cluster_name")
 	return cmd
 }
 
 func (p *MinikubeProvider) GetStopCommand() *cobra.Command {
-	var clusterName string
-
 	cmd := &cobra.Command{
 		Use:     "minikube",
 		Short:   "Stop a minikube cluster",
@@ -477,16 +521,14 @@ func (p *MinikubeProvider) GetStopCommand() *cobra.Command {
 		Example: `blitzctl stop cluster --provider minikube --cluster-name <cluster-name>`,
 		Aliases: []string{"mini", "m"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			defaults := config.GetManager().GetDefaults()
 			options := &Default{
-				ClusterName: clusterName,
+				ClusterName: defaults.ClusterName,
 			}
-			return p.Stop(options)
+			return p.Stop(cmd.Context(), options)
 		},
 	}
-	cmd.Flags().StringVar(&clusterName, "cluster-name", config.DefaultClusterName, i18n.T("Cluster Name."))
-	if err := cmd.MarkFlagRequired("cluster-name"); err != nil {
-		panic(fmt.Sprintf("❌ Failed to mark 'cluster-name' flag as required: %v", err))
-	}
-
+	cmd.Flags().String("cluster-name", "", i18n.T("Cluster Name (default: the configured cluster_name)."))
+	config.BindFlag(cmd.Flags().Lookup("cluster-name"), "cluster_name")
 	return cmd
 }
@@ -0,0 +1,135 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// minikubeSnapshotDir is where a snapshot keeps the minikube profile and
+// machine directories of the cluster
+const minikubeSnapshotDir = "minikube"
+
+// minikubeProfile is the part of a minikube profile's config.json telling
+// how its nodes run
+type minikubeProfile struct {
+	Driver string
+	Nodes  []struct{ Name string }
+}
+
+// minikubeHome returns the .minikube directory, following MINIKUBE_HOME
+// like minikube does
+func minikubeHome() (string, error) {
+	if home := os.Getenv("MINIKUBE_HOME"); home != "" {
+		if filepath.Base(home) == ".minikube" {
+			return home, nil
+		}
+		return filepath.Join(home, ".minikube"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".minikube"), nil
+}
+
+// SnapshotNodes implements SnapshotProvider from the profile's config.json.
+// Only the docker and podman drivers run the nodes as containers.
+func (p *MinikubeProvider) SnapshotNodes(ctx context.Context, clusterName string) (string, []string, error) {
+	home, err := minikubeHome()
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(home, "profiles", clusterName, "config.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	var profile minikubeProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return "", nil, fmt.Errorf("failed to parse the minikube profile %s: %w", clusterName, err)
+	}
+
+	if profile.Driver != string(Docker) && profile.Driver != string(Podman) {
+		return "", nil, errdefs.Unsupported("❌ Cluster '%s' uses the %s driver, only clusters on the docker and podman drivers can be snapshotted",
+			clusterName, profile.Driver)
+	}
+
+	var nodes []string
+	for _, node := range profile.Nodes {
+		// The primary node is unnamed, its container is the profile's
+		if node.Name == "" {
+			nodes = append(nodes, clusterName)
+		} else {
+			nodes = append(nodes, clusterName+"-"+node.Name)
+		}
+	}
+	return profile.Driver, nodes, nil
+}
+
+// SuspendCluster implements SnapshotProvider with minikube stop
+func (p *MinikubeProvider) SuspendCluster(ctx context.Context, clusterName string) error {
+	return p.Stop(ctx, &Default{ClusterName: clusterName})
+}
+
+// ResumeCluster implements SnapshotProvider with minikube start, which also
+// updates the kubeconfig
+func (p *MinikubeProvider) ResumeCluster(ctx context.Context, clusterName string) error {
+	return p.Start(ctx, &StartOptions{Default: Default{ClusterName: clusterName}})
+}
+
+// SaveSnapshotFiles implements SnapshotProvider by copying the profile and
+// the machine directories, holding the certificates, of the cluster
+func (p *MinikubeProvider) SaveSnapshotFiles(snapshot *config.SnapshotInfo) error {
+	home, err := minikubeHome()
+	if err != nil {
+		return err
+	}
+	for _, dir := range minikubeClusterDirs(snapshot) {
+		if err := os.CopyFS(filepath.Join(snapshot.Dir, minikubeSnapshotDir, dir), os.DirFS(filepath.Join(home, dir))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreSnapshotFiles implements SnapshotProvider by copying the profile and
+// machine directories back
+func (p *MinikubeProvider) RestoreSnapshotFiles(snapshot *config.SnapshotInfo) error {
+	home, err := minikubeHome()
+	if err != nil {
+		return err
+	}
+	for _, dir := range minikubeClusterDirs(snapshot) {
+		target := filepath.Join(home, dir)
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		if err := os.CopyFS(target, os.DirFS(filepath.Join(snapshot.Dir, minikubeSnapshotDir, dir))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// minikubeClusterDirs returns the directories under the minikube home
+// belonging to the cluster of a snapshot
+func minikubeClusterDirs(snapshot *config.SnapshotInfo) []string {
+	dirs := []string{filepath.Join("profiles", snapshot.Cluster.Name)}
+	for _, node := range snapshot.Nodes {
+		dirs = append(dirs, filepath.Join("machines", node.Name))
+	}
+	return dirs
+}
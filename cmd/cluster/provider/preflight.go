@@ -0,0 +1,228 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/doctor"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
+	"k8s.io/apimachinery/pkg/util/validation"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+)
+
+// PreflightTimeout bounds how long a single preflight check may run
+var PreflightTimeout = 20 * time.Second
+
+// PreflightError is a failed preflight check with a remediation hint
+type PreflightError struct {
+	Check   string
+	Message string
+	Hint    string
+	// Kind categorizes the failure, errdefs.KindPreflightFailed when empty
+	Kind errdefs.Kind
+}
+
+// ErrorKind returns the category of the failure
+func (e *PreflightError) ErrorKind() errdefs.Kind {
+	if e.Kind == "" {
+		return errdefs.KindPreflightFailed
+	}
+	return e.Kind
+}
+
+func (e *PreflightError) Error() string {
+	message := fmt.Sprintf("❌ Preflight check %q failed: %s", e.Check, e.Message)
+	if e.Hint != "" {
+		message += "\n   💡 " + e.Hint
+	}
+	return message
+}
+
+// preflightCheck runs before a cluster is created. It returns a
+// *PreflightError when the check fails and any other error when it could
+// not be performed (offline, unexpected tool output, ...)
+type preflightCheck struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// runPreflight runs every check and returns all failures joined together.
+// Checks that cannot be performed only print a warning.
+func runPreflight(ctx context.Context, checks []preflightCheck) error {
+	fmt.Printf("🔍 Running preflight checks...\n")
+
+	var failures []error
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, PreflightTimeout)
+		err := check.run(checkCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var failure *PreflightError
+		switch {
+		case err == nil:
+		case errors.As(err, &failure):
+			if failure.Check == "" {
+				failure.Check = check.name
+			}
+			failures = append(failures, failure)
+		default:
+			slog.Warn("Skipping preflight check", "check", check.name, "error", err)
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.Join(failures...)
+	}
+	fmt.Printf("✅ Preflight checks passed\n")
+	return nil
+}
+
+// checkClusterName verifies name is a valid DNS-1123 label, which both
+// providers turn into container, node and kubeconfig context names
+func checkClusterName(name string) preflightCheck {
+	return preflightCheck{name: "cluster-name", run: func(ctx context.Context) error {
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return &PreflightError{
+				Kind:    errdefs.KindInvalid,
+				Message: fmt.Sprintf("%q is not a valid cluster name: %s", name, strings.Join(errs, "; ")),
+				Hint:    "use lowercase letters, digits and '-', e.g. --cluster-name my-cluster",
+			}
+		}
+		return nil
+	}}
+}
+
+// checkNotTracked verifies blitzctl does not already track a cluster with
+// the same name and provider
+func checkNotTracked(name string, providerType ProviderType) preflightCheck {
+	return preflightCheck{name: "cluster-config", run: func(ctx context.Context) error {
+		if _, err := config.GetManager().GetCluster(name, string(providerType)); err == nil {
+			return &PreflightError{
+				Kind:    errdefs.KindAlreadyExists,
+				Message: fmt.Sprintf("cluster %q (%s) is already tracked in %s", name, providerType, config.GetManager().GetConfigFilePath()),
+				Hint:    fmt.Sprintf("pick another --cluster-name or run 'blitzctl delete cluster --provider %s --cluster-name %s'", providerType, name),
+			}
+		}
+		return nil
+	}}
+}
+
+// checkNotExisting verifies the provider itself has no cluster called name
+func checkNotExisting(name string, providerType ProviderType, list func(ctx context.Context) ([]string, error)) preflightCheck {
+	return preflightCheck{name: "cluster-exists", run: func(ctx context.Context) error {
+		existing, err := list(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list %s clusters: %w", providerType, err)
+		}
+		for _, cluster := range existing {
+			if cluster == name {
+				return &PreflightError{
+					Kind:    errdefs.KindAlreadyExists,
+					Message: fmt.Sprintf("%s already has a cluster called %q", providerType, name),
+					Hint:    fmt.Sprintf("pick another --cluster-name or run 'blitzctl delete cluster --provider %s --cluster-name %s'", providerType, name),
+				}
+			}
+		}
+		return nil
+	}}
+}
+
+// checkK8sVersion verifies version is a full semantic version and, through
+// exists, that it was actually released
+func checkK8sVersion(version string, exists func(ctx context.Context, version string) error) preflightCheck {
+	return preflightCheck{name: "k8s-version", run: func(ctx context.Context) error {
+		if _, err := utilversion.ParseSemantic(toolchain.NormalizeVersion(version)); err != nil {
+			return &PreflightError{
+				Kind:    errdefs.KindInvalid,
+				Message: fmt.Sprintf("%q is not a valid Kubernetes version", version),
+				Hint:    "use a full version such as --k8s-version " + config.DefaultK8sVersion,
+			}
+		}
+
+		if err := exists(ctx, toolchain.NormalizeVersion(version)); errors.Is(err, toolchain.ErrNotFound) {
+			return &PreflightError{
+				Kind:    errdefs.KindNotFound,
+				Message: fmt.Sprintf("Kubernetes %s is not available: %v", version, err),
+				Hint:    "check https://kubernetes.io/releases/ for released versions",
+			}
+		} else if err != nil {
+			return err
+		}
+		return nil
+	}}
+}
+
+// checkEngine verifies the docker or podman daemon backing the cluster answers
+func checkEngine(engine string) preflightCheck {
+	return preflightCheck{name: "driver", run: func(ctx context.Context) error {
+		if err := doctor.EngineReachable(ctx, engine); err != nil {
+			return &PreflightError{
+				Message: fmt.Sprintf("the %s daemon is not running (%v)", engine, err),
+				Hint:    fmt.Sprintf("start %s (e.g. 'sudo systemctl start %s' or Docker Desktop / 'podman machine start')", engine, engine),
+			}
+		}
+		return nil
+	}}
+}
+
+// checkPorts verifies the host ports the cluster binds are free
+func checkPorts(ports []int) preflightCheck {
+	return preflightCheck{name: "ports", run: func(ctx context.Context) error {
+		var busy []string
+		for _, port := range ports {
+			if !doctor.PortAvailable(port) {
+				busy = append(busy, strconv.Itoa(port))
+			}
+		}
+		if len(busy) > 0 {
+			return &PreflightError{
+				Message: "host ports already in use: " + strings.Join(busy, ", "),
+				Hint:    "stop the process listening on them (e.g. 'sudo lsof -i :" + busy[0] + "')",
+			}
+		}
+		return nil
+	}}
+}
+
+// checkDoctor reuses a `blitzctl doctor` check: failures abort, warnings are printed
+func checkDoctor(name string) preflightCheck {
+	return preflightCheck{name: name, run: func(ctx context.Context) error {
+		check, ok := doctor.Lookup(name)
+		if !ok {
+			return fmt.Errorf("unknown check")
+		}
+
+		result := check.Run(ctx)
+		switch result.Status {
+		case doctor.Fail:
+			return &PreflightError{Message: result.Message, Hint: result.Hint}
+		case doctor.Warn:
+			slog.Warn(result.Message, "check", name)
+		}
+		return nil
+	}}
+}
+
+// preflightInstaller returns a quiet installer with a short timeout used to
+// query release and image registries
+func preflightInstaller() *toolchain.Installer {
+	installer := toolchain.NewInstaller("")
+	installer.Client = &http.Client{Timeout: PreflightTimeout}
+	installer.Out = io.Discard
+	return installer
+}
@@ -0,0 +1,228 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
+)
+
+// checkReturning returns a preflight check failing with err, nil passes
+func checkReturning(name string, err error) preflightCheck {
+	return preflightCheck{name: name, run: func(ctx context.Context) error { return err }}
+}
+
+func TestRunPreflight(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []preflightCheck
+		// cancel cancels the run from the check at that index, -1 never does
+		cancel       int
+		wantChecks   []string
+		wantExitCode int
+	}{
+		{
+			name:   "every check passes",
+			checks: []preflightCheck{checkReturning("a", nil), checkReturning("b", nil)},
+			cancel: -1,
+		},
+		{
+			name: "failures are joined",
+			checks: []preflightCheck{
+				checkReturning("ports", &PreflightError{Message: "port 80 in use"}),
+				checkReturning("ok", nil),
+				checkReturning("driver", &PreflightError{Message: "docker is down"}),
+			},
+			cancel:       -1,
+			wantChecks:   []string{"ports", "driver"},
+			wantExitCode: 8,
+		},
+		{
+			name:         "failure keeps its kind",
+			checks:       []preflightCheck{checkReturning("cluster-name", &PreflightError{Kind: errdefs.KindInvalid, Message: "bad name"})},
+			cancel:       -1,
+			wantChecks:   []string{"cluster-name"},
+			wantExitCode: 2,
+		},
+		{
+			name:   "check that cannot run is skipped",
+			checks: []preflightCheck{checkReturning("k8s-version", errors.New("offline")), checkReturning("ok", nil)},
+			cancel: -1,
+		},
+		{
+			name:         "canceled run stops",
+			checks:       []preflightCheck{checkReturning("a", nil), checkReturning("b", nil), checkReturning("c", &PreflightError{Message: "never run"})},
+			cancel:       1,
+			wantExitCode: 130,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			checks := tt.checks
+			if tt.cancel >= 0 {
+				checks = append([]preflightCheck(nil), tt.checks...)
+				run := checks[tt.cancel].run
+				checks[tt.cancel].run = func(ctx context.Context) error {
+					cancel()
+					return run(ctx)
+				}
+			}
+
+			err := runPreflight(ctx, checks)
+			if got := errdefs.ExitCode(err); got != tt.wantExitCode {
+				t.Errorf("runPreflight() exit code = %d, want %d (error %v)", got, tt.wantExitCode, err)
+			}
+			for _, check := range tt.wantChecks {
+				if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("%q failed", check)) {
+					t.Errorf("runPreflight() error = %v, want check %s reported", err, check)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckClusterName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "blitz-cluster1"},
+		{name: "dev"},
+		{name: "Dev", wantErr: true},
+		{name: "my_cluster", wantErr: true},
+		{name: "-dev", wantErr: true},
+		{name: strings.Repeat("a", 64), wantErr: true},
+	}
+	for _, tt := range tests {
+		err := checkClusterName(tt.name).run(context.Background())
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkClusterName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if err != nil && errdefs.KindOf(err) != errdefs.KindInvalid {
+			t.Errorf("checkClusterName(%q) kind = %s, want %s", tt.name, errdefs.KindOf(err), errdefs.KindInvalid)
+		}
+	}
+}
+
+func TestCheckNotExisting(t *testing.T) {
+	tests := []struct {
+		name     string
+		clusters []string
+		listErr  error
+		wantKind errdefs.Kind
+	}{
+		{name: "no clusters"},
+		{name: "other clusters", clusters: []string{"ci", "staging"}},
+		{name: "same name", clusters: []string{"ci", "dev"}, wantKind: errdefs.KindAlreadyExists},
+		{name: "listing fails", listErr: errors.New("kind not running"), wantKind: errdefs.KindUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := func(ctx context.Context) ([]string, error) { return tt.clusters, tt.listErr }
+			err := checkNotExisting("dev", Kind, list).run(context.Background())
+			if got := errdefs.KindOf(err); got != tt.wantKind {
+				t.Errorf("checkNotExisting() kind = %q, want %q (error %v)", got, tt.wantKind, err)
+			}
+			var failure *PreflightError
+			if tt.listErr != nil && errors.As(err, &failure) {
+				t.Errorf("checkNotExisting() = %v, a failed listing can't fail the check", err)
+			}
+		})
+	}
+}
+
+func TestCheckK8sVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		existsErr error
+		wantKind  errdefs.Kind
+		wantCheck bool
+	}{
+		{name: "released", version: "1.34.0"},
+		{name: "v prefix", version: "v1.34.0"},
+		{name: "not semantic", version: "1.34", wantKind: errdefs.KindInvalid, wantCheck: true},
+		{name: "not a version", version: "latest", wantKind: errdefs.KindInvalid, wantCheck: true},
+		{name: "not released", version: "1.99.0", existsErr: fmt.Errorf("%w: no kind image", toolchain.ErrNotFound), wantKind: errdefs.KindNotFound, wantCheck: true},
+		{name: "lookup fails", version: "1.34.0", existsErr: errors.New("offline"), wantKind: errdefs.KindUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var looked string
+			exists := func(ctx context.Context, version string) error {
+				looked = version
+				return tt.existsErr
+			}
+
+			err := checkK8sVersion(tt.version, exists).run(context.Background())
+			if got := errdefs.KindOf(err); got != tt.wantKind {
+				t.Errorf("checkK8sVersion(%q) kind = %q, want %q (error %v)", tt.version, got, tt.wantKind, err)
+			}
+			var failure *PreflightError
+			if errors.As(err, &failure) != tt.wantCheck {
+				t.Errorf("checkK8sVersion(%q) = %v, want a failed check %v", tt.version, err, tt.wantCheck)
+			}
+			if looked != "" && strings.HasPrefix(looked, "v") {
+				t.Errorf("looked up %q, want the version without the v prefix", looked)
+			}
+		})
+	}
+}
+
+func TestCheckPorts(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = listener.Close() }()
+	busy := listener.Addr().(*net.TCPAddr).Port
+
+	free, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	freePort := free.Addr().(*net.TCPAddr).Port
+	_ = free.Close()
+
+	tests := []struct {
+		name    string
+		ports   []int
+		wantErr bool
+	}{
+		{name: "no ports"},
+		{name: "free port", ports: []int{freePort}},
+		{name: "busy port", ports: []int{freePort, busy}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPorts(tt.ports).run(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkPorts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), fmt.Sprint(busy)) {
+				t.Errorf("checkPorts() error = %v, want port %d named", err, busy)
+			}
+		})
+	}
+}
+
+func TestPreflightError(t *testing.T) {
+	err := &PreflightError{Check: "driver", Message: "the docker daemon is not running", Hint: "start docker"}
+	want := "❌ Preflight check \"driver\" failed: the docker daemon is not running\n   💡 start docker"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got := errdefs.ExitCode(err); got != 8 {
+		t.Errorf("ExitCode() = %d, want the preflight exit code 8", got)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// RollbackTimeout bounds deleting a cluster that failed to be created
+const RollbackTimeout = 2 * time.Minute
+
+// clusterExists reports whether name is already in list. Errors count as
+// existing so a failed create never deletes a cluster it cannot prove it made.
+func clusterExists(ctx context.Context, name string, list func(ctx context.Context) ([]string, error)) bool {
+	existing, err := list(ctx)
+	if err != nil {
+		slog.Debug("failed to list clusters, rollback disabled", "error", err)
+		return true
+	}
+	return slices.Contains(existing, name)
+}
+
+// rollbackCreate deletes a cluster whose creation failed with cause, unless
+// it existed before the create or options.KeepOnFailure is set. It returns
+// cause, joined with the rollback failure if the cluster could not be deleted.
+func rollbackCreate(p ClusterProvider, options *CreateOptions, existed bool, cause error) error {
+	if existed {
+		return cause
+	}
+
+	if options.KeepOnFailure {
+		fmt.Printf("🔧 Keeping cluster '%s' for debugging, delete it with 'blitzctl delete cluster --provider %s --cluster-name=%s'\n",
+			options.ClusterName, p.GetProviderType(), options.ClusterName)
+		return cause
+	}
+
+	fmt.Printf("🧹 Rolling back partially created cluster '%s'...\n", options.ClusterName)
+
+	// The command context is usually cancelled by now, the cleanup gets its own
+	ctx, cancel := context.WithTimeout(context.Background(), RollbackTimeout)
+	defer cancel()
+
+	if err := p.Delete(ctx, &Default{ClusterName: options.ClusterName}); err != nil {
+		return errors.Join(cause, errdefs.Wrap(errdefs.KindExternalCommandFailed, err, "❌ Rollback of cluster '%s' failed", options.ClusterName).
+			WithHint("blitzctl delete cluster --provider %s --cluster-name=%s", p.GetProviderType(), options.ClusterName))
+	}
+	return cause
+}
+
+// rollbackUnready handles a created cluster that failed to become ready with
+// cause. A cluster that timed out is kept, recorded as degraded, so it can be
+// inspected; only an interrupted create is rolled back.
+func rollbackUnready(ctx context.Context, p ClusterProvider, options *CreateOptions, existed bool, cause error) error {
+	if ctx.Err() == nil {
+		return cause
+	}
+	return rollbackCreate(p, options, existed, cause)
+}
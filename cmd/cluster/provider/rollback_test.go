@@ -0,0 +1,130 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// fakeProvider records the clusters deleted through it, every other method
+// of ClusterProvider panics
+type fakeProvider struct {
+	ClusterProvider
+	deleteErr error
+	deleted   []string
+}
+
+func (p *fakeProvider) GetProviderType() ProviderType {
+	return Kind
+}
+
+func (p *fakeProvider) Delete(ctx context.Context, options *Default) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	p.deleted = append(p.deleted, options.ClusterName)
+	return p.deleteErr
+}
+
+func TestRollbackCreate(t *testing.T) {
+	cause := errors.New("kind create cluster failed")
+
+	tests := []struct {
+		name          string
+		existed       bool
+		keep          bool
+		deleteErr     error
+		wantDeleted   bool
+		wantExitCode  int
+		wantInMessage string
+	}{
+		{name: "created cluster is deleted", wantDeleted: true, wantExitCode: 1},
+		{name: "existing cluster is kept", existed: true, wantExitCode: 1},
+		{name: "kept on failure", keep: true, wantExitCode: 1},
+		{
+			name:          "failed rollback is reported",
+			deleteErr:     errors.New("container in use"),
+			wantDeleted:   true,
+			wantExitCode:  7,
+			wantInMessage: "Rollback of cluster 'dev' failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &fakeProvider{deleteErr: tt.deleteErr}
+			options := &CreateOptions{ClusterOptions: ClusterOptions{ClusterName: "dev"}, KeepOnFailure: tt.keep}
+
+			err := rollbackCreate(p, options, tt.existed, cause)
+			if !errors.Is(err, cause) {
+				t.Errorf("rollbackCreate() error = %v, want the cause", err)
+			}
+			if deleted := slices.Contains(p.deleted, "dev"); deleted != tt.wantDeleted {
+				t.Errorf("cluster deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if got := errdefs.ExitCode(err); got != tt.wantExitCode {
+				t.Errorf("rollbackCreate() exit code = %d, want %d", got, tt.wantExitCode)
+			}
+			if tt.wantInMessage != "" && !strings.Contains(err.Error(), tt.wantInMessage) {
+				t.Errorf("rollbackCreate() error = %v, want %q", err, tt.wantInMessage)
+			}
+		})
+	}
+}
+
+func TestRollbackUnready(t *testing.T) {
+	cause := errors.New("cluster not ready")
+
+	tests := []struct {
+		name        string
+		interrupted bool
+		wantDeleted bool
+	}{
+		{name: "timed out cluster is kept"},
+		{name: "interrupted create is rolled back", interrupted: true, wantDeleted: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.interrupted {
+				cancel()
+			}
+
+			p := &fakeProvider{}
+			options := &CreateOptions{ClusterOptions: ClusterOptions{ClusterName: "dev"}}
+			// The rollback runs under a context of its own, not the canceled one
+			if err := rollbackUnready(ctx, p, options, false, cause); !errors.Is(err, cause) {
+				t.Errorf("rollbackUnready() error = %v, want the cause", err)
+			}
+			if deleted := len(p.deleted) > 0; deleted != tt.wantDeleted {
+				t.Errorf("cluster deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestClusterExists(t *testing.T) {
+	tests := []struct {
+		name     string
+		clusters []string
+		listErr  error
+		want     bool
+	}{
+		{name: "not listed", clusters: []string{"ci"}},
+		{name: "listed", clusters: []string{"ci", "dev"}, want: true},
+		{name: "listing fails", listErr: errors.New("docker is down"), want: true},
+	}
+	for _, tt := range tests {
+		list := func(ctx context.Context) ([]string, error) { return tt.clusters, tt.listErr }
+		if got := clusterExists(context.Background(), "dev", list); got != tt.want {
+			t.Errorf("%s: clusterExists() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,422 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/container"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// SnapshotProvider is implemented by providers running their nodes as
+// containers, which a snapshot commits to images along with their volumes
+type SnapshotProvider interface {
+	// SnapshotNodes returns the container engine and the node containers of
+	// a cluster, the control plane first, none when it doesn't exist
+	SnapshotNodes(ctx context.Context, clusterName string) (string, []string, error)
+	// SuspendCluster stops the nodes so their disks are consistent
+	SuspendCluster(ctx context.Context, clusterName string) error
+	// ResumeCluster starts the nodes and refreshes the kubeconfig context
+	ResumeCluster(ctx context.Context, clusterName string) error
+	// SaveSnapshotFiles writes what the provider keeps outside the nodes to
+	// the snapshot directory
+	SaveSnapshotFiles(snapshot *config.SnapshotInfo) error
+	// RestoreSnapshotFiles puts back what SaveSnapshotFiles wrote
+	RestoreSnapshotFiles(snapshot *config.SnapshotInfo) error
+}
+
+const (
+	// snapshotImageRepo prefixes the images nodes are committed to
+	snapshotImageRepo = "blitzctl-snapshot/"
+	// snapshotVolume is the node volume holding etcd, the container images
+	// and the kubelet state, which committing a container leaves out
+	snapshotVolume = "/var"
+
+	snapshotContainersFile = "containers.json"
+	snapshotNetworksFile   = "networks.json"
+)
+
+// SnapshotsDir returns the directory snapshots are kept in
+func SnapshotsDir() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "snapshots"), nil
+}
+
+// snapshotProvider returns p as a SnapshotProvider
+func snapshotProvider(p ClusterProvider) (SnapshotProvider, error) {
+	sp, ok := p.(SnapshotProvider)
+	if !ok {
+		return nil, errdefs.Unsupported("❌ %s clusters can't be snapshotted", p.GetProviderType())
+	}
+	return sp, nil
+}
+
+// SaveSnapshot commits the nodes of a cluster to images and archives their
+// volumes under the data directory, stopping a running cluster while it
+// does and starting it again after. An existing snapshot of the same name is
+// only replaced with force.
+func SaveSnapshot(ctx context.Context, p ClusterProvider, clusterName, name string, force bool) error {
+	sp, err := snapshotProvider(p)
+	if err != nil {
+		return err
+	}
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return errdefs.Invalid("❌ %q is not a valid snapshot name: %s", name, strings.Join(errs, "; "))
+	}
+
+	providerType := p.GetProviderType()
+	configManager := config.GetManager()
+	if existing, err := configManager.GetSnapshot(clusterName, string(providerType), name); err == nil {
+		if !force {
+			return errdefs.AlreadyExists("❌ Snapshot %s of cluster '%s' already exists", name, clusterName).
+				WithHint("replace it with 'blitzctl snapshot save %s --force'", name)
+		}
+		if err := DeleteSnapshot(ctx, *existing); err != nil {
+			return err
+		}
+	}
+
+	engineName, nodes, err := sp.SnapshotNodes(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return errdefs.NotFound("❌ Cluster '%s' (%s) not found", clusterName, providerType)
+	}
+
+	cluster, err := configManager.GetCluster(clusterName, string(providerType))
+	if err != nil {
+		slog.Warn("Cluster not tracked, the snapshot only records its nodes", "cluster", clusterName)
+		cluster = &config.ClusterInfo{Name: clusterName, Provider: string(providerType), Nodes: len(nodes)}
+	}
+
+	root, err := SnapshotsDir()
+	if err != nil {
+		return err
+	}
+	snapshot := &config.SnapshotInfo{
+		Name:      name,
+		Cluster:   *cluster,
+		CreatedAt: time.Now(),
+		Engine:    engineName,
+		Dir:       filepath.Join(root, string(providerType), clusterName, name),
+	}
+
+	engine := container.New(engineName)
+	containers := make([]*container.Container, 0, len(nodes))
+	for _, node := range nodes {
+		c, err := engine.Inspect(ctx, node)
+		if err != nil {
+			return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error inspecting node %s", node)
+		}
+		containers = append(containers, c)
+	}
+
+	running := containers[0].State.Running
+	if running {
+		fmt.Printf("⏸️ Stopping cluster '%s' for a consistent snapshot...\n", clusterName)
+		if err := sp.SuspendCluster(ctx, clusterName); err != nil {
+			return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error stopping cluster '%s'", clusterName)
+		}
+	}
+
+	err = saveSnapshotNodes(ctx, engine, snapshot, containers)
+	if err == nil {
+		err = sp.SaveSnapshotFiles(snapshot)
+	}
+	if err != nil {
+		removeSnapshotFiles(engine, snapshot)
+		err = errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error saving snapshot %s of cluster '%s'", name, clusterName)
+	} else {
+		snapshot.Size = dirSize(snapshot.Dir)
+		if err := configManager.AddSnapshot(*snapshot); err != nil {
+			removeSnapshotFiles(engine, snapshot)
+			return err
+		}
+		fmt.Printf("✅ Snapshot %s of cluster '%s' saved (%s)\n", name, clusterName, FormatSize(snapshot.Size))
+	}
+
+	if running {
+		fmt.Printf("▶️ Starting cluster '%s' again...\n", clusterName)
+		if resumeErr := sp.ResumeCluster(ctx, clusterName); resumeErr != nil {
+			err = errors.Join(err, errdefs.Wrap(errdefs.KindOf(resumeErr), resumeErr, "❌ Error starting cluster '%s' after the snapshot", clusterName).
+				WithHint("blitzctl snapshot restore %s --provider %s --cluster-name %s --force", name, providerType, clusterName))
+		}
+	}
+	return err
+}
+
+// saveSnapshotNodes commits the stopped node containers, archives their
+// volume and writes what restoring them needs to the snapshot directory
+func saveSnapshotNodes(ctx context.Context, engine *container.Engine, snapshot *config.SnapshotInfo, containers []*container.Container) error {
+	if err := os.MkdirAll(snapshot.Dir, 0o755); err != nil {
+		return err
+	}
+
+	var networks []*container.Network
+	for _, c := range containers {
+		node := config.SnapshotNode{Name: c.Name, Image: snapshotImageRepo + c.Name + ":" + snapshot.Name}
+		fmt.Printf("📸 Committing node %s to %s...\n", c.Name, node.Image)
+		if err := engine.Commit(ctx, c.Name, node.Image); err != nil {
+			return err
+		}
+		// Recorded now so a failure further on removes the image
+		snapshot.Nodes = append(snapshot.Nodes, node)
+
+		if slices.ContainsFunc(c.Mounts, func(m container.Mount) bool { return m.Destination == snapshotVolume }) {
+			archive := c.Name + "-var.tar"
+			fmt.Printf("💾 Archiving %s of node %s...\n", snapshotVolume, c.Name)
+			if err := exportVolume(ctx, engine, c.Name, filepath.Join(snapshot.Dir, archive)); err != nil {
+				return err
+			}
+			snapshot.Nodes[len(snapshot.Nodes)-1].Archive = archive
+		}
+
+		for _, name := range c.Networks() {
+			if slices.ContainsFunc(networks, func(n *container.Network) bool { return n.Name == name }) {
+				continue
+			}
+			network, err := engine.InspectNetwork(ctx, name)
+			if err != nil {
+				return err
+			}
+			networks = append(networks, network)
+		}
+	}
+
+	if err := writeSnapshotJSON(snapshot, snapshotContainersFile, containers); err != nil {
+		return err
+	}
+	return writeSnapshotJSON(snapshot, snapshotNetworksFile, networks)
+}
+
+// exportVolume writes the archive of a node's volume to path
+func exportVolume(ctx context.Context, engine *container.Engine, node, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := engine.Export(ctx, node, snapshotVolume, f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// RestoreSnapshot recreates a cluster from a snapshot: its networks when
+// they are gone, then its node containers from the committed images with
+// their volume restored, and waits up to timeout for it to be ready. An
+// existing cluster is only replaced with force.
+func RestoreSnapshot(ctx context.Context, p ClusterProvider, clusterName, name string, force bool, timeout time.Duration) error {
+	sp, err := snapshotProvider(p)
+	if err != nil {
+		return err
+	}
+
+	providerType := p.GetProviderType()
+	configManager := config.GetManager()
+	snapshot, err := configManager.GetSnapshot(clusterName, string(providerType), name)
+	if err != nil {
+		return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error restoring snapshot %s", name).WithHint("blitzctl snapshot list")
+	}
+
+	var containers []*container.Container
+	if err := readSnapshotJSON(snapshot, snapshotContainersFile, &containers); err != nil {
+		return err
+	}
+	var networks []*container.Network
+	if err := readSnapshotJSON(snapshot, snapshotNetworksFile, &networks); err != nil {
+		return err
+	}
+
+	_, existing, err := sp.SnapshotNodes(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		if !force {
+			return errdefs.AlreadyExists("❌ Cluster '%s' already exists", clusterName).
+				WithHint("replace it with 'blitzctl snapshot restore %s --force'", name)
+		}
+		fmt.Printf("🧹 Replacing cluster '%s'...\n", clusterName)
+		if err := p.Delete(ctx, &Default{ClusterName: clusterName}); err != nil {
+			return err
+		}
+	}
+
+	engine := container.New(snapshot.Engine)
+	for _, network := range networks {
+		if engine.NetworkExists(ctx, network.Name) {
+			continue
+		}
+		fmt.Printf("🌐 Creating network %s...\n", network.Name)
+		if err := engine.CreateNetwork(ctx, network); err != nil {
+			return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error creating network %s", network.Name)
+		}
+	}
+
+	if err := sp.RestoreSnapshotFiles(snapshot); err != nil {
+		return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error restoring snapshot %s of cluster '%s'", name, clusterName)
+	}
+
+	var created []string
+	for _, c := range containers {
+		i := slices.IndexFunc(snapshot.Nodes, func(node config.SnapshotNode) bool { return node.Name == c.Name })
+		if i < 0 {
+			return errdefs.Invalid("❌ Snapshot %s has no image for node %s", name, c.Name).
+				WithHint("delete it with 'blitzctl snapshot delete %s'", name)
+		}
+		node := snapshot.Nodes[i]
+
+		fmt.Printf("📦 Restoring node %s from %s...\n", c.Name, node.Image)
+		err := engine.Create(ctx, c, node.Image)
+		if err == nil {
+			created = append(created, c.Name)
+			if node.Archive != "" {
+				err = importVolume(ctx, engine, c.Name, filepath.Join(snapshot.Dir, node.Archive))
+			}
+		}
+		if err != nil {
+			removeContainers(engine, created)
+			return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error restoring node %s", c.Name)
+		}
+	}
+
+	fmt.Printf("▶️ Starting cluster '%s'...\n", clusterName)
+	if err := sp.ResumeCluster(ctx, clusterName); err != nil {
+		return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error starting restored cluster '%s'", clusterName)
+	}
+
+	cluster := snapshot.Cluster
+	cluster.Status = StatusRunning
+	if err := configManager.AddCluster(cluster); err != nil {
+		slog.Warn("Failed to save cluster information", "error", err)
+	}
+	fmt.Printf("✅ Cluster '%s' restored from snapshot %s\n", clusterName, name)
+
+	return waitReady(ctx, providerType, clusterName, WaitOptions{Wait: true, Timeout: timeout})
+}
+
+// importVolume extracts the archive of a node's volume at path into the node
+func importVolume(ctx context.Context, engine *container.Engine, node, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	// The archive holds the volume directory itself
+	return engine.Import(ctx, node, "/", f)
+}
+
+// removeContainers removes the containers of a restore that failed
+func removeContainers(engine *container.Engine, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), RollbackTimeout)
+	defer cancel()
+	if err := engine.Remove(ctx, names...); err != nil {
+		slog.Warn("Failed to remove restored nodes", "nodes", names, "error", err)
+	}
+}
+
+// DeleteSnapshot removes the images and files of a snapshot and forgets it
+func DeleteSnapshot(ctx context.Context, snapshot config.SnapshotInfo) error {
+	removeSnapshotFiles(container.New(snapshot.Engine), &snapshot)
+	err := config.GetManager().RemoveSnapshot(snapshot.Cluster.Name, snapshot.Cluster.Provider, snapshot.Name)
+	if err != nil && !errdefs.Is(err, errdefs.KindNotFound) {
+		return err
+	}
+	return nil
+}
+
+// removeSnapshotFiles removes the images and the directory of a snapshot,
+// logging what could not be removed
+func removeSnapshotFiles(engine *container.Engine, snapshot *config.SnapshotInfo) {
+	ctx, cancel := context.WithTimeout(context.Background(), RollbackTimeout)
+	defer cancel()
+
+	for _, node := range snapshot.Nodes {
+		if err := engine.RemoveImage(ctx, node.Image); err != nil {
+			slog.Warn("Failed to remove snapshot image", "image", node.Image, "error", err)
+		}
+	}
+
+	// The directory comes from the state file, never remove anything
+	// outside the snapshots directory
+	root, err := SnapshotsDir()
+	if err != nil {
+		slog.Warn("Failed to remove snapshot files", "dir", snapshot.Dir, "error", err)
+		return
+	}
+	if rel, err := filepath.Rel(root, snapshot.Dir); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		slog.Warn("Snapshot directory outside of the snapshots directory, not removed", "dir", snapshot.Dir)
+		return
+	}
+	if err := os.RemoveAll(snapshot.Dir); err != nil {
+		slog.Warn("Failed to remove snapshot files", "dir", snapshot.Dir, "error", err)
+	}
+}
+
+// writeSnapshotJSON writes v to the file name of the snapshot directory
+func writeSnapshotJSON(snapshot *config.SnapshotInfo, name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(snapshot.Dir, name), append(data, '\n'), 0o644)
+}
+
+// readSnapshotJSON reads the file name of the snapshot directory into v
+func readSnapshotJSON(snapshot *config.SnapshotInfo, name string, v any) error {
+	path := filepath.Join(snapshot.Dir, name)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errdefs.Wrap(errdefs.KindNotFound, err, "❌ Snapshot %s is incomplete", snapshot.Name).
+			WithHint("delete it with 'blitzctl snapshot delete %s'", snapshot.Name)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errdefs.Wrap(errdefs.KindInvalid, err, "❌ Failed to parse %s", path)
+	}
+	return nil
+}
+
+// dirSize returns the size in bytes of the files under dir
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// FormatSize formats a size in bytes in MiB or GiB
+func FormatSize(size int64) string {
+	if size >= 1<<30 {
+		return fmt.Sprintf("%.1f GiB", float64(size)/(1<<30))
+	}
+	return fmt.Sprintf("%.1f MiB", float64(size)/(1<<20))
+}
@@ -0,0 +1,268 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// fakeEngine is a container engine script logging its commands to
+// $ENGINE_LOG. Nodes are running, control-plane has a /var volume, and
+// networks exist unless $NETWORK_GONE is set.
+const fakeEngine = `#!/bin/sh
+echo "engine $*" >> "$ENGINE_LOG"
+case "$1 $2" in
+"container inspect")
+	mounts=""
+	case "$3" in *control-plane) mounts='{"Type": "volume", "Destination": "/var"}' ;; esac
+	echo "[{\"Name\": \"/$3\", \"Mounts\": [$mounts], \"NetworkSettings\": {\"Networks\": {\"kind\": {}}}, \"State\": {\"Running\": true}}]"
+	;;
+"network inspect")
+	[ -z "$NETWORK_GONE" ] || exit 1
+	echo '[{"Name": "kind", "Driver": "bridge"}]'
+	;;
+"cp "*)
+	[ "$2" = "-" ] && cat > /dev/null || echo "var archive"
+	;;
+esac
+`
+
+// snapshotFake is a kind-like provider whose nodes run on the fake engine,
+// logging its calls next to the engine's
+type snapshotFake struct {
+	ClusterProvider
+	engine string
+	log    string
+	// nodes are the node containers of the cluster, none when it is gone
+	nodes []string
+}
+
+func (p *snapshotFake) record(call string) {
+	f, err := os.OpenFile(p.log, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		panic(err)
+	}
+	defer func() { _ = f.Close() }()
+	_, _ = f.WriteString(call + "\n")
+}
+
+func (p *snapshotFake) GetProviderType() ProviderType {
+	return Kind
+}
+
+func (p *snapshotFake) SnapshotNodes(ctx context.Context, clusterName string) (string, []string, error) {
+	return p.engine, p.nodes, nil
+}
+
+func (p *snapshotFake) SuspendCluster(ctx context.Context, clusterName string) error {
+	p.record("suspend " + clusterName)
+	return nil
+}
+
+func (p *snapshotFake) ResumeCluster(ctx context.Context, clusterName string) error {
+	p.record("resume " + clusterName)
+	return nil
+}
+
+func (p *snapshotFake) SaveSnapshotFiles(snapshot *config.SnapshotInfo) error {
+	p.record("save files " + snapshot.Name)
+	return nil
+}
+
+func (p *snapshotFake) RestoreSnapshotFiles(snapshot *config.SnapshotInfo) error {
+	p.record("restore files " + snapshot.Name)
+	return nil
+}
+
+func (p *snapshotFake) Delete(ctx context.Context, options *Default) error {
+	p.record("delete " + options.ClusterName)
+	p.nodes = nil
+	return nil
+}
+
+// newSnapshotFake returns a provider of cluster dev with two nodes on the
+// fake engine, the snapshots kept in a temporary data directory
+func newSnapshotFake(t *testing.T) *snapshotFake {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake engine is a shell script")
+	}
+	dir := t.TempDir()
+	engine := filepath.Join(dir, "docker")
+	if err := os.WriteFile(engine, []byte(fakeEngine), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	log := filepath.Join(dir, "calls.log")
+	t.Setenv("ENGINE_LOG", log)
+	t.Setenv("NETWORK_GONE", "")
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	// No cluster answers, restoring ends failing to connect to it
+	t.Setenv("KUBECONFIG", filepath.Join(dir, "kubeconfig"))
+	trackCluster(t, Kind)
+
+	return &snapshotFake{engine: engine, log: log, nodes: []string{"dev-control-plane", "dev-worker"}}
+}
+
+// calls returns the calls logged since the last one, with the engine's
+// path shortened
+func (p *snapshotFake) calls(t *testing.T) []string {
+	t.Helper()
+	data, err := os.ReadFile(p.log)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	if err := os.Remove(p.log); err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	var calls []string
+	for line := range strings.Lines(string(data)) {
+		calls = append(calls, strings.TrimSpace(line))
+	}
+	return calls
+}
+
+func TestSaveSnapshot(t *testing.T) {
+	p := newSnapshotFake(t)
+
+	if err := SaveSnapshot(context.Background(), p, "dev", "golden", false); err != nil {
+		t.Fatalf("SaveSnapshot() error = %v", err)
+	}
+	want := []string{
+		"engine container inspect dev-control-plane",
+		"engine container inspect dev-worker",
+		// Stopped before anything is committed, started after the files
+		// are saved
+		"suspend dev",
+		"engine commit dev-control-plane blitzctl-snapshot/dev-control-plane:golden",
+		"engine cp dev-control-plane:/var -",
+		"engine network inspect kind",
+		"engine commit dev-worker blitzctl-snapshot/dev-worker:golden",
+		"save files golden",
+		"resume dev",
+	}
+	if got := p.calls(t); !slices.Equal(got, want) {
+		t.Errorf("calls =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	snapshot, err := config.GetManager().GetSnapshot("dev", string(Kind), "golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot.Nodes) != 2 || snapshot.Nodes[0].Archive != "dev-control-plane-var.tar" || snapshot.Nodes[1].Archive != "" {
+		t.Errorf("snapshot nodes = %+v, want the control plane volume archived", snapshot.Nodes)
+	}
+	for _, file := range []string{snapshotContainersFile, snapshotNetworksFile, "dev-control-plane-var.tar"} {
+		if _, err := os.Stat(filepath.Join(snapshot.Dir, file)); err != nil {
+			t.Errorf("snapshot file missing: %v", err)
+		}
+	}
+
+	// The same name again is refused unless forced
+	if err := SaveSnapshot(context.Background(), p, "dev", "golden", false); errdefs.KindOf(err) != errdefs.KindAlreadyExists {
+		t.Errorf("second SaveSnapshot() error = %v, want already exists", err)
+	}
+	if calls := p.calls(t); len(calls) != 0 {
+		t.Errorf("refused snapshot ran %v", calls)
+	}
+	if err := SaveSnapshot(context.Background(), p, "dev", "golden", true); err != nil {
+		t.Fatalf("forced SaveSnapshot() error = %v", err)
+	}
+	calls := p.calls(t)
+	replaced := []string{"engine rmi --force blitzctl-snapshot/dev-control-plane:golden", "engine rmi --force blitzctl-snapshot/dev-worker:golden"}
+	if len(calls) < 2 || !slices.Equal(calls[:2], replaced) {
+		t.Errorf("forced snapshot calls = %v, want the old images removed first", calls)
+	}
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		clusterGone bool
+		networkGone bool
+		force       bool
+		want        []string
+		wantKind    errdefs.Kind
+		wantInError string
+	}{
+		{
+			name:     "cluster exists",
+			wantKind: errdefs.KindAlreadyExists,
+		},
+		{
+			name:  "cluster replaced",
+			force: true,
+			want: []string{
+				"delete dev",
+				"engine network inspect kind",
+				"restore files golden",
+				"engine create --name dev-control-plane",
+				"engine cp - dev-control-plane:/",
+				"engine create --name dev-worker",
+				"resume dev",
+			},
+			wantInError: "Error connecting to cluster 'dev'",
+		},
+		{
+			name:        "network recreated first",
+			clusterGone: true,
+			networkGone: true,
+			want: []string{
+				"engine network inspect kind",
+				"engine network create --driver bridge kind",
+				"restore files golden",
+				"engine create --name dev-control-plane",
+				"engine cp - dev-control-plane:/",
+				"engine create --name dev-worker",
+				"resume dev",
+			},
+			wantInError: "Error connecting to cluster 'dev'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newSnapshotFake(t)
+			if err := SaveSnapshot(context.Background(), p, "dev", "golden", false); err != nil {
+				t.Fatal(err)
+			}
+			p.calls(t)
+			if tt.clusterGone {
+				p.nodes = nil
+			}
+			if tt.networkGone {
+				t.Setenv("NETWORK_GONE", "1")
+			}
+
+			err := RestoreSnapshot(context.Background(), p, "dev", "golden", tt.force, 0)
+			if tt.wantInError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantInError) {
+					t.Fatalf("RestoreSnapshot() error = %v, want %q", err, tt.wantInError)
+				}
+			} else if got := errdefs.KindOf(err); got != tt.wantKind {
+				t.Fatalf("RestoreSnapshot() error = %v, want kind %q", err, tt.wantKind)
+			}
+
+			// Creating a node passes its whole configuration, compare the
+			// start of the command
+			var got []string
+			for _, call := range p.calls(t) {
+				if strings.HasPrefix(call, "engine create ") {
+					call = strings.Join(strings.Fields(call)[:4], " ")
+				}
+				got = append(got, call)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("calls =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
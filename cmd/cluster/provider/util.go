@@ -4,8 +4,9 @@ Copyright © 2026 Oneide Luiz Schneider
 package provider
 
 import (
-	"fmt"
 	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
 )
 
 // ProviderAliases maps supported aliases to their provider type.
@@ -24,5 +25,5 @@ func ParseProvider(input string) (ProviderType, error) {
 		return providerType, nil
 	}
 
-	return "", fmt.Errorf("❌ Unsupported provider: %s (supported: minikube, kind)", input)
+	return "", errdefs.Unsupported("❌ Unsupported provider: %s (supported: minikube, kind)", input)
 }
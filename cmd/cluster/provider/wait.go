@@ -0,0 +1,70 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/kube"
+)
+
+// Cluster statuses recorded in ClusterInfo
+const (
+	StatusRunning  = "running"
+	StatusDegraded = "degraded"
+)
+
+// waitReady blocks until the cluster's nodes are Ready and its kube-system
+// workloads available, recording the outcome in the cluster's status
+func waitReady(ctx context.Context, providerType ProviderType, clusterName string, options WaitOptions) error {
+	if !options.Wait {
+		return nil
+	}
+
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+
+	kubeContext := kube.ContextName(string(providerType), clusterName)
+	client, err := kube.NewClient(kubeContext)
+	if err != nil {
+		return fmt.Errorf("❌ Error connecting to cluster '%s': %w", clusterName, err)
+	}
+
+	fmt.Printf("⏳ Waiting up to %s for cluster '%s' to be ready...\n", timeout, clusterName)
+	if err := kube.WaitReady(ctx, client, timeout, os.Stdout); err != nil {
+		if errors.Is(err, kube.ErrNotReady) {
+			setClusterStatus(providerType, clusterName, StatusDegraded)
+			return errdefs.Wrap(errdefs.KindTimeout, err, "❌ Cluster '%s' is degraded", clusterName).
+				WithHint("inspect it with 'kubectl --context %s get pods -n kube-system' or retry with a longer --timeout", kubeContext)
+		}
+		return err
+	}
+
+	setClusterStatus(providerType, clusterName, StatusRunning)
+	fmt.Printf("✅ Cluster '%s' is ready\n", clusterName)
+	return nil
+}
+
+// setClusterStatus updates the status of a tracked cluster
+func setClusterStatus(providerType ProviderType, clusterName, status string) {
+	configManager := config.GetManager()
+	cluster, err := configManager.GetCluster(clusterName, string(providerType))
+	if err != nil {
+		slog.Debug("cluster not tracked, status not recorded", "cluster", clusterName, "status", status)
+		return
+	}
+
+	cluster.Status = status
+	if err := configManager.AddCluster(*cluster); err != nil {
+		slog.Warn("Failed to save cluster status", "error", err)
+	}
+}
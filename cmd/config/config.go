@@ -18,6 +18,15 @@ var (
 		blitzctl config set driver docker
 		blitzctl config set k8s-version 1.35.0
 		blitzctl config set cluster-name my-cluster
+		blitzctl config set resources.cpus 4
+		blitzctl config set wait-timeout 10m
+
+		# Append to a list, or write the project configuration
+		blitzctl config set extra-config kubelet.max-pods=150 --append
+		blitzctl config set nodes 3 --local
+
+		# Reset a value to its built-in default
+		blitzctl config unset nodes
 
 		# Get a specific configuration value
 		blitzctl config get driver
@@ -28,6 +37,18 @@ var (
 
 		# Show configuration file location
 		blitzctl config view
+
+		# Show which layer (flag, environment, profile, file) a value comes from
+		blitzctl config explain driver
+
+		# Validate the configuration file
+		blitzctl config validate
+
+		# Edit the configuration file, invalid edits are refused
+		blitzctl config edit
+
+		# Switch to a named profile of defaults
+		blitzctl config profile use integration
 	`))
 
 	configCmd = &cobra.Command{
@@ -53,6 +74,11 @@ func GetConfigCmd() *cobra.Command {
 func init() {
 	configCmd.AddCommand(getCmd)
 	configCmd.AddCommand(setCmd)
+	configCmd.AddCommand(unsetCmd)
 	configCmd.AddCommand(listCmd)
 	configCmd.AddCommand(viewCmd)
+	configCmd.AddCommand(validateCmd)
+	configCmd.AddCommand(editCmd)
+	configCmd.AddCommand(explainCmd)
+	configCmd.AddCommand(profileCmd)
 }
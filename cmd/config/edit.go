@@ -0,0 +1,162 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/command"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/prompt"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	editLocal  bool
+	editGlobal bool
+
+	editExamples = templates.Examples(i18n.T(`
+		# Edit the configuration file in use
+		blitzctl config edit
+
+		# Edit the project configuration with another editor
+		EDITOR="code --wait" blitzctl config edit --local
+	`))
+
+	editCmd = &cobra.Command{
+		Use:   "edit",
+		Short: "Edit the configuration file in $EDITOR",
+		Long: `Open the configuration file in $VISUAL or $EDITOR (vi by default). The file
+is validated when the editor exits and only saved when it is valid, an
+invalid edit can be reopened to fix it and is never written.`,
+		Example: editExamples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := targetManager(editLocal, editGlobal)
+			if err != nil {
+				return fmt.Errorf("❌ Error editing configuration: %w", err)
+			}
+
+			path, original, err := manager.ReadFile()
+			if err != nil {
+				return fmt.Errorf("❌ Error editing configuration: %w", err)
+			}
+			content := original
+			if len(content) == 0 {
+				content = []byte(newFileTemplate())
+			}
+
+			// Edit a copy so an invalid file never replaces the real one
+			tmp, err := os.CreateTemp("", "blitzctl-config-*.yaml")
+			if err != nil {
+				return fmt.Errorf("❌ Error editing configuration: %w", err)
+			}
+			keep := false
+			defer func() {
+				if keep {
+					return
+				}
+				if err := os.Remove(tmp.Name()); err != nil {
+					slog.Debug("Failed to remove the edited copy", "file", tmp.Name(), "error", err)
+				}
+			}()
+			if err := tmp.Close(); err != nil {
+				return fmt.Errorf("❌ Error editing configuration: %w", err)
+			}
+			if err := os.WriteFile(tmp.Name(), content, 0600); err != nil {
+				return fmt.Errorf("❌ Error editing configuration: %w", err)
+			}
+
+			for {
+				if err := runEditor(cmd, tmp.Name()); err != nil {
+					return err
+				}
+				edited, err := os.ReadFile(tmp.Name())
+				if err != nil {
+					return fmt.Errorf("❌ Error editing configuration: %w", err)
+				}
+				if bytes.Equal(edited, content) {
+					fmt.Println("Edit cancelled, no changes made")
+					return nil
+				}
+
+				if err := config.ValidateData(path, edited); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+				} else if err := manager.ReplaceFile(original, edited); err != nil {
+					keep = true
+					return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error editing configuration").
+						WithHint("your changes are kept in %s", tmp.Name())
+				} else {
+					fmt.Printf("✅ Saved %s\n", path)
+					return nil
+				}
+
+				reopen := false
+				if prompt.Interactive() {
+					reopen, err = prompt.New(os.Stdin, os.Stdout).Confirm("Reopen the editor to fix it?", true)
+					if err != nil {
+						return err
+					}
+				}
+				if !reopen {
+					keep = true
+					return errdefs.Invalid("❌ Refusing to save an invalid configuration, %s is unchanged", path).
+						WithHint("your changes are kept in %s", tmp.Name())
+				}
+			}
+		},
+	}
+)
+
+func init() {
+	addTargetFlags(editCmd, &editLocal, &editGlobal)
+}
+
+// runEditor opens path in the user's editor and waits for it to exit. The
+// editor may carry arguments, e.g. "code --wait".
+func runEditor(cmd *cobra.Command, path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	args := strings.Fields(editor)
+	editorCmd := command.Context(cmd.Context(), args[0], append(args[1:], path)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := command.Run(editorCmd); err != nil {
+		return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Editor %s failed", filepath.Base(args[0])).
+			WithHint("set $EDITOR to the editor to use")
+	}
+	return nil
+}
+
+// newFileTemplate is the starting point when the configuration file doesn't
+// exist yet
+func newFileTemplate() string {
+	return fmt.Sprintf(`# blitzctl configuration, see 'blitzctl config set --help' for every key
+apiVersion: %s
+defaults:
+  # cluster_name: %s
+  # driver: %s
+  # cni: %s
+  # k8s_version: %s
+`, config.CurrentAPIVersion, config.DefaultClusterName, config.DefaultDriver, config.DefaultCni, config.DefaultK8sVersion)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+)
+
+// fakeEditors puts editors on PATH that write their name and arguments to
+// the edited file, the broken one fails
+func fakeEditors(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake editors are shell scripts")
+	}
+	dir := t.TempDir()
+	scripts := map[string]string{
+		"vi":     "#!/bin/sh\nfor file; do :; done\necho \"vi $*\" > \"$file\"\n",
+		"code":   "#!/bin/sh\nfor file; do :; done\necho \"code $*\" > \"$file\"\n",
+		"nano":   "#!/bin/sh\nfor file; do :; done\necho \"nano $*\" > \"$file\"\n",
+		"broken": "#!/bin/sh\nexit 3\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+}
+
+func TestRunEditor(t *testing.T) {
+	fakeEditors(t)
+
+	tests := []struct {
+		name    string
+		visual  string
+		editor  string
+		want    string
+		wantErr string
+	}{
+		{name: "vi by default", want: "vi"},
+		{name: "EDITOR", editor: "nano", want: "nano"},
+		{name: "VISUAL over EDITOR", visual: "code --wait", editor: "nano", want: "code --wait"},
+		{name: "failing editor", editor: "broken", wantErr: "Editor broken failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", tt.visual)
+			t.Setenv("EDITOR", tt.editor)
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			cmd := &cobra.Command{}
+			cmd.SetContext(context.Background())
+
+			err := runEditor(cmd, path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("runEditor() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runEditor() error = %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := strings.TrimSpace(string(data)), tt.want+" "+path; got != want {
+				t.Errorf("editor ran as %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestNewFileTemplate(t *testing.T) {
+	if err := config.ValidateData("config.yaml", []byte(newFileTemplate())); err != nil {
+		t.Errorf("the new file template is not valid: %v", err)
+	}
+}
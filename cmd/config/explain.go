@@ -0,0 +1,87 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [key]",
+	Short: "Show where a configuration value comes from",
+	Long: `Show the effective value of a configuration key and the layer it comes from.
+Layers, highest precedence first: command-line flag, environment variable
+(BLITZCTL_DEFAULTS_<KEY>), profile, config file and built-in default.
+Without a key every key is summarized.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager := config.GetManager()
+
+		if len(args) == 0 {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+			for _, key := range config.DefaultsKeys() {
+				layers, err := manager.Explain(key)
+				if err != nil {
+					return err
+				}
+				if layer, ok := effectiveLayer(layers); ok {
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", key, layer.Value, describeLayer(layer))
+				}
+			}
+			return w.Flush()
+		}
+
+		layers, err := manager.Explain(args[0])
+		if err != nil {
+			return fmt.Errorf("❌ Error explaining configuration: %w", err)
+		}
+
+		if layer, ok := effectiveLayer(layers); ok {
+			fmt.Printf("%s: %s (from %s)\n", args[0], layer.Value, describeLayer(layer))
+		}
+
+		fmt.Println("\nLayers, highest precedence first:")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		effective := true
+		for _, layer := range layers {
+			marker, value := " ", "(not set)"
+			if layer.Set {
+				value = layer.Value
+				if effective {
+					marker, effective = "→", false
+				}
+			}
+			name := layer.Name
+			if name == "" {
+				name = "-"
+			}
+			_, _ = fmt.Fprintf(w, "%s %s\t%s\t%s\n", marker, layer.Source, name, value)
+		}
+		return w.Flush()
+	},
+}
+
+// effectiveLayer returns the layer giving the effective value
+func effectiveLayer(layers []config.Layer) (config.Layer, bool) {
+	for _, layer := range layers {
+		if layer.Set {
+			return layer, true
+		}
+	}
+	return config.Layer{}, false
+}
+
+// describeLayer names the source of a layer for humans
+func describeLayer(layer config.Layer) string {
+	if layer.Name == "" {
+		return string(layer.Source)
+	}
+	return fmt.Sprintf("%s %s", layer.Source, layer.Name)
+}
@@ -14,46 +14,45 @@ var getCmd = &cobra.Command{
 	Use:   "get [key]",
 	Short: "Get a configuration value",
 	Long: `Get a configuration value. If no key is specified, returns all current configuration.
-	
+
 Valid keys:
-  - driver
-  - k8s-version
-  - cluster-name
-  - cni
-  - helm-version`,
+` + keysHelp(),
 	Args: cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		manager := config.GetManager()
 
 		if len(args) == 0 {
 			// Display all configuration
-			cfg := manager.GetConfig()
+			defaults := manager.GetDefaults()
 			fmt.Println("Current Configuration:")
 			fmt.Println("===================")
-			fmt.Printf("Driver: %s\n", cfg.Defaults.Driver)
-			fmt.Printf("K8s Version: %s\n", cfg.Defaults.K8sVersion)
-			fmt.Printf("Cluster Name: %s\n", cfg.Defaults.ClusterName)
-			fmt.Printf("CNI: %s\n", cfg.Defaults.CNI)
-			fmt.Printf("Helm Version: %s\n", cfg.Defaults.HelmVersion)
+			if profile := manager.ActiveProfile(); profile != "" {
+				fmt.Printf("Profile: %s\n", profile)
+			}
+			fmt.Printf("Driver: %s\n", defaults.Driver)
+			fmt.Printf("K8s Version: %s\n", defaults.K8sVersion)
+			fmt.Printf("Cluster Name: %s\n", defaults.ClusterName)
+			fmt.Printf("CNI: %s\n", defaults.CNI)
+			fmt.Printf("Helm Version: %s\n", defaults.HelmVersion)
 
-			if cfg.CurrentContext != nil {
+			if currentContext := manager.GetCurrentContext(); currentContext != nil {
 				fmt.Println("\nCurrent Context:")
-				fmt.Printf("Cluster: %s (%s)\n", cfg.CurrentContext.Cluster, cfg.CurrentContext.Provider)
+				fmt.Printf("Cluster: %s (%s)\n", currentContext.Cluster, currentContext.Provider)
 			}
 
-			if len(cfg.Clusters) > 0 {
-				fmt.Printf("\nManaged Clusters: %d\n", len(cfg.Clusters))
+			if clusters := manager.ListClusters(); len(clusters) > 0 {
+				fmt.Printf("\nManaged Clusters: %d\n", len(clusters))
 			}
-			return
+			return nil
 		}
 
 		key := args[0]
 		value, err := manager.GetDefault(key)
 		if err != nil {
-			fmt.Printf("❌ Error getting configuration: %v\n", err)
-			return
+			return fmt.Errorf("❌ Error getting configuration: %w", err)
 		}
 
-		fmt.Printf("%s: %v\n", key, value)
+		fmt.Printf("%s: %s\n", key, config.FormatValue(value))
+		return nil
 	},
 }
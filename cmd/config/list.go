@@ -5,6 +5,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/OneideLuizSchneider/blitzctl/config"
 	"github.com/spf13/cobra"
@@ -17,26 +18,41 @@ var listCmd = &cobra.Command{
 	Long:    `List all configuration values including defaults and managed clusters.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		manager := config.GetManager()
-		cfg := manager.GetConfig()
+		defaults := manager.GetDefaults()
 
 		fmt.Println("Configuration Values:")
 		fmt.Println("====================")
+		if profile := manager.ActiveProfile(); profile != "" {
+			fmt.Printf("\nProfile: %s\n", profile)
+		}
+		if profiles := manager.ListProfiles(); len(profiles) > 0 {
+			fmt.Printf("Profiles: %s\n", strings.Join(profiles, ", "))
+		}
+
 		fmt.Println("\nDefaults:")
-		fmt.Printf("  Driver: %s\n", cfg.Defaults.Driver)
-		fmt.Printf("  K8s Version: %s\n", cfg.Defaults.K8sVersion)
-		fmt.Printf("  Cluster Name: %s\n", cfg.Defaults.ClusterName)
-		fmt.Printf("  CNI: %s\n", cfg.Defaults.CNI)
-		fmt.Printf("  Helm Version: %s\n", cfg.Defaults.HelmVersion)
+		fmt.Printf("  Driver: %s\n", defaults.Driver)
+		fmt.Printf("  K8s Version: %s\n", defaults.K8sVersion)
+		fmt.Printf("  Cluster Name: %s\n", defaults.ClusterName)
+		fmt.Printf("  CNI: %s\n", defaults.CNI)
+		fmt.Printf("  Helm Version: %s\n", defaults.HelmVersion)
 
-		if cfg.CurrentContext != nil {
+		fmt.Println("\nTool Versions:")
+		fmt.Printf("  kubectl: %s\n", defaults.KubectlVersion)
+		fmt.Printf("  kind: %s\n", defaults.KindVersion)
+		fmt.Printf("  minikube: %s\n", defaults.MinikubeVersion)
+		fmt.Printf("  k9s: %s\n", defaults.K9sVersion)
+		fmt.Printf("  kustomize: %s\n", defaults.KustomizeVersion)
+		fmt.Printf("  stern: %s\n", defaults.SternVersion)
+
+		if currentContext := manager.GetCurrentContext(); currentContext != nil {
 			fmt.Println("\nCurrent Context:")
-			fmt.Printf("  Cluster: %s\n", cfg.CurrentContext.Cluster)
-			fmt.Printf("  Provider: %s\n", cfg.CurrentContext.Provider)
+			fmt.Printf("  Cluster: %s\n", currentContext.Cluster)
+			fmt.Printf("  Provider: %s\n", currentContext.Provider)
 		}
 
-		if len(cfg.Clusters) > 0 {
+		if clusters := manager.ListClusters(); len(clusters) > 0 {
 			fmt.Println("\nManaged Clusters:")
-			for _, cluster := range cfg.Clusters {
+			for _, cluster := range clusters {
 				status := "❓"
 				switch cluster.Status {
 				case "running":
@@ -46,9 +62,13 @@ var listCmd = &cobra.Command{
 				case "deleted":
 					status = "❌"
 				}
-				fmt.Printf("  %s %s (%s) - %s - Created: %s\n",
+				profile := ""
+				if cluster.Profile != "" {
+					profile = " - Profile: " + cluster.Profile
+				}
+				fmt.Printf("  %s %s (%s) - %s - Created: %s%s\n",
 					status, cluster.Name, cluster.Provider, cluster.K8sVersion,
-					cluster.CreatedAt.Format("2006-01-02 15:04:05"))
+					cluster.CreatedAt.Format("2006-01-02 15:04:05"), profile)
 			}
 		} else {
 			fmt.Println("\nManaged Clusters: None")
@@ -60,5 +80,8 @@ var listCmd = &cobra.Command{
 		} else {
 			fmt.Println("\nConfig File: Not yet created (using defaults)")
 		}
+		if store, err := manager.StateStore(); err == nil {
+			fmt.Printf("State File: %s\n", store.Location())
+		}
 	},
 }
@@ -0,0 +1,52 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	profileExample = templates.Examples(i18n.T(`
+		# Create a profile overriding some defaults
+		blitzctl config profile create laptop-light cni=false
+		blitzctl config profile create integration cni=cilium k8s-version=1.34.4
+
+		# Use a profile for every command
+		blitzctl config profile use integration
+
+		# Use a profile for a single command
+		blitzctl create cluster --profile laptop-light
+
+		# Go back to the plain defaults
+		blitzctl config profile use default
+
+		# List and delete profiles
+		blitzctl config profile list
+		blitzctl config profile delete laptop-light
+	`))
+
+	profileCmd = &cobra.Command{
+		Use:     "profile",
+		Example: profileExample,
+		Short:   "Manage configuration profiles",
+		Long: `Manage named configuration profiles. A profile overrides some of the defaults,
+the others keep their configured value. The profile in use is set with
+'config profile use' and can be replaced for a single command with --profile.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmd.Help(); err != nil {
+				cmd.PrintErrln("❌ Error displaying help:", err)
+			}
+		},
+	}
+)
+
+func init() {
+	profileCmd.AddCommand(profileCreateCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+var (
+	profileCreateFrom string
+
+	profileCreateCmd = &cobra.Command{
+		Use:   "create <name> [key=value...]",
+		Short: "Create a configuration profile",
+		Long: `Create a configuration profile overriding the given defaults. Keys are the
+ones of 'config set'.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			manager := config.GetManager()
+
+			var profile config.Defaults
+			// The keys the profile sets, false and 0 included
+			var keys []string
+			if profileCreateFrom != "" {
+				from, err := manager.GetProfile(profileCreateFrom)
+				if err != nil {
+					return fmt.Errorf("❌ Error creating profile: %w", err)
+				}
+				profile = from
+				for _, key := range config.DefaultsKeys() {
+					if manager.ProfileSets(profileCreateFrom, key) {
+						keys = append(keys, key)
+					}
+				}
+			}
+
+			for _, arg := range args[1:] {
+				key, value, ok := strings.Cut(arg, "=")
+				if !ok {
+					return errdefs.Invalid("❌ %q is not key=value", arg)
+				}
+				if err := config.SetDefaultIn(&profile, key, value); err != nil {
+					return fmt.Errorf("❌ Error creating profile: %w", err)
+				}
+				keys = append(keys, key)
+			}
+
+			if err := manager.CreateProfile(name, profile, keys...); err != nil {
+				return fmt.Errorf("❌ Error creating profile: %w", err)
+			}
+
+			fmt.Printf("✅ Created profile %s\n", name)
+			return nil
+		},
+	}
+)
+
+func init() {
+	profileCreateCmd.Flags().StringVar(&profileCreateFrom, "from", "", i18n.T("Start from the values of an existing profile."))
+}
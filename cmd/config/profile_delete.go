@@ -0,0 +1,29 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+)
+
+var profileDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a configuration profile",
+	Long:    `Delete a configuration profile. If it was in use, the plain defaults are used again.`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		if err := config.GetManager().DeleteProfile(name); err != nil {
+			return fmt.Errorf("❌ Error deleting profile: %w", err)
+		}
+
+		fmt.Printf("✅ Deleted profile %s\n", name)
+		return nil
+	},
+}
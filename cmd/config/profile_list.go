@@ -0,0 +1,46 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+)
+
+var profileListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List configuration profiles",
+	Long:    `List the configuration profiles and the defaults each one overrides.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager := config.GetManager()
+		active := manager.ActiveProfile()
+
+		marker := func(name string) string {
+			if name == active {
+				return "*"
+			}
+			return " "
+		}
+
+		fmt.Println("Configuration Profiles:")
+		fmt.Printf("%s %s\n", marker(""), config.DefaultProfile)
+		for _, name := range manager.ListProfiles() {
+			profile, err := manager.GetProfile(name)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s %s\n", marker(name), name)
+			for _, field := range config.DefaultsKeys() {
+				if manager.ProfileSets(name, field) {
+					value, _ := config.GetDefaultIn(profile, field)
+					fmt.Printf("    %s: %s\n", field, config.FormatValue(value))
+				}
+			}
+		}
+		return nil
+	},
+}
@@ -0,0 +1,29 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+)
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the profile in use",
+	Long: `Set the profile applied to the defaults when --profile isn't given.
+Use "default" to apply no profile.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		if err := config.GetManager().UseProfile(name); err != nil {
+			return fmt.Errorf("❌ Error setting profile: %w", err)
+		}
+
+		fmt.Printf("✅ Switched to profile: %s\n", name)
+		return nil
+	},
+}
@@ -5,39 +5,104 @@ package config
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/OneideLuizSchneider/blitzctl/config"
 	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
 )
 
-var setCmd = &cobra.Command{
-	Use:   "set <key> <value>",
-	Short: "Set a configuration value",
-	Long: `Set a configuration value and save it to the config file.
-	
+var (
+	setAppend bool
+	setLocal  bool
+	setGlobal bool
+
+	setCmd = &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a configuration value",
+		Long: `Set a configuration value and save it to the config file. Values are parsed
+according to the type of the key, lists are comma separated. Nested keys use
+dots, e.g. resources.cpus.
+
 Valid keys:
-  - driver (e.g., docker, podman, virtualbox)
-  - k8s-version (e.g., 1.32.0, 1.33.4)
-  - cluster-name (e.g., my-cluster)
-  - cni (e.g., cilium, flannel, calico)
-  - helm-version (e.g., v3.18.6)`,
-	Args: cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		key := args[0]
-		value := args[1]
-
-		manager := config.GetManager()
-
-		if err := manager.SetDefault(key, value); err != nil {
-			fmt.Printf("❌ Error setting configuration: %v\n", err)
-			return
-		}
+` + keysHelp(),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := args[0]
+			value := args[1]
+
+			manager, err := targetManager(setLocal, setGlobal)
+			if err != nil {
+				return fmt.Errorf("❌ Error setting configuration: %w", err)
+			}
 
-		fmt.Printf("✅ Set %s = %s\n", key, value)
+			if setAppend {
+				err = manager.AppendDefault(key, value)
+			} else {
+				err = manager.SetDefault(key, value)
+			}
+			if err != nil {
+				return fmt.Errorf("❌ Error setting configuration: %w", err)
+			}
+
+			saved, _ := config.GetDefaultIn(manager.GetConfig().Defaults, key)
+			fmt.Printf("✅ Set %s = %s\n", key, config.FormatValue(saved))
+
+			global := config.GetManager()
+			if profile := global.ActiveProfile(); profile != "" {
+				if overlay, err := global.GetProfile(profile); err == nil && global.ProfileSets(profile, key) {
+					overridden, _ := config.GetDefaultIn(overlay, key)
+					fmt.Printf("⚠️ Profile %s overrides %s with %s\n", profile, key, config.FormatValue(overridden))
+				}
+			}
+
+			// Show config file location
+			if configPath := manager.GetConfigFilePath(); configPath != "" {
+				fmt.Printf("Configuration saved to: %s\n", configPath)
+			}
+			return nil
+		},
+	}
+)
 
-		// Show config file location
-		if configPath := manager.GetConfigFilePath(); configPath != "" {
-			fmt.Printf("Configuration saved to: %s\n", configPath)
+func init() {
+	setCmd.Flags().BoolVar(&setAppend, "append", false, i18n.T("Append the comma separated items to a list instead of replacing it."))
+	addTargetFlags(setCmd, &setLocal, &setGlobal)
+}
+
+// addTargetFlags adds --local and --global, selecting the file a command writes
+func addTargetFlags(cmd *cobra.Command, local, global *bool) {
+	cmd.Flags().BoolVar(local, "local", false, i18n.T("Write the project configuration (./.blitzctl/config.yaml)."))
+	cmd.Flags().BoolVar(global, "global", false, i18n.T("Write the user configuration (~/.blitzctl/config.yaml)."))
+	cmd.MarkFlagsMutuallyExclusive("local", "global")
+}
+
+// targetManager returns the manager of the file selected by --local or
+// --global, the one of the file in use otherwise
+func targetManager(local, global bool) (*config.Manager, error) {
+	switch {
+	case local:
+		path, err := config.LocalConfigFile()
+		if err != nil {
+			return nil, err
 		}
-	},
+		return config.OpenFile(path)
+	case global:
+		path, err := config.GlobalConfigFile()
+		if err != nil {
+			return nil, err
+		}
+		return config.OpenFile(path)
+	default:
+		return config.GetManager(), nil
+	}
+}
+
+// keysHelp lists every configuration key and its type
+func keysHelp() string {
+	var help strings.Builder
+	for _, key := range config.DefaultsKeys() {
+		fmt.Fprintf(&help, "  - %s (%s)\n", key, config.KeyType(key))
+	}
+	return strings.TrimSuffix(help.String(), "\n")
 }
@@ -15,7 +15,7 @@ var testCmd = &cobra.Command{
 	Use:    "test-add-cluster",
 	Short:  "Add a test cluster to configuration (for testing)",
 	Hidden: true, // Hide from help
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		manager := config.GetManager()
 
 		// Add a test cluster
@@ -29,12 +29,12 @@ var testCmd = &cobra.Command{
 		}
 
 		if err := manager.AddCluster(testCluster); err != nil {
-			fmt.Printf("❌ Error adding test cluster: %v\n", err)
-			return
+			return fmt.Errorf("❌ Error adding test cluster: %w", err)
 		}
 
 		fmt.Println("✅ Test cluster added successfully")
 		fmt.Println("Run 'blitzctl config list' to see the tracked cluster")
+		return nil
 	},
 }
 
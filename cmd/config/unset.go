@@ -0,0 +1,50 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	unsetLocal  bool
+	unsetGlobal bool
+
+	unsetCmd = &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a configuration value from the config file",
+		Long: `Remove a configuration value from the config file, so the config files
+below it, or the built-in default, apply again.
+
+Valid keys:
+` + keysHelp(),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := args[0]
+
+			manager, err := targetManager(unsetLocal, unsetGlobal)
+			if err != nil {
+				return fmt.Errorf("❌ Error unsetting configuration: %w", err)
+			}
+			if err := manager.UnsetDefault(key); err != nil {
+				return fmt.Errorf("❌ Error unsetting configuration: %w", err)
+			}
+
+			value, _ := config.GetDefaultIn(manager.GetConfig().Defaults, key)
+			fmt.Printf("✅ Unset %s, now %s\n", key, config.FormatValue(value))
+
+			if configPath := manager.GetConfigFilePath(); configPath != "" {
+				fmt.Printf("Configuration saved to: %s\n", configPath)
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	addTargetFlags(unsetCmd, &unsetLocal, &unsetGlobal)
+}
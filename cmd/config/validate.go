@@ -0,0 +1,42 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Validate a configuration file",
+	Long: `Validate a configuration file against the schema of this blitzctl version.
+Unknown keys, unsupported drivers or CNIs, malformed versions and invalid
+cluster names are all reported at once. Without a file every configuration
+file in use is validated, /etc/blitzctl, ~/.blitzctl and ./.blitzctl.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths := config.GetManager().GetConfigFilePaths()
+		if len(args) > 0 {
+			paths = args[:1]
+		}
+		if len(paths) == 0 {
+			fmt.Println("No configuration file found, using defaults")
+			return nil
+		}
+
+		var problems []error
+		for _, path := range paths {
+			if err := config.ValidateFile(path); err != nil {
+				problems = append(problems, err)
+				continue
+			}
+			fmt.Printf("✅ %s is valid\n", path)
+		}
+		return errors.Join(problems...)
+	},
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
 	"github.com/spf13/cobra"
 )
 
@@ -16,14 +17,14 @@ var viewCmd = &cobra.Command{
 	Short: "View configuration file contents and location",
 	Long: `View the current configuration file contents and show where it's located.
 This command displays the raw YAML configuration file.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		manager := config.GetManager()
 		configPath := manager.GetConfigFilePath()
 
 		if configPath == "" {
 			fmt.Println("No configuration file found. Using default values.")
 			fmt.Println("Run 'blitzctl config set <key> <value>' to create a configuration file.")
-			return
+			return nil
 		}
 
 		fmt.Printf("Configuration file: %s\n", configPath)
@@ -33,10 +34,10 @@ This command displays the raw YAML configuration file.`,
 		// Read and display file contents
 		content, err := os.ReadFile(configPath)
 		if err != nil {
-			fmt.Printf("❌ Error reading configuration file: %v\n", err)
-			return
+			return errdefs.Wrap(errdefs.KindConfig, err, "❌ Error reading configuration file")
 		}
 
 		fmt.Println(string(content))
+		return nil
 	},
 }
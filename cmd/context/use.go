@@ -16,17 +16,17 @@ var useCmd = &cobra.Command{
 	Long: `Set the active cluster context to the specified cluster and provider.
 The cluster must be already managed by blitzctl.`,
 	Args: cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		clusterName := args[0]
 		provider := args[1]
 
 		manager := config.GetManager()
 
 		if err := manager.SetCurrentContext(clusterName, provider); err != nil {
-			fmt.Printf("❌ Error setting context: %v\n", err)
-			return
+			return fmt.Errorf("❌ Error setting context: %w", err)
 		}
 
 		fmt.Printf("✅ Switched to context: %s (%s)\n", clusterName, provider)
+		return nil
 	},
 }
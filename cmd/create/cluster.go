@@ -4,10 +4,11 @@ Copyright © 2025 Oneide Luiz Schneider
 package create
 
 import (
-	"fmt"
+	"time"
 
 	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
 	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
 	"github.com/spf13/cobra"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -20,6 +21,36 @@ var (
 
 		# Create a minikube cluster
 		blitzctl create cluster --provider minikube --cluster-name=mycluster
+
+		# Create a kind cluster and wait until it is ready
+		blitzctl create cluster --provider kind --cluster-name=mycluster --wait --timeout 5m
+
+		# Create a kind cluster without the preflight checks
+		blitzctl create cluster --provider kind --cluster-name=mycluster --skip-preflight
+
+		# Create a three node minikube cluster with 4 CPUs and 8 GB per node
+		blitzctl create cluster --cluster-name=mycluster --nodes 3 --cpus 4 --memory 8g
+
+		# Create a cluster with the defaults of the "integration" profile
+		blitzctl create cluster --profile integration
+
+		# Create a kind cluster with calico instead of kindnet
+		blitzctl create cluster --provider kind --cluster-name=mycluster --cni calico
+
+		# Create a kind cluster with metrics-server and ingress-nginx
+		blitzctl create cluster --provider kind --cluster-name=mycluster --addons metrics-server,ingress-nginx
+
+		# Apply the manifests of ./k8s/bootstrap, a kustomization or plain YAML files
+		blitzctl create cluster --provider kind --cluster-name=mycluster --bootstrap-dir ./k8s/bootstrap
+
+		# Spin up a second cluster identical to mycluster
+		blitzctl create cluster --from mycluster --cluster-name=pr-123
+
+		# Create a cluster from the "ci" template, with a newer k8s version
+		blitzctl create cluster --template ci --cluster-name=pr-123 --k8s-version=1.35.0
+
+		# Keep the cluster around for debugging if it fails to come up
+		blitzctl create cluster --provider kind --cluster-name=mycluster --wait --keep-on-failure
 	`))
 
 	clusterCmd = &cobra.Command{
@@ -29,9 +60,18 @@ var (
 		Example: clusterExamples,
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !cmd.Flags().Changed("cluster-name") {
-				clusterName = config.GetManager().GetDefaults().ClusterName
+			// Flags, environment, profile and config file, in that order
+			defaults := config.GetManager().GetDefaults()
+
+			// A template or the cluster cloned replaces all but the flags
+			source, err := clusterSource(cmd.Flags(), defaults.ClusterName)
+			if err != nil {
+				return err
 			}
+			if source != nil {
+				applyTemplate(cmd.Flags(), &defaults, source)
+			}
+
 			providerType, err := provider.ParseProvider(clusterProvider)
 			if err != nil {
 				return err
@@ -39,38 +79,115 @@ var (
 
 			clusterProviderInstance, ok := provider.GetProviderByType(providerType)
 			if !ok {
-				return fmt.Errorf("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
+				return errdefs.Unsupported("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
 			}
 
 			options := &provider.CreateOptions{
 				ClusterOptions: provider.ClusterOptions{
-					ClusterName: clusterName,
-					K8sVersion:  k8sVersion,
+					ClusterName: defaults.ClusterName,
+					K8sVersion:  defaults.K8sVersion,
+				},
+				SkipPreflight: skipPreflight,
+				KeepOnFailure: keepOnFailure,
+				Nodes:         defaults.Nodes,
+				WaitOptions: provider.WaitOptions{
+					Wait:    defaults.Wait,
+					Timeout: time.Duration(defaults.WaitTimeout),
 				},
 			}
 
-			if providerType == provider.Minikube {
+			switch providerType {
+			case provider.Minikube:
 				options.ProviderOptions = map[string]interface{}{
-					"driver": driver,
-					"cni":    cni,
+					"driver":       defaults.Driver,
+					"cni":          defaults.CNI,
+					"cpus":         defaults.Resources.CPUs,
+					"memory":       defaults.Resources.Memory,
+					"extra_config": defaults.ExtraConfig,
+				}
+			case provider.Kind:
+				// The configured CNI is left to the provider, which keeps
+				// kindnet over the built-in one, the cloned CNI is passed on
+				options.ProviderOptions = map[string]interface{}{}
+				if source != nil && source.CNI != "" {
+					options.ProviderOptions["cni"] = defaults.CNI
 				}
 			}
+			if source != nil {
+				applyTemplateOptions(options, source)
+			}
 
-			return clusterProviderInstance.Create(options)
+			// Unknown addons, unreadable chart values and malformed
+			// manifests fail before the cluster is created
+			if _, err := provider.LookupAddons(defaults.Addons); err != nil {
+				return err
+			}
+			if _, err := provider.ChartReleases(defaults.Charts); err != nil {
+				return err
+			}
+			bootstrapObjects, err := provider.LoadBootstrap(defaults.Bootstrap)
+			if err != nil {
+				return err
+			}
+
+			if err := provider.RunHooks(cmd.Context(), provider.HookPreCreate, providerType, options.ClusterName, defaults.Hooks.PreCreate); err != nil {
+				return err
+			}
+			if err := clusterProviderInstance.Create(cmd.Context(), options); err != nil {
+				return err
+			}
+			// A cluster whose addons, charts or manifests failed is kept,
+			// the hints retry them
+			if err := provider.EnableAddons(cmd.Context(), clusterProviderInstance, options.ClusterName, defaults.Addons, options.WaitOptions.Timeout); err != nil {
+				return err
+			}
+			if err := provider.ApplyCharts(cmd.Context(), providerType, options.ClusterName, defaults.Charts, options.WaitOptions.Timeout); err != nil {
+				return err
+			}
+			if err := provider.ApplyBootstrap(cmd.Context(), providerType, options.ClusterName, defaults.Bootstrap, bootstrapObjects, options.WaitOptions.Timeout); err != nil {
+				return err
+			}
+			return provider.RunHooks(cmd.Context(), provider.HookPostCreate, providerType, options.ClusterName, defaults.Hooks.PostCreate)
 		},
 	}
 
 	clusterProvider string
-	clusterName     string
-	k8sVersion      string
-	driver          string
-	cni             string
+	skipPreflight   bool
+	keepOnFailure   bool
+	fromCluster     string
+	templateName    string
 )
 
 func init() {
 	clusterCmd.Flags().StringVarP(&clusterProvider, "provider", "p", string(provider.Minikube), i18n.T("Cluster provider (minikube or kind)."))
-	clusterCmd.Flags().StringVar(&clusterName, "cluster-name", "", i18n.T("Cluster Name."))
-	clusterCmd.Flags().StringVar(&k8sVersion, "k8s-version", config.DefaultK8sVersion, i18n.T("K8s Version."))
-	clusterCmd.Flags().StringVar(&driver, "driver", config.DefaultDriver, i18n.T("Driver (minikube only)."))
-	clusterCmd.Flags().StringVar(&cni, "cni", config.DefaultCni, i18n.T("CNI (minikube only)."))
+	clusterCmd.Flags().String("cluster-name", "", i18n.T("Cluster Name (default: the configured cluster_name)."))
+	clusterCmd.Flags().String("k8s-version", "", i18n.T("K8s Version (default: the configured k8s_version)."))
+	clusterCmd.Flags().String("driver", "", i18n.T("Driver, minikube only (default: the configured driver)."))
+	clusterCmd.Flags().String("cni", "", i18n.T("CNI such as cilium, calico, flannel or none, kind installs it after creating the cluster (default: the configured cni)."))
+	clusterCmd.Flags().Int("nodes", 0, i18n.T("Number of nodes, the control plane included (default: the configured nodes)."))
+	clusterCmd.Flags().Int("cpus", 0, i18n.T("CPUs per node, minikube only (default: the configured resources.cpus)."))
+	clusterCmd.Flags().String("memory", "", i18n.T("Memory per node such as 4g, minikube only (default: the configured resources.memory)."))
+	clusterCmd.Flags().StringArray("extra-config", nil, i18n.T("Component configuration such as kubelet.max-pods=100, minikube only, repeatable (default: the configured extra_config)."))
+	clusterCmd.Flags().Bool("wait", false, i18n.T("Wait until nodes are Ready and kube-system workloads are available (default: the configured wait)."))
+	clusterCmd.Flags().Duration("timeout", 0, i18n.T("How long --wait waits for the cluster to become ready (default: the configured wait_timeout)."))
+	clusterCmd.Flags().StringSlice("addons", nil, i18n.T("Addons to enable once the cluster is created, comma separated, see 'blitzctl addon list' (default: the configured addons)."))
+	clusterCmd.Flags().String("bootstrap-dir", "", i18n.T("Directory of manifests to server-side apply once the cluster is created, in lexical order or with its kustomization.yaml (default: the configured bootstrap)."))
+	clusterCmd.Flags().BoolVar(&skipPreflight, "skip-preflight", false, i18n.T("Skip the checks run before creating the cluster (name, existing clusters, k8s version, ports, memory, disk, driver daemon)."))
+	clusterCmd.Flags().BoolVar(&keepOnFailure, "keep-on-failure", false, i18n.T("Keep a cluster that failed to be created instead of deleting it."))
+	clusterCmd.Flags().StringVar(&fromCluster, "from", "", i18n.T("Create the cluster like a tracked cluster: its provider, k8s version, driver, CNI, nodes, resources, extra config and addons, flags still win."))
+	clusterCmd.Flags().StringVar(&templateName, "template", "", i18n.T("Create the cluster from a template saved with 'blitzctl template save', flags still win."))
+	clusterCmd.MarkFlagsMutuallyExclusive("from", "template")
+
+	config.BindFlag(clusterCmd.Flags().Lookup("cluster-name"), "cluster_name")
+	config.BindFlag(clusterCmd.Flags().Lookup("k8s-version"), "k8s_version")
+	config.BindFlag(clusterCmd.Flags().Lookup("driver"), "driver")
+	config.BindFlag(clusterCmd.Flags().Lookup("cni"), "cni")
+	config.BindFlag(clusterCmd.Flags().Lookup("nodes"), "nodes")
+	config.BindFlag(clusterCmd.Flags().Lookup("cpus"), "resources.cpus")
+	config.BindFlag(clusterCmd.Flags().Lookup("memory"), "resources.memory")
+	config.BindFlag(clusterCmd.Flags().Lookup("extra-config"), "extra_config")
+	config.BindFlag(clusterCmd.Flags().Lookup("wait"), "wait")
+	config.BindFlag(clusterCmd.Flags().Lookup("timeout"), "wait_timeout")
+	config.BindFlag(clusterCmd.Flags().Lookup("addons"), "addons")
+	config.BindFlag(clusterCmd.Flags().Lookup("bootstrap-dir"), "bootstrap")
 }
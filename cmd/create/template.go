@@ -0,0 +1,104 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package create
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/spf13/pflag"
+)
+
+// clusterSource returns the template named by --template, or the shape of
+// the tracked cluster named by --from, nil when neither is given
+func clusterSource(flags *pflag.FlagSet, clusterName string) (*config.ClusterTemplate, error) {
+	manager := config.GetManager()
+
+	var providerFilter string
+	if flags.Changed("provider") {
+		providerType, err := provider.ParseProvider(clusterProvider)
+		if err != nil {
+			return nil, err
+		}
+		providerFilter = string(providerType)
+	}
+
+	switch {
+	case fromCluster != "":
+		cluster, err := manager.FindCluster(fromCluster, providerFilter)
+		if err != nil {
+			return nil, errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error cloning cluster '%s'", fromCluster)
+		}
+		if cluster.Name == clusterName {
+			return nil, errdefs.Invalid("❌ The clone of cluster '%s' needs a name of its own", fromCluster).
+				WithHint("pass --cluster-name")
+		}
+		template := cluster.Template()
+		fmt.Printf("📋 Creating cluster '%s' like cluster '%s' (%s)\n", clusterName, cluster.Name, cluster.Provider)
+		return &template, nil
+
+	case templateName != "":
+		template, err := manager.GetTemplate(templateName)
+		if err != nil {
+			return nil, fmt.Errorf("❌ Error creating cluster: %w", err)
+		}
+		if providerFilter != "" && providerFilter != template.Provider {
+			return nil, errdefs.Invalid("❌ Template %s is for %s clusters, not %s", templateName, template.Provider, providerFilter)
+		}
+		fmt.Printf("📋 Creating cluster '%s' from template %s (%s)\n", clusterName, templateName, template.Provider)
+		return &template, nil
+	}
+	return nil, nil
+}
+
+// applyTemplate sets the provider and the defaults from template, except
+// those given by a flag
+func applyTemplate(flags *pflag.FlagSet, defaults *config.Defaults, template *config.ClusterTemplate) {
+	unset := func(flag string) bool { return !flags.Changed(flag) }
+
+	clusterProvider = template.Provider
+	if template.K8sVersion != "" && unset("k8s-version") {
+		defaults.K8sVersion = template.K8sVersion
+	}
+	if template.Driver != "" && unset("driver") {
+		defaults.Driver = template.Driver
+	}
+	if template.CNI != "" && unset("cni") {
+		defaults.CNI = template.CNI
+	}
+	if template.Nodes > 0 && unset("nodes") {
+		defaults.Nodes = template.Nodes
+	}
+	if cpus, err := strconv.Atoi(template.Options["cpus"]); err == nil && unset("cpus") {
+		defaults.Resources.CPUs = cpus
+	}
+	if memory := template.Options["memory"]; memory != "" && unset("memory") {
+		defaults.Resources.Memory = memory
+	}
+	if len(template.ExtraConfig) > 0 && unset("extra-config") {
+		defaults.ExtraConfig = template.ExtraConfig
+	}
+	if len(template.Addons) > 0 && unset("addons") {
+		defaults.Addons = template.Addons
+	}
+}
+
+// applyTemplateOptions carries the provider options of template the create
+// command has no default for over to options, so they are passed to the
+// provider and recorded with the new cluster. Those it has a default for,
+// cpus and memory among them, are already in options: applyTemplate put
+// them in the defaults unless a flag was given.
+func applyTemplateOptions(options *provider.CreateOptions, template *config.ClusterTemplate) {
+	if options.ProviderOptions == nil {
+		options.ProviderOptions = map[string]interface{}{}
+	}
+	for k, v := range template.Options {
+		if _, ok := options.ProviderOptions[k]; !ok {
+			options.ProviderOptions[k] = v
+		}
+	}
+}
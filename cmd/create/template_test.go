@@ -0,0 +1,176 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package create
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/pflag"
+)
+
+// createFlags returns the flags of create cluster a template gives way to,
+// with the flags in given set on the command line
+func createFlags(t *testing.T, given map[string]string) *pflag.FlagSet {
+	t.Helper()
+	flags := pflag.NewFlagSet(t.Name(), pflag.ContinueOnError)
+	for _, name := range []string{"k8s-version", "driver", "cni", "memory"} {
+		flags.String(name, "", "")
+	}
+	flags.Int("nodes", 0, "")
+	flags.Int("cpus", 0, "")
+	flags.StringArray("extra-config", nil, "")
+	flags.StringSlice("addons", nil, "")
+	for name, value := range given {
+		if err := flags.Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return flags
+}
+
+func TestApplyTemplate(t *testing.T) {
+	template := &config.ClusterTemplate{
+		Provider:    "minikube",
+		K8sVersion:  "1.33.0",
+		Driver:      "podman",
+		CNI:         "calico",
+		Nodes:       3,
+		Options:     map[string]string{"cpus": "4", "memory": "8g"},
+		ExtraConfig: []string{"kubelet.max-pods=150", "apiserver.enable-admission-plugins=A,B"},
+		Addons:      []string{"metrics-server"},
+	}
+	defaults := config.Defaults{
+		K8sVersion:  "1.34.0",
+		Driver:      "docker",
+		CNI:         "auto",
+		Nodes:       1,
+		Resources:   config.Resources{CPUs: 2, Memory: "4g"},
+		ExtraConfig: []string{"kubelet.max-pods=100"},
+	}
+
+	tests := []struct {
+		name     string
+		template *config.ClusterTemplate
+		given    map[string]string
+		want     config.Defaults
+	}{
+		{
+			name:     "template replaces the defaults",
+			template: template,
+			want: config.Defaults{
+				K8sVersion:  "1.33.0",
+				Driver:      "podman",
+				CNI:         "calico",
+				Nodes:       3,
+				Resources:   config.Resources{CPUs: 4, Memory: "8g"},
+				ExtraConfig: template.ExtraConfig,
+				Addons:      template.Addons,
+			},
+		},
+		{
+			name:     "flags win over the template",
+			template: template,
+			given:    map[string]string{"k8s-version": "1.34.0", "cpus": "2", "extra-config": "kubelet.max-pods=100"},
+			want: config.Defaults{
+				K8sVersion:  "1.34.0",
+				Driver:      "podman",
+				CNI:         "calico",
+				Nodes:       3,
+				Resources:   config.Resources{CPUs: 2, Memory: "8g"},
+				ExtraConfig: []string{"kubelet.max-pods=100"},
+				Addons:      template.Addons,
+			},
+		},
+		{
+			name:     "empty template keeps the defaults",
+			template: &config.ClusterTemplate{Provider: "kind"},
+			want:     defaults,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := defaults
+			got.ExtraConfig = append([]string(nil), defaults.ExtraConfig...)
+			applyTemplate(createFlags(t, tt.given), &got, tt.template)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("defaults = %+v\nwant %+v", got, tt.want)
+			}
+			if clusterProvider != tt.template.Provider {
+				t.Errorf("provider = %s, want %s", clusterProvider, tt.template.Provider)
+			}
+		})
+	}
+}
+
+func TestApplyTemplateOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  map[string]interface{}
+		template map[string]string
+		want     map[string]interface{}
+	}{
+		{
+			name:     "options without a default carry over",
+			options:  map[string]interface{}{"cpus": 4},
+			template: map[string]string{"cpus": "2", "disk_size": "40g"},
+			want:     map[string]interface{}{"cpus": 4, "disk_size": "40g"},
+		},
+		{
+			name:     "no provider options yet",
+			template: map[string]string{"disk_size": "40g"},
+			want:     map[string]interface{}{"disk_size": "40g"},
+		},
+		{
+			name:    "template without options",
+			options: map[string]interface{}{"cni": "calico"},
+			want:    map[string]interface{}{"cni": "calico"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := &provider.CreateOptions{ProviderOptions: tt.options}
+			applyTemplateOptions(options, &config.ClusterTemplate{Options: tt.template})
+			if !reflect.DeepEqual(options.ProviderOptions, tt.want) {
+				t.Errorf("provider options = %v, want %v", options.ProviderOptions, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterTemplate(t *testing.T) {
+	cluster := config.ClusterInfo{
+		Name:        "dev",
+		Provider:    "minikube",
+		K8sVersion:  "1.34.0",
+		Status:      "running",
+		Driver:      "docker",
+		CNI:         "calico",
+		Nodes:       2,
+		Options:     map[string]string{"driver": "docker", "cni": "calico", "cpus": "4", "memory": ""},
+		ExtraConfig: []string{"kubelet.max-pods=150"},
+		Addons:      []config.AddonInfo{{Name: "ingress"}, {Name: "metrics-server"}},
+	}
+	want := config.ClusterTemplate{
+		Provider:    "minikube",
+		K8sVersion:  "1.34.0",
+		Driver:      "docker",
+		CNI:         "calico",
+		Nodes:       2,
+		Options:     map[string]string{"cpus": "4"},
+		ExtraConfig: []string{"kubelet.max-pods=150"},
+		Addons:      []string{"ingress", "metrics-server"},
+	}
+
+	got := cluster.Template()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Template() = %+v\nwant %+v", got, want)
+	}
+	got.ExtraConfig[0] = "changed"
+	if cluster.ExtraConfig[0] != "kubelet.max-pods=150" {
+		t.Error("Template() shares the extra config of the cluster")
+	}
+}
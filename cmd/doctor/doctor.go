@@ -0,0 +1,186 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/doctor"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+// SkipConfigErrorsAnnotation marks commands that must run even when the
+// configuration file cannot be loaded, and that leave a file written by an
+// older version unmigrated
+const SkipConfigErrorsAnnotation = "blitzctl/skip-config-errors"
+
+var (
+	doctorExamples = templates.Examples(i18n.T(`
+		# Check the local environment
+		blitzctl doctor
+
+		# Attach the results to a bug report
+		blitzctl doctor -o json
+	`))
+
+	doctorCmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the local environment",
+		Long: `Run a set of environment checks (provider binaries, container engine,
+cgroups, inotify limits, disk space, ports, kubeconfig and configuration file)
+and print pass/warn/fail with remediation hints. Exits with an error when a
+check fails.`,
+		Example:     doctorExamples,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{SkipConfigErrorsAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checks := append(providerChecks(), doctor.Checks()...)
+			checks = append(checks, configCheck(cmd))
+
+			results := doctor.Run(cmd.Context(), checks)
+			if err := cmd.Context().Err(); err != nil {
+				return err
+			}
+			summary := doctor.Summary(results)
+
+			switch output {
+			case "json":
+				data, err := json.MarshalIndent(struct {
+					Results []doctor.Result       `json:"results"`
+					Summary map[doctor.Status]int `json:"summary"`
+				}{results, summary}, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+			case "":
+				printResults(results, summary)
+			default:
+				return errdefs.Invalid("❌ Unsupported output format: %s (supported: json)", output)
+			}
+
+			if summary[doctor.Fail] > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("❌ %d check(s) failed", summary[doctor.Fail])
+			}
+			return nil
+		},
+	}
+
+	output string
+)
+
+// GetDoctorCmd returns the doctor command
+func GetDoctorCmd() *cobra.Command {
+	return doctorCmd
+}
+
+func init() {
+	doctorCmd.Flags().StringVarP(&output, "output", "o", "", i18n.T("Output format (json)."))
+}
+
+// providerChecks reuses each provider's Validate to check its binaries
+func providerChecks() []doctor.Check {
+	var checks []doctor.Check
+	for _, p := range provider.GetProviders() {
+		name := string(p.GetProviderType())
+		checks = append(checks, doctor.NewCheck("provider:"+name, func(ctx context.Context) doctor.Result {
+			if err := p.Validate(); err != nil {
+				return doctor.Result{
+					Status:  doctor.Warn,
+					Message: checkMessage(err),
+					Hint:    checkHint(err, "blitzctl install cluster --provider "+name),
+				}
+			}
+			return doctor.Result{Status: doctor.Pass, Message: name + " is ready"}
+		}))
+	}
+	return checks
+}
+
+// configCheck reads and validates the configuration files, leaving them as
+// they are even when they need migrating
+func configCheck(cmd *cobra.Command) doctor.Check {
+	return doctor.NewCheck("config", func(ctx context.Context) doctor.Result {
+		configFile := ""
+		if flag := cmd.Flag("config"); flag != nil {
+			configFile = flag.Value.String()
+		}
+
+		files, err := config.CheckFiles(configFile)
+		if err != nil {
+			return doctor.Result{
+				Status:  doctor.Fail,
+				Message: checkMessage(err),
+				Hint:    checkHint(err, "fix the YAML or move the file aside to fall back to defaults"),
+			}
+		}
+		if len(files) == 0 {
+			return doctor.Result{Status: doctor.Pass, Message: "no configuration file, using defaults"}
+		}
+
+		var paths, outdated []string
+		for _, file := range files {
+			paths = append(paths, file.Path)
+			if file.NeedsMigration() {
+				from := file.APIVersion
+				if from == "" {
+					from = "no apiVersion"
+				}
+				outdated = append(outdated, fmt.Sprintf("%s (%s)", file.Path, from))
+			}
+		}
+		if len(outdated) > 0 {
+			return doctor.Result{
+				Status:  doctor.Warn,
+				Message: fmt.Sprintf("%s needs migration to %s", strings.Join(outdated, ", "), config.CurrentAPIVersion),
+				Hint:    "any other blitzctl command migrates it, keeping the original as a .bak file",
+			}
+		}
+		return doctor.Result{Status: doctor.Pass, Message: strings.Join(paths, ", ") + " parsed"}
+	})
+}
+
+func printResults(results []doctor.Result, summary map[doctor.Status]int) {
+	for _, r := range results {
+		icon := "✅"
+		switch r.Status {
+		case doctor.Warn:
+			icon = "⚠️"
+		case doctor.Fail:
+			icon = "❌"
+		case doctor.Skip:
+			icon = "⏭️"
+		}
+		fmt.Printf("%s %-18s %s\n", icon, r.Check, r.Message)
+		if r.Hint != "" && (r.Status == doctor.Warn || r.Status == doctor.Fail) {
+			fmt.Printf("   💡 %s\n", r.Hint)
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d warnings, %d failed, %d skipped\n",
+		summary[doctor.Pass], summary[doctor.Warn], summary[doctor.Fail], summary[doctor.Skip])
+}
+
+// checkMessage returns the message of a check failing with err, its hint is
+// reported on its own
+func checkMessage(err error) string {
+	return strings.TrimSpace(strings.TrimPrefix(errdefs.Message(err), "❌"))
+}
+
+// checkHint returns the hint of err, or fallback when it has none
+func checkHint(err error, fallback string) string {
+	if hint := errdefs.Hint(err); hint != "" {
+		return hint
+	}
+	return fallback
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package env
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	envExamples = templates.Examples(i18n.T(`
+		# Use the project toolchain in the current shell
+		eval "$(blitzctl env)"
+
+		# fish
+		blitzctl env --shell fish | source
+	`))
+
+	envCmd = &cobra.Command{
+		Use:   "env",
+		Short: "Print the shell commands to use the managed tools",
+		Long: `Print the PATH adjustment that puts the managed bin directories in front of
+PATH: the project one (./.blitzctl/bin, populated by 'blitzctl tool sync')
+first, then ~/.blitzctl/bin.`,
+		Example: envExamples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var dirs []string
+			if dir, err := toolchain.ProjectBinDir(); err == nil {
+				dirs = append(dirs, dir)
+			}
+			if dir, err := toolchain.DefaultBinDir(); err == nil {
+				dirs = append(dirs, dir)
+			}
+
+			switch shell {
+			case "sh", "bash", "zsh":
+				fmt.Printf("export PATH=\"%s:$PATH\"\n", strings.Join(dirs, ":"))
+			case "fish":
+				fmt.Printf("set -gx PATH %s $PATH\n", strings.Join(quoteAll(dirs), " "))
+			default:
+				return errdefs.Unsupported("❌ Unsupported shell: %s (supported: sh, bash, zsh, fish)", shell)
+			}
+			fmt.Println("# Run this command to configure your shell:")
+			fmt.Println("# eval \"$(blitzctl env)\"")
+			return nil
+		},
+	}
+
+	shell string
+)
+
+// GetEnvCmd returns the env command
+func GetEnvCmd() *cobra.Command {
+	return envCmd
+}
+
+func init() {
+	envCmd.Flags().StringVar(&shell, "shell", "sh", i18n.T("Shell syntax (sh, bash, zsh or fish)."))
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return quoted
+}
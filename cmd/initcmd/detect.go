@@ -0,0 +1,97 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package initcmd
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/doctor"
+)
+
+// lowMemoryBytes is the host memory under which the wizard steers away from
+// the heavier defaults
+const lowMemoryBytes = 8 << 30
+
+// machine is what the wizard learned about the host
+type machine struct {
+	// Providers are the cluster providers whose binaries are ready
+	Providers []string
+	// Engines are the installed container engines, Reachable the ones
+	// whose daemon answers
+	Engines   []string
+	Reachable []string
+	// Cgroup is the cgroup version, 0 when not on Linux
+	Cgroup int
+	// Memory is the physical memory in bytes, 0 when unknown
+	Memory uint64
+	CPUs   int
+}
+
+// inspect probes the providers, container engines, cgroups and memory
+func inspect(ctx context.Context) machine {
+	var m machine
+
+	for _, p := range provider.GetProviders() {
+		if p.Validate() == nil {
+			m.Providers = append(m.Providers, string(p.GetProviderType()))
+		}
+	}
+
+	for _, engine := range []string{"docker", "podman"} {
+		if _, err := exec.LookPath(engine); err != nil {
+			continue
+		}
+		m.Engines = append(m.Engines, engine)
+		if doctor.EngineReachable(ctx, engine) == nil {
+			m.Reachable = append(m.Reachable, engine)
+		}
+	}
+
+	if runtime.GOOS == "linux" {
+		m.Cgroup = doctor.CgroupVersion()
+		m.Memory, _ = doctor.TotalMemory()
+	}
+	m.CPUs = runtime.NumCPU()
+	return m
+}
+
+// driver suggests the minikube driver: the engine that answers, or the one
+// that is at least installed
+func (m machine) driver() string {
+	if len(m.Reachable) > 0 {
+		return m.Reachable[0]
+	}
+	if len(m.Engines) > 0 {
+		return m.Engines[0]
+	}
+	return config.DefaultDriver
+}
+
+// cni suggests a lighter CNI than the default on small machines
+func (m machine) cni() string {
+	if m.Memory != 0 && m.Memory < lowMemoryBytes {
+		return "bridge"
+	}
+	return config.DefaultCni
+}
+
+// memory suggests the memory of a node: a quarter of the host, between 2
+// and 8 GiB. Empty leaves minikube's default when the host size is unknown.
+func (m machine) memory() string {
+	if m.Memory == 0 {
+		return ""
+	}
+	gib := min(max(m.Memory>>30/4, 2), 8)
+	return fmt.Sprintf("%dg", gib)
+}
+
+// cpus suggests the CPUs of a node: half of the host, between 2 and 4
+func (m machine) cpus() int {
+	return min(max(m.CPUs/2, 2), 4)
+}
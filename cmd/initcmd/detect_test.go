@@ -0,0 +1,68 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package initcmd
+
+import (
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+)
+
+func TestMachineSuggestions(t *testing.T) {
+	tests := []struct {
+		name       string
+		host       machine
+		wantDriver string
+		wantCNI    string
+		wantMemory string
+		wantCPUs   int
+	}{
+		{
+			name:       "unknown host",
+			wantDriver: config.DefaultDriver,
+			wantCNI:    config.DefaultCni,
+			wantCPUs:   2,
+		},
+		{
+			name:       "reachable engine first",
+			host:       machine{Engines: []string{"docker", "podman"}, Reachable: []string{"podman"}, Memory: 32 << 30, CPUs: 16},
+			wantDriver: "podman",
+			wantCNI:    config.DefaultCni,
+			wantMemory: "8g",
+			wantCPUs:   4,
+		},
+		{
+			name:       "installed engine without a daemon",
+			host:       machine{Engines: []string{"docker"}, Memory: 16 << 30, CPUs: 6},
+			wantDriver: "docker",
+			wantCNI:    config.DefaultCni,
+			wantMemory: "4g",
+			wantCPUs:   3,
+		},
+		{
+			name:       "small machine",
+			host:       machine{Memory: 4 << 30, CPUs: 2},
+			wantDriver: config.DefaultDriver,
+			wantCNI:    "bridge",
+			wantMemory: "2g",
+			wantCPUs:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.host.driver(); got != tt.wantDriver {
+				t.Errorf("driver() = %q, want %q", got, tt.wantDriver)
+			}
+			if got := tt.host.cni(); got != tt.wantCNI {
+				t.Errorf("cni() = %q, want %q", got, tt.wantCNI)
+			}
+			if got := tt.host.memory(); got != tt.wantMemory {
+				t.Errorf("memory() = %q, want %q", got, tt.wantMemory)
+			}
+			if got := tt.host.cpus(); got != tt.wantCPUs {
+				t.Errorf("cpus() = %d, want %d", got, tt.wantCPUs)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package initcmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/prompt"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+// question asks for one configuration key, offering options when the key
+// has a known set of values
+type question struct {
+	key     string
+	text    string
+	options []string
+}
+
+var (
+	initLocal  bool
+	initGlobal bool
+	initYes    bool
+	initForce  bool
+
+	initExamples = templates.Examples(i18n.T(`
+		# Inspect this machine and answer a few questions
+		blitzctl init
+
+		# Write the project configuration with the suggested values, no questions
+		blitzctl init --local --yes
+
+		# Start over from the suggestions, replacing the values of an existing file
+		blitzctl init --global --force
+	`))
+
+	initCmd = &cobra.Command{
+		Use:   "init",
+		Short: "Create a configuration file for this machine",
+		Long: `Inspect this machine (installed providers and container engines, cgroup
+version, memory and CPUs), suggest a driver, CNI and node size that fit it,
+ask a few questions and write the project (./.blitzctl/config.yaml) or user
+(~/.blitzctl/config.yaml) configuration.
+
+Without a terminal, or with --yes, the suggested values are written without
+asking. An existing file is only updated with --force or after confirming.`,
+		Example: initExamples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			interactive := prompt.Interactive() && !initYes
+			prompter := prompt.New(os.Stdin, os.Stdout)
+
+			fmt.Println("🔍 Inspecting this machine...")
+			host := inspect(cmd.Context())
+			printMachine(host)
+
+			path, err := targetPath(prompter, interactive)
+			if err != nil {
+				return err
+			}
+			manager, err := config.OpenFile(path)
+			if err != nil {
+				return fmt.Errorf("❌ Error initializing configuration: %w", err)
+			}
+
+			// An existing file keeps its values as the answers to offer,
+			// a new one starts from what suits this machine
+			defaults := manager.GetConfig().Defaults
+			if _, err := os.Stat(path); err == nil {
+				if !initForce {
+					if !interactive {
+						return errdefs.AlreadyExists("❌ %s already exists", path).
+							WithHint("rerun with --force to update it, or use 'blitzctl config edit'")
+					}
+					update, err := prompter.Confirm(fmt.Sprintf("%s already exists, update it?", path), false)
+					if err != nil {
+						return err
+					}
+					if !update {
+						fmt.Println("Init cancelled, no changes made")
+						return nil
+					}
+				}
+			} else if errors.Is(err, fs.ErrNotExist) {
+				defaults.Driver = host.driver()
+				defaults.CNI = host.cni()
+				defaults.Resources.CPUs = host.cpus()
+				defaults.Resources.Memory = host.memory()
+			} else {
+				return fmt.Errorf("❌ Error initializing configuration: %w", err)
+			}
+
+			values, err := answers(prompter, interactive, defaults)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("\n📝 Configuration for %s:\n", path)
+			for _, q := range questions() {
+				if value, ok := values[q.key]; ok {
+					fmt.Printf("  %-18s %s\n", q.key+":", value)
+				}
+			}
+			if interactive {
+				write, err := prompter.Confirm("Write it?", true)
+				if err != nil {
+					return err
+				}
+				if !write {
+					fmt.Println("Init cancelled, no changes made")
+					return nil
+				}
+			}
+
+			if err := manager.SetDefaults(values); err != nil {
+				return fmt.Errorf("❌ Error initializing configuration: %w", err)
+			}
+			fmt.Printf("✅ Configuration saved to: %s\n", path)
+
+			printNextSteps(host)
+			return nil
+		},
+	}
+)
+
+// GetInitCmd returns the init command
+func GetInitCmd() *cobra.Command {
+	return initCmd
+}
+
+func init() {
+	initCmd.Flags().BoolVar(&initLocal, "local", false, i18n.T("Write the project configuration (./.blitzctl/config.yaml)."))
+	initCmd.Flags().BoolVar(&initGlobal, "global", false, i18n.T("Write the user configuration (~/.blitzctl/config.yaml)."))
+	initCmd.MarkFlagsMutuallyExclusive("local", "global")
+	initCmd.Flags().BoolVarP(&initYes, "yes", "y", false, i18n.T("Accept the suggested values without asking."))
+	initCmd.Flags().BoolVar(&initForce, "force", false, i18n.T("Update the configuration file when it already exists."))
+}
+
+// questions are asked in this order, the node size only matters to
+// minikube
+func questions() []question {
+	return []question{
+		{key: "cluster_name", text: "Cluster name"},
+		{key: "driver", text: "Minikube driver (a name below or a path to a driver binary)", options: []string{"docker", "podman"}},
+		{key: "cni", text: "CNI (cilium needs more memory, bridge is the lightest)", options: config.KnownCNIs},
+		{key: "k8s_version", text: "Kubernetes version"},
+		{key: "nodes", text: "Nodes per cluster"},
+		{key: "resources.cpus", text: "CPUs per minikube node (0 leaves minikube's default)"},
+		{key: "resources.memory", text: "Memory per minikube node, e.g. 4g (empty leaves minikube's default)"},
+	}
+}
+
+// answers returns the value of every question, asked on a terminal and
+// taken from defaults otherwise. Empty answers are left out.
+func answers(prompter *prompt.Prompter, interactive bool, defaults config.Defaults) (map[string]string, error) {
+	if interactive {
+		fmt.Println()
+	}
+
+	values := map[string]string{}
+	for _, q := range questions() {
+		current, err := config.GetDefaultIn(defaults, q.key)
+		if err != nil {
+			return nil, err
+		}
+		answer := config.FormatValue(current)
+
+		if interactive {
+			validate := func(value string) error {
+				if value == "" {
+					return nil
+				}
+				scratch := defaults
+				return config.SetDefaultIn(&scratch, q.key, value)
+			}
+			if q.options != nil {
+				answer, err = prompter.Choose(q.text, q.options, answer, validate)
+			} else {
+				answer, err = prompter.Ask(q.text, answer, validate)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("❌ Init cancelled: %w", err)
+			}
+		}
+
+		if answer != "" {
+			values[q.key] = answer
+		}
+	}
+	return values, nil
+}
+
+// targetPath returns the file selected by --local or --global, or asks for
+// it. The project file is suggested inside a git repository.
+func targetPath(prompter *prompt.Prompter, interactive bool) (string, error) {
+	local, err := config.LocalConfigFile()
+	if err != nil {
+		return "", err
+	}
+	global, err := config.GlobalConfigFile()
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case initLocal:
+		return local, nil
+	case initGlobal:
+		return global, nil
+	}
+
+	scope := "global"
+	if _, err := os.Stat(".git"); err == nil {
+		scope = "project"
+	}
+	if interactive {
+		fmt.Println()
+		scope, err = prompter.Choose(fmt.Sprintf("Where should the configuration be written?\n  project: %s\n  global:  %s", local, global),
+			[]string{"project", "global"}, scope, nil)
+		if err != nil {
+			return "", fmt.Errorf("❌ Init cancelled: %w", err)
+		}
+	}
+
+	if scope == "project" {
+		return local, nil
+	}
+	return global, nil
+}
+
+// printMachine reports what inspect found, with a hint for what is missing
+func printMachine(host machine) {
+	providers := "none ready"
+	if len(host.Providers) > 0 {
+		providers = strings.Join(host.Providers, ", ")
+	}
+	fmt.Printf("  %-19s %s\n", "Providers:", providers)
+
+	engines := "none installed"
+	if len(host.Engines) > 0 {
+		var states []string
+		for _, engine := range host.Engines {
+			state := "not running"
+			if slices.Contains(host.Reachable, engine) {
+				state = "running"
+			}
+			states = append(states, fmt.Sprintf("%s (%s)", engine, state))
+		}
+		engines = strings.Join(states, ", ")
+	}
+	fmt.Printf("  %-19s %s\n", "Container engines:", engines)
+
+	if host.Cgroup != 0 {
+		fmt.Printf("  %-19s v%d\n", "cgroup:", host.Cgroup)
+	}
+	if host.Memory != 0 {
+		fmt.Printf("  %-19s %.1f GiB, %d CPUs\n", "Memory:", float64(host.Memory)/(1<<30), host.CPUs)
+	} else {
+		fmt.Printf("  %-19s %d\n", "CPUs:", host.CPUs)
+	}
+
+	if len(host.Engines) == 0 {
+		fmt.Println("⚠️ No container engine is installed")
+		fmt.Println("   💡 blitzctl install container --driver docker")
+	}
+	if len(host.Providers) == 0 {
+		fmt.Println("⚠️ No cluster provider is ready")
+		fmt.Println("   💡 blitzctl install cluster --provider kind")
+	}
+	if host.Cgroup == 1 {
+		fmt.Println("⚠️ cgroup v1 is deprecated by newer Kubernetes releases")
+		fmt.Println("   💡 boot with systemd.unified_cgroup_hierarchy=1")
+	}
+	if host.Memory != 0 && host.Memory < lowMemoryBytes {
+		fmt.Println("⚠️ Less than 8 GiB of memory, suggesting the bridge CNI and smaller nodes")
+	}
+}
+
+// printNextSteps points at the command creating the first cluster
+func printNextSteps(host machine) {
+	fmt.Println("\nNext steps:")
+	if len(host.Providers) == 0 {
+		fmt.Println("  blitzctl install cluster --provider kind")
+		fmt.Println("  blitzctl create cluster --provider kind")
+		return
+	}
+	fmt.Printf("  blitzctl create cluster --provider %s\n", host.Providers[0])
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package initcmd
+
+import (
+	"errors"
+	"io"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/prompt"
+)
+
+func TestAnswers(t *testing.T) {
+	defaults := config.Defaults{
+		ClusterName: "blitz-cluster1",
+		Driver:      "docker",
+		CNI:         "auto",
+		K8sVersion:  "1.34.0",
+		Nodes:       1,
+		Resources:   config.Resources{CPUs: 2, Memory: "4g"},
+	}
+	suggested := map[string]string{
+		"cluster_name":     "blitz-cluster1",
+		"driver":           "docker",
+		"cni":              "auto",
+		"k8s_version":      "1.34.0",
+		"nodes":            "1",
+		"resources.cpus":   "2",
+		"resources.memory": "4g",
+	}
+
+	tests := []struct {
+		name        string
+		interactive bool
+		input       string
+		want        map[string]string
+		wantErr     error
+	}{
+		{name: "suggested values without a terminal", want: suggested},
+		{name: "defaults accepted", interactive: true, input: strings.Repeat("\n", 7), want: suggested},
+		{
+			name:        "answers",
+			interactive: true,
+			// driver picked by number, cni and nodes retried after an
+			// invalid answer
+			input: "dev\n2\nbogus\ncalico\n\n0\n3\n\n\n",
+			want: map[string]string{
+				"cluster_name":     "dev",
+				"driver":           "podman",
+				"cni":              "calico",
+				"k8s_version":      "1.34.0",
+				"nodes":            "3",
+				"resources.cpus":   "2",
+				"resources.memory": "4g",
+			},
+		},
+		{name: "input closed", interactive: true, input: "dev\n", wantErr: io.EOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter := prompt.New(strings.NewReader(tt.input), io.Discard)
+			got, err := answers(prompter, tt.interactive, defaults)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("answers() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !maps.Equal(got, tt.want) {
+				t.Errorf("answers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
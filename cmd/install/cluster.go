@@ -4,9 +4,8 @@ Copyright © 2025 Oneide Luiz Schneider
 package install
 
 import (
-	"fmt"
-
 	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
 	"github.com/spf13/cobra"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -19,12 +18,16 @@ var (
 
 		# Install kind
 		blitzctl install cluster --provider kind
+
+		# Install a specific minikube version into a custom directory
+		blitzctl install cluster --provider minikube --version v1.38.1 --bin-dir ~/bin
 	`))
 
 	clusterCmd = &cobra.Command{
-		Use:     "cluster",
-		Short:   "Install a k8s tool",
-		Long:    `Install a local k8s tool using the specified provider.`,
+		Use:   "cluster",
+		Short: "Install a k8s tool",
+		Long: `Install the CLI of the specified provider. The release is downloaded for
+your OS/arch and verified against its published sha256 checksum.`,
 		Example: clusterExamples,
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -35,14 +38,21 @@ var (
 
 			clusterProviderInstance, ok := provider.GetProviderByType(providerType)
 			if !ok {
-				return fmt.Errorf("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
+				return errdefs.Unsupported("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
 			}
 
-			return clusterProviderInstance.Install(&provider.InstallOptions{})
+			return clusterProviderInstance.Install(cmd.Context(), &provider.InstallOptions{
+				BinaryOptions: provider.BinaryOptions{
+					Version: binaryVersion,
+					BinDir:  binDir,
+				},
+			})
 		},
 	}
 
 	clusterProvider string
+	binaryVersion   string
+	binDir          string
 )
 
 func GetClusterCmd() *cobra.Command {
@@ -51,4 +61,6 @@ func GetClusterCmd() *cobra.Command {
 
 func init() {
 	clusterCmd.Flags().StringVarP(&clusterProvider, "provider", "p", string(provider.Minikube), i18n.T("Cluster provider (minikube or kind)."))
+	clusterCmd.Flags().StringVar(&binaryVersion, "version", "", i18n.T("Version to install, or 'latest' (default: the configured kind_version or minikube_version)."))
+	clusterCmd.Flags().StringVar(&binDir, "bin-dir", "", i18n.T("Install directory (default: /usr/local/bin if writable, else ~/.blitzctl/bin)."))
 }
@@ -9,6 +9,8 @@ import (
 	"os/exec"
 
 	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/internal/command"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
 	"github.com/spf13/cobra"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -28,21 +30,19 @@ var (
 		Aliases: []string{"c"},
 		Short:   "Install a container driver",
 		Long:    `It opens the webbrowser to install docker desktop manually for example.`,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if containerDriver == "" {
-				fmt.Fprintf(os.Stderr, "❗Error: --driver flag is required (docker or podman)\n")
-				os.Exit(1)
+				return errdefs.Invalid("❗Error: --driver flag is required (docker or podman)")
 			}
 			switch containerDriver {
 			case string(provider.Docker):
 				fmt.Fprintf(os.Stderr, "❗Opening docker website...\n")
-				dockerCmd.Run(cmd, args)
+				return dockerCmd.RunE(cmd, args)
 			case string(provider.Podman):
 				fmt.Fprintf(os.Stderr, "❗Opening podman website...\n")
-				podmanCmd.Run(cmd, args)
+				return podmanCmd.RunE(cmd, args)
 			default:
-				fmt.Fprintf(os.Stderr, "❗Error: invalid driver '%s'. Valid options are 'docker' or 'podman'\n", containerDriver)
-				os.Exit(1)
+				return errdefs.Invalid("❗Error: invalid driver '%s'. Valid options are 'docker' or 'podman'", containerDriver)
 			}
 		},
 	}
@@ -53,16 +53,16 @@ var (
 		Aliases: []string{"d"},
 		Short:   "Docker website",
 		Long:    `Docker website`,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			getCmd := exec.Command("open", "https://www.docker.com/get-started/")
 			// Set up real-time output streaming
 			getCmd.Stdout = os.Stdout
 			getCmd.Stderr = os.Stderr
 			// Start and wait for the command to complete
-			if err := getCmd.Run(); err != nil {
-				fmt.Fprintf(os.Stderr, "❗Error opening docker website\n")
-				os.Exit(1)
+			if err := command.Run(getCmd); err != nil {
+				return fmt.Errorf("❗Error opening docker website: %w", err)
 			}
+			return nil
 		},
 	}
 
@@ -72,16 +72,16 @@ var (
 		Aliases: []string{"p"},
 		Short:   "Podman website",
 		Long:    `Podman website`,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			getCmd := exec.Command("open", "https://podman-desktop.io/downloads")
 			// Set up real-time output streaming
 			getCmd.Stdout = os.Stdout
 			getCmd.Stderr = os.Stderr
 			// Start and wait for the command to complete
-			if err := getCmd.Run(); err != nil {
-				fmt.Fprintf(os.Stderr, "❗Error opening podman website\n")
-				os.Exit(1)
+			if err := command.Run(getCmd); err != nil {
+				return fmt.Errorf("❗Error opening podman website: %w", err)
 			}
+			return nil
 		},
 	}
 
@@ -4,9 +4,6 @@ Copyright © 2025 Oneide Luiz Schneider
 package install
 
 import (
-	"fmt"
-	"os"
-
 	"github.com/OneideLuizSchneider/blitzctl/cmd/tools"
 	"github.com/spf13/cobra"
 	"k8s.io/kubectl/pkg/util/i18n"
@@ -18,6 +15,7 @@ var (
 		# Install Tools like Helm...
 		blitzctl install tool --help
 		blitzctl install tool --tool=helm
+		blitzctl install tool --tool=kubectl,kind
 		blitzctl install tool --tool=all
 	`))
 
@@ -26,26 +24,19 @@ var (
 		Example: toolsExample,
 		Aliases: []string{"t"},
 		Short:   "Install tools",
-		Long:    `Install All Tools like Helm`,
-		Run: func(cmd *cobra.Command, args []string) {
-			if toolName == "" {
-				fmt.Fprintf(os.Stderr, "❗Error: --tool flag is required (helm or all)\n")
-				os.Exit(1)
-			}
-			switch toolName {
-			case "helm", "all":
-				tools.InstallHelmCmd.Run(cmd, args)
-			default:
-				fmt.Fprintf(os.Stderr, "❗Error: invalid tool '%s'. Valid options are 'helm' or 'all'\n", toolName)
-				os.Exit(1)
-			}
-			if err := cmd.Help(); err != nil {
-				cmd.PrintErrln("❌ Error displaying help:", err)
+		Long: `Install tools like kubectl, kind, minikube, helm, k9s, kustomize and stern
+at their pinned versions. This is a shortcut for 'blitzctl tool install'.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			all := len(toolNames) == 1 && toolNames[0] == "all"
+			selected, err := tools.SelectTools(toolNames, all)
+			if err != nil {
+				return err
 			}
+			return tools.InstallTools(cmd.Context(), selected, "")
 		},
 	}
 
-	toolName string
+	toolNames []string
 )
 
 func GetToolsCmd() *cobra.Command {
@@ -53,5 +44,5 @@ func GetToolsCmd() *cobra.Command {
 }
 
 func init() {
-	toolsCmd.Flags().StringVarP(&toolName, "tool", "t", "", i18n.T("Tool name (Helm...)."))
+	toolsCmd.Flags().StringSliceVarP(&toolNames, "tool", "t", nil, i18n.T("Tool names (kubectl, kind, minikube, helm, k9s, kustomize, stern or all)."))
 }
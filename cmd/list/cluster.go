@@ -4,9 +4,8 @@ Copyright © 2025 Oneide Luiz Schneider
 package list
 
 import (
-	"fmt"
-
 	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
 	"github.com/spf13/cobra"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -36,10 +35,10 @@ var (
 
 			clusterProviderInstance, ok := provider.GetProviderByType(providerType)
 			if !ok {
-				return fmt.Errorf("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
+				return errdefs.Unsupported("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
 			}
 
-			return clusterProviderInstance.List(&provider.ListOptions{})
+			return clusterProviderInstance.List(cmd.Context(), &provider.ListOptions{})
 		},
 	}
 
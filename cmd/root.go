@@ -4,28 +4,49 @@ Copyright © 2025 Oneide Luiz Schneider
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
+	addonCmd "github.com/OneideLuizSchneider/blitzctl/cmd/addon"
+	chartsCmd "github.com/OneideLuizSchneider/blitzctl/cmd/charts"
 	configCmd "github.com/OneideLuizSchneider/blitzctl/cmd/config"
 	contextCmd "github.com/OneideLuizSchneider/blitzctl/cmd/context"
 	createCmd "github.com/OneideLuizSchneider/blitzctl/cmd/create"
 	deleteCmd "github.com/OneideLuizSchneider/blitzctl/cmd/delete"
+	doctorCmd "github.com/OneideLuizSchneider/blitzctl/cmd/doctor"
+	envCmd "github.com/OneideLuizSchneider/blitzctl/cmd/env"
+	initCmd "github.com/OneideLuizSchneider/blitzctl/cmd/initcmd"
 	installCmd "github.com/OneideLuizSchneider/blitzctl/cmd/install"
 	listCmd "github.com/OneideLuizSchneider/blitzctl/cmd/list"
+	selfUpdateCmd "github.com/OneideLuizSchneider/blitzctl/cmd/selfupdate"
+	snapshotCmd "github.com/OneideLuizSchneider/blitzctl/cmd/snapshot"
 	startCmd "github.com/OneideLuizSchneider/blitzctl/cmd/start"
 	stopCmd "github.com/OneideLuizSchneider/blitzctl/cmd/stop"
+	templateCmd "github.com/OneideLuizSchneider/blitzctl/cmd/template"
+	toolsCmd "github.com/OneideLuizSchneider/blitzctl/cmd/tools"
 	upgradeCmd "github.com/OneideLuizSchneider/blitzctl/cmd/upgrade"
 	versionCmdPkg "github.com/OneideLuizSchneider/blitzctl/cmd/version"
 	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/logging"
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
 	"github.com/OneideLuizSchneider/blitzctl/internal/version"
 )
 
 var (
 	configFile string
-	rootCmd    = &cobra.Command{
+	profile    string
+	logOptions logging.Options
+	// logErr and configErr are reported before running a command
+	logErr    error
+	configErr error
+	rootCmd   = &cobra.Command{
 		Use:     "blitzctl",
 		Version: version.String(),
 		Short:   "The k8s local environment manager",
@@ -35,6 +56,27 @@ using various drivers and configurations. It is designed to be
 lightweight and easy to use, making it ideal for developers
 who need a quick and efficient way to set up and manage
 local Kubernetes environments.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Flags and arguments are valid at this point, don't bury
+			// runtime failures under the usage text
+			cmd.SilenceUsage = true
+
+			if logErr != nil {
+				return logErr
+			}
+			// Diagnostic commands report a broken or outdated config file
+			// themselves, leaving it as is
+			if cmd.Annotations[doctorCmd.SkipConfigErrorsAnnotation] == "true" {
+				return nil
+			}
+			if configErr != nil {
+				return configErr
+			}
+			if err := config.GetManager().Migrate(); err != nil {
+				slog.Warn("Failed to migrate the configuration file", "error", err)
+			}
+			return nil
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := cmd.Help(); err != nil {
 				// Handle the error (e.g., log it or print it)
@@ -44,10 +86,35 @@ local Kubernetes environments.`,
 	}
 )
 
+// Execute runs the root command and exits with the code documented in
+// errdefs for the category of the failure
 func Execute() {
-	err := rootCmd.Execute()
+	// The first Ctrl-C (or SIGTERM) cancels the command context, interrupting
+	// provider CLIs and downloads so partially created clusters can be rolled
+	// back. A second Ctrl-C exits immediately.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		signal.Stop(signals)
+		fmt.Fprintln(os.Stderr, "\n🛑 Interrupted, cleaning up... (press Ctrl-C again to abort immediately)")
+		cancel()
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
+	// os.Exit skips deferred calls, the log file is closed first
+	if closeErr := logging.Close(); closeErr != nil {
+		fmt.Fprintln(os.Stderr, "⚠️", closeErr)
+	}
 	if err != nil {
-		os.Exit(1)
+		if ctx.Err() != nil && !errdefs.Is(err, errdefs.KindInterrupted) {
+			// Whatever failed, it failed because it was interrupted
+			err = errdefs.Wrap(errdefs.KindInterrupted, err, "")
+		}
+		os.Exit(errdefs.ExitCode(err))
 	}
 }
 
@@ -55,9 +122,17 @@ func init() {
 	// Initialize configuration before command execution
 	cobra.OnInitialize(initConfig)
 	rootCmd.SetVersionTemplate("{{printf \"%s\\n\" .Version}}")
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return errdefs.Wrap(errdefs.KindInvalid, err, "")
+	})
 
 	// Add global flags
 	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file (default is $HOME/.blitzctl/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "configuration profile applied to the defaults (default is the profile set with 'config profile use')")
+	rootCmd.PersistentFlags().CountVarP(&logOptions.Verbosity, "verbose", "v", "enable debug logs, including every external command run")
+	rootCmd.PersistentFlags().StringVar(&logOptions.Level, "log-level", "info", "log level (debug, info, warn, error)")
+	rootCmd.PersistentFlags().StringVar(&logOptions.Format, "log-format", logging.FormatText, "log format (text, json)")
+	rootCmd.PersistentFlags().StringVar(&logOptions.File, "log-file", "", "write logs to this file instead of stderr")
 
 	// Add commands to the root command
 	rootCmd.AddCommand(createCmd.GetCreateCmd())
@@ -67,15 +142,45 @@ func init() {
 	rootCmd.AddCommand(upgradeCmd.GetUpgradeCmd())
 	rootCmd.AddCommand(startCmd.GetStartCmd())
 	rootCmd.AddCommand(stopCmd.GetStopCmd())
+	rootCmd.AddCommand(addonCmd.GetAddonCmd())
+	rootCmd.AddCommand(chartsCmd.GetChartsCmd())
+	rootCmd.AddCommand(snapshotCmd.GetSnapshotCmd())
+	rootCmd.AddCommand(templateCmd.GetTemplateCmd())
+	rootCmd.AddCommand(initCmd.GetInitCmd())
 	rootCmd.AddCommand(configCmd.GetConfigCmd())
 	rootCmd.AddCommand(contextCmd.GetContextCmd())
+	rootCmd.AddCommand(toolsCmd.GetToolCmd())
+	rootCmd.AddCommand(envCmd.GetEnvCmd())
 	rootCmd.AddCommand(versionCmdPkg.GetVersionCmd())
+	rootCmd.AddCommand(selfUpdateCmd.GetSelfUpdateCmd())
+	rootCmd.AddCommand(doctorCmd.GetDoctorCmd())
 }
 
-// initConfig reads in config file and ENV variables
+// initConfig sets up logging and reads in config file and ENV variables
 func initConfig() {
+	// Logs go to stderr (or --log-file) so stdout stays clean for command output
+	if logErr = logging.Setup(logOptions); logErr != nil {
+		return
+	}
+
 	if err := config.InitializeGlobalManager(configFile); err != nil {
-		fmt.Printf("❌ Error initializing configuration: %v\n", err)
-		os.Exit(1)
+		configErr = errdefs.Wrap(errdefs.KindConfig, err, "❌ Error initializing configuration")
+		return
+	}
+	if profile != "" {
+		if err := config.GetManager().SetProfile(profile); err != nil {
+			configErr = fmt.Errorf("❌ %w", err)
+			return
+		}
+	}
+	if path := config.GetManager().GetConfigFilePath(); path != "" {
+		slog.Debug("configuration loaded", "file", path)
+	} else {
+		slog.Debug("no configuration file found, using defaults")
+	}
+
+	// Prefer the tools managed by blitzctl (project first) for every command we run
+	if err := toolchain.ActivateBinDirs(); err != nil {
+		slog.Warn("Failed to activate managed tools", "error", err)
 	}
 }
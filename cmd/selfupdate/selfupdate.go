@@ -0,0 +1,101 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package selfupdate
+
+import (
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/selfupdate"
+	"github.com/OneideLuizSchneider/blitzctl/internal/version"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	selfUpdateExamples = templates.Examples(i18n.T(`
+		# Update blitzctl to the latest release
+		blitzctl self-update
+
+		# Only check whether a newer release exists
+		blitzctl self-update --check
+
+		# Install a specific release
+		blitzctl self-update --version v0.0.3
+	`))
+
+	selfUpdateCmd = &cobra.Command{
+		Use:   "self-update",
+		Short: "Update blitzctl to the latest release",
+		Long: `Update blitzctl itself. The release index is read from --release-url
+(or $BLITZCTL_RELEASE_URL, default: GitHub releases), the binary for your
+OS/arch is downloaded, verified against the release checksums.txt and
+atomically replaces the running binary. The previous binary is kept next to
+it with a .bak suffix.`,
+		Example: selfUpdateExamples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := selfupdate.NewClient(releaseURL)
+
+			release, err := client.Find(cmd.Context(), targetVersion)
+			if err != nil {
+				return fmt.Errorf("❌ %w", err)
+			}
+
+			current := version.Version
+			fmt.Printf("Current version: %s\n", current)
+			fmt.Printf("Target version:  %s\n", release.TagName)
+
+			if !selfupdate.IsRelease(current) {
+				fmt.Println("⚠️ This is a development build")
+			}
+
+			upToDate := selfupdate.Compare(current, release.TagName) >= 0
+			if checkOnly {
+				if upToDate && targetVersion == "" {
+					fmt.Println("✅ blitzctl is up to date")
+				} else {
+					fmt.Printf("⬆️ %s is available, run 'blitzctl self-update'\n", release.TagName)
+				}
+				return nil
+			}
+
+			if targetVersion == "" && upToDate {
+				fmt.Println("✅ blitzctl is up to date")
+				return nil
+			}
+
+			exe, err := selfupdate.Executable()
+			if err != nil {
+				return fmt.Errorf("❌ Error locating the blitzctl binary: %w", err)
+			}
+
+			backup, err := client.Apply(cmd.Context(), release, exe)
+			if err != nil {
+				return fmt.Errorf("❌ Error updating blitzctl: %w", err)
+			}
+
+			fmt.Printf("✅ blitzctl updated to %s (%s)\n", release.TagName, exe)
+			if backup != "" {
+				fmt.Printf("💾 Previous version saved to %s\n", backup)
+			}
+			return nil
+		},
+	}
+
+	targetVersion string
+	checkOnly     bool
+	releaseURL    string
+)
+
+// GetSelfUpdateCmd returns the self-update command
+func GetSelfUpdateCmd() *cobra.Command {
+	return selfUpdateCmd
+}
+
+func init() {
+	selfUpdateCmd.Flags().StringVar(&targetVersion, "version", "", i18n.T("Release to install (default: latest)."))
+	selfUpdateCmd.Flags().BoolVar(&checkOnly, "check", false, i18n.T("Only check for a newer release."))
+	selfUpdateCmd.Flags().StringVar(&releaseURL, "release-url", "", i18n.T("Release index URL (GitHub releases API format)."))
+}
@@ -0,0 +1,40 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/spf13/cobra"
+)
+
+var deleteCmd = &cobra.Command{
+	Use:     "delete <snapshot>...",
+	Aliases: []string{"rm"},
+	Short:   "Delete snapshots of a cluster",
+	Long:    `Remove the images and archives of snapshots and forget them. The cluster is left alone.`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterProviderInstance, clusterName, err := targetCluster()
+		if err != nil {
+			return err
+		}
+		providerType := clusterProviderInstance.GetProviderType()
+
+		for _, name := range args {
+			snapshot, err := config.GetManager().GetSnapshot(clusterName, string(providerType), name)
+			if err != nil {
+				return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error deleting snapshot %s", name).WithHint("blitzctl snapshot list")
+			}
+			if err := provider.DeleteSnapshot(cmd.Context(), *snapshot); err != nil {
+				return err
+			}
+			fmt.Printf("✅ Snapshot %s of cluster '%s' deleted\n", name, clusterName)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,40 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the snapshots of every cluster",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshots := config.GetManager().ListSnapshots()
+		if len(snapshots) == 0 {
+			fmt.Println("No snapshots, save one with 'blitzctl snapshot save <name>'")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "NAME\tCLUSTER\tPROVIDER\tK8S VERSION\tNODES\tSIZE\tCREATED")
+		for _, snapshot := range snapshots {
+			version := snapshot.Cluster.K8sVersion
+			if version == "" {
+				version = "-"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n", snapshot.Name, snapshot.Cluster.Name, snapshot.Cluster.Provider,
+				version, len(snapshot.Nodes), provider.FormatSize(snapshot.Size), snapshot.CreatedAt.Format("2006-01-02 15:04"))
+		}
+		return w.Flush()
+	},
+}
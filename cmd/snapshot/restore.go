@@ -0,0 +1,38 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package snapshot
+
+import (
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+var restoreForce bool
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <snapshot>",
+	Short: "Restore a cluster from a snapshot",
+	Long: `Recreate the node containers of a cluster from a snapshot's images with their
+/var volume restored, start them and wait for the cluster to be ready. The
+cluster comes back with the same name and API server port. An existing cluster is only replaced with --force.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterProviderInstance, clusterName, err := targetCluster()
+		if err != nil {
+			return err
+		}
+		timeout := time.Duration(config.GetManager().GetDefaults().WaitTimeout)
+		return provider.RestoreSnapshot(cmd.Context(), clusterProviderInstance, clusterName, args[0], restoreForce, timeout)
+	},
+}
+
+func init() {
+	restoreCmd.Flags().BoolVar(&restoreForce, "force", false, i18n.T("Delete the cluster first when it exists."))
+	restoreCmd.Flags().Duration("timeout", 0, i18n.T("How long to wait for the restored cluster to be ready (default: the configured wait_timeout)."))
+	config.BindFlag(restoreCmd.Flags().Lookup("timeout"), "wait_timeout")
+}
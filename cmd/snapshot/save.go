@@ -0,0 +1,32 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package snapshot
+
+import (
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+var saveForce bool
+
+var saveCmd = &cobra.Command{
+	Use:   "save <snapshot>",
+	Short: "Save a snapshot of a cluster",
+	Long: `Commit the node containers of a cluster to images and archive their /var
+volume. A running cluster is stopped while the snapshot is taken and started
+again after.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterProviderInstance, clusterName, err := targetCluster()
+		if err != nil {
+			return err
+		}
+		return provider.SaveSnapshot(cmd.Context(), clusterProviderInstance, clusterName, args[0], saveForce)
+	},
+}
+
+func init() {
+	saveCmd.Flags().BoolVar(&saveForce, "force", false, i18n.T("Replace an existing snapshot of the same name."))
+}
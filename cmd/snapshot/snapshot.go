@@ -0,0 +1,82 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package snapshot
+
+import (
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	snapshotExamples = templates.Examples(i18n.T(`
+		# Snapshot a kind cluster once it is set up
+		blitzctl snapshot save golden --provider kind --cluster-name mycluster
+
+		# Bring it back to that state
+		blitzctl snapshot restore golden --provider kind --cluster-name mycluster --force
+
+		# List the snapshots of every cluster
+		blitzctl snapshot list
+
+		# Remove a snapshot and its images
+		blitzctl snapshot delete golden --provider kind --cluster-name mycluster
+	`))
+
+	snapshotCmd = &cobra.Command{
+		Use:     "snapshot",
+		Aliases: []string{"snapshots", "snap"},
+		Short:   "Save and restore snapshots of a cluster",
+		Long: `Save a known-good cluster and restore it in seconds instead of creating it
+again. A snapshot commits every node container to an image and archives the
+node's /var volume, which holds etcd, the pulled images and the kubelet
+state. Minikube snapshots also keep the profile and machine directories.
+
+Snapshots work with kind clusters and with minikube clusters on the docker
+or podman driver. A running cluster is stopped while it is saved and
+started again after. The archives are kept under $XDG_DATA_HOME/blitzctl/snapshots
+(~/.local/share/blitzctl/snapshots) and recorded in the state file.`,
+		Example: snapshotExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmd.Help(); err != nil {
+				cmd.PrintErrln("❌ Error displaying help:", err)
+			}
+		},
+	}
+
+	clusterProvider string
+)
+
+// GetSnapshotCmd returns the snapshot command
+func GetSnapshotCmd() *cobra.Command {
+	return snapshotCmd
+}
+
+func init() {
+	snapshotCmd.PersistentFlags().StringVarP(&clusterProvider, "provider", "p", string(provider.Minikube), i18n.T("Cluster provider (minikube or kind)."))
+	snapshotCmd.PersistentFlags().String("cluster-name", "", i18n.T("Cluster Name (default: the configured cluster_name)."))
+	config.BindFlag(snapshotCmd.PersistentFlags().Lookup("cluster-name"), "cluster_name")
+
+	snapshotCmd.AddCommand(saveCmd)
+	snapshotCmd.AddCommand(restoreCmd)
+	snapshotCmd.AddCommand(listCmd)
+	snapshotCmd.AddCommand(deleteCmd)
+}
+
+// targetCluster returns the provider and name of the cluster selected by
+// --provider and --cluster-name
+func targetCluster() (provider.ClusterProvider, string, error) {
+	providerType, err := provider.ParseProvider(clusterProvider)
+	if err != nil {
+		return nil, "", err
+	}
+	clusterProviderInstance, ok := provider.GetProviderByType(providerType)
+	if !ok {
+		return nil, "", errdefs.Unsupported("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
+	}
+	return clusterProviderInstance, config.GetManager().GetDefaults().ClusterName, nil
+}
@@ -4,10 +4,11 @@ Copyright © 2025 Oneide Luiz Schneider
 package start
 
 import (
-	"fmt"
+	"time"
 
 	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
 	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
 	"github.com/spf13/cobra"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -20,6 +21,9 @@ var (
 
 		# Start a minikube cluster
 		blitzctl start cluster --provider minikube --cluster-name <cluster-name>
+
+		# Start a minikube cluster and wait up to 10 minutes for it to be ready
+		blitzctl start cluster --cluster-name <cluster-name> --wait --timeout 10m
 	`))
 
 	clusterCmd = &cobra.Command{
@@ -29,9 +33,7 @@ var (
 		Example: clusterExamples,
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !cmd.Flags().Changed("cluster-name") {
-				clusterName = config.GetManager().GetDefaults().ClusterName
-			}
+			defaults := config.GetManager().GetDefaults()
 			providerType, err := provider.ParseProvider(clusterProvider)
 			if err != nil {
 				return err
@@ -39,20 +41,34 @@ var (
 
 			clusterProviderInstance, ok := provider.GetProviderByType(providerType)
 			if !ok {
-				return fmt.Errorf("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
+				return errdefs.Unsupported("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
 			}
 
-			return clusterProviderInstance.Start(&provider.Default{
-				ClusterName: clusterName,
+			err = clusterProviderInstance.Start(cmd.Context(), &provider.StartOptions{
+				Default: provider.Default{
+					ClusterName: defaults.ClusterName,
+				},
+				WaitOptions: provider.WaitOptions{
+					Wait:    defaults.Wait,
+					Timeout: time.Duration(defaults.WaitTimeout),
+				},
 			})
+			if err != nil {
+				return err
+			}
+			return provider.RunHooks(cmd.Context(), provider.HookPostStart, providerType, defaults.ClusterName, defaults.Hooks.PostStart)
 		},
 	}
 
 	clusterProvider string
-	clusterName     string
 )
 
 func init() {
 	clusterCmd.Flags().StringVarP(&clusterProvider, "provider", "p", string(provider.Minikube), i18n.T("Cluster provider (minikube or kind)."))
-	clusterCmd.Flags().StringVar(&clusterName, "cluster-name", "", i18n.T("Cluster Name."))
+	clusterCmd.Flags().String("cluster-name", "", i18n.T("Cluster Name (default: the configured cluster_name)."))
+	config.BindFlag(clusterCmd.Flags().Lookup("cluster-name"), "cluster_name")
+	clusterCmd.Flags().Bool("wait", false, i18n.T("Wait until nodes are Ready and kube-system workloads are available (default: the configured wait)."))
+	clusterCmd.Flags().Duration("timeout", 0, i18n.T("How long --wait waits before marking the cluster degraded (default: the configured wait_timeout)."))
+	config.BindFlag(clusterCmd.Flags().Lookup("wait"), "wait")
+	config.BindFlag(clusterCmd.Flags().Lookup("timeout"), "wait_timeout")
 }
@@ -4,10 +4,9 @@ Copyright © 2025 Oneide Luiz Schneider
 package stop
 
 import (
-	"fmt"
-
 	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
 	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
 	"github.com/spf13/cobra"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -29,9 +28,7 @@ var (
 		Example: clusterExamples,
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !cmd.Flags().Changed("cluster-name") {
-				clusterName = config.GetManager().GetDefaults().ClusterName
-			}
+			clusterName := config.GetManager().GetDefaults().ClusterName
 			providerType, err := provider.ParseProvider(clusterProvider)
 			if err != nil {
 				return err
@@ -39,20 +36,20 @@ var (
 
 			clusterProviderInstance, ok := provider.GetProviderByType(providerType)
 			if !ok {
-				return fmt.Errorf("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
+				return errdefs.Unsupported("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
 			}
 
-			return clusterProviderInstance.Stop(&provider.Default{
+			return clusterProviderInstance.Stop(cmd.Context(), &provider.Default{
 				ClusterName: clusterName,
 			})
 		},
 	}
 
 	clusterProvider string
-	clusterName     string
 )
 
 func init() {
 	clusterCmd.Flags().StringVarP(&clusterProvider, "provider", "p", string(provider.Minikube), i18n.T("Cluster provider (minikube or kind)."))
-	clusterCmd.Flags().StringVar(&clusterName, "cluster-name", "", i18n.T("Cluster Name."))
+	clusterCmd.Flags().String("cluster-name", "", i18n.T("Cluster Name (default: the configured cluster_name)."))
+	config.BindFlag(clusterCmd.Flags().Lookup("cluster-name"), "cluster_name")
 }
@@ -0,0 +1,27 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package template
+
+import (
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+)
+
+var deleteCmd = &cobra.Command{
+	Use:     "delete <template>...",
+	Aliases: []string{"rm"},
+	Short:   "Delete cluster templates",
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, name := range args {
+			if err := config.GetManager().DeleteTemplate(name); err != nil {
+				return fmt.Errorf("❌ Error deleting template: %w", err)
+			}
+			fmt.Printf("✅ Deleted template %s\n", name)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,64 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package template
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List cluster templates",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager := config.GetManager()
+		names := manager.ListTemplates()
+		if len(names) == 0 {
+			fmt.Println("No templates, save one with 'blitzctl template save <cluster> <template>'")
+			return nil
+		}
+
+		orDash := func(value string) string {
+			if value == "" {
+				return "-"
+			}
+			return value
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "NAME\tPROVIDER\tK8S VERSION\tDRIVER\tCNI\tNODES\tOPTIONS\tADDONS")
+		for _, name := range names {
+			template, err := manager.GetTemplate(name)
+			if err != nil {
+				return err
+			}
+
+			nodes := "-"
+			if template.Nodes > 0 {
+				nodes = fmt.Sprint(template.Nodes)
+			}
+			var options []string
+			for _, key := range slices.Sorted(maps.Keys(template.Options)) {
+				options = append(options, key+"="+template.Options[key])
+			}
+			for _, extra := range template.ExtraConfig {
+				options = append(options, "extra_config="+extra)
+			}
+
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", name, template.Provider, orDash(template.K8sVersion),
+				orDash(template.Driver), orDash(template.CNI), nodes,
+				orDash(strings.Join(options, ",")), orDash(strings.Join(template.Addons, ",")))
+		}
+		return w.Flush()
+	},
+}
@@ -0,0 +1,57 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package template
+
+import (
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+var (
+	saveProvider string
+	saveForce    bool
+)
+
+var saveCmd = &cobra.Command{
+	Use:   "save <cluster> <template>",
+	Short: "Save the shape of a tracked cluster as a template",
+	Long: `Save the provider, k8s version, driver, CNI, node count, resources and addons
+of a tracked cluster as a template. --provider picks the cluster when a kind
+and a minikube cluster share its name.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterName, name := args[0], args[1]
+
+		var providerFilter string
+		if saveProvider != "" {
+			providerType, err := provider.ParseProvider(saveProvider)
+			if err != nil {
+				return err
+			}
+			providerFilter = string(providerType)
+		}
+
+		manager := config.GetManager()
+		cluster, err := manager.FindCluster(clusterName, providerFilter)
+		if err != nil {
+			return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error saving template %s", name)
+		}
+		if err := manager.SaveTemplate(name, cluster.Template(), saveForce); err != nil {
+			return errdefs.Wrap(errdefs.KindOf(err), err, "❌ Error saving template %s", name)
+		}
+
+		fmt.Printf("✅ Saved cluster '%s' (%s) as template %s in %s\n", cluster.Name, cluster.Provider, name, manager.GetConfigFilePath())
+		return nil
+	},
+}
+
+func init() {
+	saveCmd.Flags().StringVarP(&saveProvider, "provider", "p", "", i18n.T("Provider of the cluster (minikube or kind), needed when both have a cluster of that name."))
+	saveCmd.Flags().BoolVar(&saveForce, "force", false, i18n.T("Replace an existing template of the same name."))
+}
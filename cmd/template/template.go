@@ -0,0 +1,55 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package template
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	templateExamples = templates.Examples(i18n.T(`
+		# Save the shape of mycluster as the "ci" template
+		blitzctl template save mycluster ci
+
+		# Create clusters from it
+		blitzctl create cluster --template ci --cluster-name=pr-123
+
+		# List and delete templates
+		blitzctl template list
+		blitzctl template delete ci
+	`))
+
+	templateCmd = &cobra.Command{
+		Use:     "template",
+		Aliases: []string{"templates"},
+		Short:   "Save the shape of a cluster to create identical ones",
+		Long: `Save the shape of a tracked cluster under a name: its provider, k8s version,
+driver, CNI, node count, resources and addons. 'blitzctl create cluster
+--template <name>' creates clusters from it, flags given on the command line
+still win. Templates are kept under templates in the configuration file, a
+project file shares them with the team.
+
+'blitzctl create cluster --from <cluster>' does the same straight from a
+tracked cluster, without saving a template.`,
+		Example: templateExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmd.Help(); err != nil {
+				cmd.PrintErrln("❌ Error displaying help:", err)
+			}
+		},
+	}
+)
+
+// GetTemplateCmd returns the template command
+func GetTemplateCmd() *cobra.Command {
+	return templateCmd
+}
+
+func init() {
+	templateCmd.AddCommand(saveCmd)
+	templateCmd.AddCommand(listCmd)
+	templateCmd.AddCommand(deleteCmd)
+}
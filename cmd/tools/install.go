@@ -0,0 +1,33 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package tools
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+var (
+	installCmd = &cobra.Command{
+		Use:   "install [tool...]",
+		Short: "Install tools at their pinned versions",
+		Long: `Install tools at the versions pinned in the configuration.
+Use --version to install a specific version instead.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			selected, err := SelectTools(args, installAll)
+			if err != nil {
+				return err
+			}
+			return InstallTools(cmd.Context(), selected, installVersion)
+		},
+	}
+
+	installAll     bool
+	installVersion string
+)
+
+func init() {
+	installCmd.Flags().BoolVar(&installAll, "all", false, i18n.T("Install every managed tool."))
+	installCmd.Flags().StringVar(&installVersion, "version", "", i18n.T("Version to install instead of the pinned one (or 'latest')."))
+}
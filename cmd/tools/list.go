@@ -0,0 +1,52 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package tools
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List managed tools with pinned and installed versions",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		installer, err := newInstaller()
+		if err != nil {
+			return err
+		}
+		receipts, err := installer.Receipts()
+		if err != nil {
+			return fmt.Errorf("❌ %w", err)
+		}
+		defaults := config.GetManager().GetDefaults()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "NAME\tPINNED\tINSTALLED\tDESCRIPTION")
+		for _, tool := range toolchain.Tools() {
+			installed := "-"
+			if receipt, ok := receipts[tool.Name]; ok {
+				installed = receipt.Version
+			}
+			pinned := toolchain.NormalizeVersion(tool.Pinned(defaults))
+			if pinned != "" && installed != "-" && pinned != installed {
+				installed += " ⚠️"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", tool.Name, pinned, installed, tool.Description)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Printf("\nBin Directory: %s\n", installer.BinDir)
+		return nil
+	},
+}
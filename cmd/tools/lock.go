@@ -0,0 +1,99 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+var (
+	lockCmd = &cobra.Command{
+		Use:   "lock [tool...]",
+		Short: "Write the project lockfile from the pinned tool versions",
+		Long: `Write ./.blitzctl/blitzctl.lock with the exact versions and sha256 checksums
+of the given tools for every supported platform, plus the digest of the kind
+node image for the configured Kubernetes version.
+
+Without arguments the tools already in the lockfile are re-locked, or every
+managed tool when there is no lockfile yet. Commit the lockfile so that
+'blitzctl tool sync' reproduces the same toolchain on any checkout.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := toolchain.ProjectLockPath()
+			if err != nil {
+				return err
+			}
+
+			lock, err := toolchain.LoadLockfile(path)
+			if errors.Is(err, os.ErrNotExist) {
+				lock = &toolchain.Lockfile{}
+			} else if err != nil {
+				return fmt.Errorf("❌ %w", err)
+			}
+			if lock.Tools == nil {
+				lock.Tools = map[string]toolchain.LockedTool{}
+			}
+
+			names := args
+			if len(names) == 0 {
+				names = lock.ToolNames()
+			}
+			selected, err := SelectTools(names, len(names) == 0)
+			if err != nil {
+				return err
+			}
+
+			installer, err := newInstaller()
+			if err != nil {
+				return err
+			}
+			defaults := config.GetManager().GetDefaults()
+
+			for _, tool := range selected {
+				version, err := installer.ResolveVersion(cmd.Context(), tool, "", defaults)
+				if err != nil {
+					return fmt.Errorf("❌ %w", err)
+				}
+				fmt.Printf("🔒 Locking %s %s...\n", tool.Name, version)
+				locked, err := installer.Lock(cmd.Context(), tool, version)
+				if err != nil {
+					return fmt.Errorf("❌ %w", err)
+				}
+				lock.Tools[tool.Name] = locked
+			}
+
+			switch {
+			case lockKindImage != "":
+				lock.KindNodeImage = lockKindImage
+			case !lockSkipKindImage:
+				fmt.Printf("🔒 Resolving kind node image for Kubernetes %s...\n", defaults.K8sVersion)
+				image, err := installer.ResolveKindNodeImage(cmd.Context(), defaults.K8sVersion)
+				if err != nil {
+					return fmt.Errorf("❌ %w (use --kind-image or --skip-kind-image)", err)
+				}
+				lock.KindNodeImage = image
+			}
+
+			if err := lock.Save(path); err != nil {
+				return fmt.Errorf("❌ Error writing lockfile: %w", err)
+			}
+			fmt.Printf("✅ Lockfile written to %s\n", path)
+			return nil
+		},
+	}
+
+	lockKindImage     string
+	lockSkipKindImage bool
+)
+
+func init() {
+	lockCmd.Flags().StringVar(&lockKindImage, "kind-image", "", i18n.T("Kind node image to lock (e.g. kindest/node:v1.36.2@sha256:...)."))
+	lockCmd.Flags().BoolVar(&lockSkipKindImage, "skip-kind-image", false, i18n.T("Don't resolve the kind node image digest."))
+}
@@ -0,0 +1,62 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package tools
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+var (
+	removeCmd = &cobra.Command{
+		Use:     "remove [tool...]",
+		Aliases: []string{"rm", "uninstall"},
+		Short:   "Remove tools installed by blitzctl",
+		Long:    `Remove tools installed by blitzctl. Binaries installed by other means are never touched.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			installer, err := newInstaller()
+			if err != nil {
+				return err
+			}
+
+			if removeAll {
+				receipts, err := installer.Receipts()
+				if err != nil {
+					return fmt.Errorf("❌ %w", err)
+				}
+				args = args[:0]
+				for name := range receipts {
+					args = append(args, name)
+				}
+				if len(args) == 0 {
+					fmt.Println("No tools installed by blitzctl")
+					return nil
+				}
+				sort.Strings(args)
+			}
+
+			selected, err := SelectTools(args, false)
+			if err != nil {
+				return err
+			}
+
+			for _, tool := range selected {
+				if err := installer.Remove(tool); err != nil {
+					return fmt.Errorf("❌ %w", err)
+				}
+				fmt.Printf("🗑️ %s removed\n", tool.Name)
+			}
+			return nil
+		},
+	}
+
+	removeAll bool
+)
+
+func init() {
+	removeCmd.Flags().BoolVar(&removeAll, "all", false, i18n.T("Remove every tool installed by blitzctl."))
+}
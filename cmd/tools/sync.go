@@ -0,0 +1,95 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package tools
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Install exactly the tools recorded in the project lockfile",
+	Long: `Install the tool versions recorded in ./.blitzctl/blitzctl.lock into the
+project bin directory (./.blitzctl/bin), verifying each download against the
+locked checksum. Tools no longer in the lockfile are removed from the project
+bin directory, which ./.blitzctl/.gitignore keeps out of git. Run
+'eval "$(blitzctl env)"' to use them from your shell.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lock, err := toolchain.LoadProjectLockfile()
+		if err != nil {
+			return fmt.Errorf("❌ %w", err)
+		}
+		if lock == nil {
+			return errdefs.NotFound("❌ No %s found in ./.blitzctl", toolchain.LockFileName).WithHint("blitzctl tool lock")
+		}
+
+		binDir, err := toolchain.ProjectBinDir()
+		if err != nil {
+			return err
+		}
+		if err := toolchain.IgnoreProjectBinDir(); err != nil {
+			slog.Warn("Failed to add the project bin directory to .gitignore", "error", err)
+		}
+		installer := toolchain.NewInstaller(binDir)
+		receipts, err := installer.Receipts()
+		if err != nil {
+			return fmt.Errorf("❌ %w", err)
+		}
+		platform := toolchain.CurrentPlatform("").PlatformKey()
+
+		for _, name := range lock.ToolNames() {
+			tool, err := toolchain.Lookup(name)
+			if err != nil {
+				return fmt.Errorf("❌ %w", err)
+			}
+
+			locked := lock.Tools[name]
+			expected := locked.Checksums[platform]
+			if expected == "" {
+				return errdefs.NotFound("❌ %s has no checksum for %s in the lockfile", name, platform).WithHint("blitzctl tool lock %s", name)
+			}
+
+			if receipt, ok := receipts[name]; ok && receipt.Version == locked.Version && receipt.SHA256 == expected {
+				if _, err := os.Stat(receipt.Path); err == nil {
+					fmt.Printf("✅ %s %s is up to date\n", name, locked.Version)
+					continue
+				}
+			}
+
+			if _, err := installer.InstallVerified(cmd.Context(), tool, locked.Version, expected); err != nil {
+				return fmt.Errorf("❌ Error installing %s: %w", name, err)
+			}
+			fmt.Printf("✅ %s %s installed\n", name, locked.Version)
+		}
+
+		var stale []string
+		for name := range receipts {
+			if _, ok := lock.Tools[name]; !ok {
+				stale = append(stale, name)
+			}
+		}
+		sort.Strings(stale)
+		for _, name := range stale {
+			tool, err := toolchain.Lookup(name)
+			if err != nil {
+				continue
+			}
+			if err := installer.Remove(tool); err != nil {
+				return fmt.Errorf("❌ %w", err)
+			}
+			fmt.Printf("🗑️ %s removed (not in lockfile)\n", name)
+		}
+
+		fmt.Printf("📁 Project tools: %s\n", binDir)
+		return nil
+	},
+}
@@ -0,0 +1,127 @@
+/*
+Copyright © 2025 Oneide Luiz Schneider
+*/
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolExamples = templates.Examples(i18n.T(`
+		# Install the pinned version of kubectl and kind
+		blitzctl tool install kubectl kind
+
+		# Install every managed tool
+		blitzctl tool install --all
+
+		# Upgrade helm to the latest release
+		blitzctl tool upgrade helm
+
+		# Show pinned and installed versions
+		blitzctl tool list
+
+		# Show which binary will be used
+		blitzctl tool which kubectl
+
+		# Lock the project toolchain and install it on another checkout
+		blitzctl tool lock
+		blitzctl tool sync
+	`))
+
+	toolCmd = &cobra.Command{
+		Use:     "tool",
+		Aliases: []string{"tools"},
+		Short:   "Manage CLI tools like kubectl, kind and helm",
+		Long: `Install, upgrade and remove the CLI tools used with local clusters.
+
+Tools are downloaded from their official release locations, verified against
+the published sha256 checksums and installed into ~/.blitzctl/bin. Versions
+are pinned in the configuration defaults (e.g. kind_version), so a project
+can check in ./.blitzctl/config.yaml and every teammate gets the same tools.`,
+		Example: toolExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmd.Help(); err != nil {
+				cmd.PrintErrln("❌ Error displaying help:", err)
+			}
+		},
+	}
+)
+
+// GetToolCmd returns the tool command
+func GetToolCmd() *cobra.Command {
+	return toolCmd
+}
+
+func init() {
+	toolCmd.AddCommand(installCmd)
+	toolCmd.AddCommand(upgradeCmd)
+	toolCmd.AddCommand(listCmd)
+	toolCmd.AddCommand(removeCmd)
+	toolCmd.AddCommand(whichCmd)
+	toolCmd.AddCommand(lockCmd)
+	toolCmd.AddCommand(syncCmd)
+}
+
+// newInstaller returns an installer for the default bin directory
+func newInstaller() (*toolchain.Installer, error) {
+	binDir, err := toolchain.DefaultBinDir()
+	if err != nil {
+		return nil, err
+	}
+	return toolchain.NewInstaller(binDir), nil
+}
+
+// SelectTools resolves tool names given on the command line
+func SelectTools(names []string, all bool) ([]*toolchain.Tool, error) {
+	if all {
+		return toolchain.Tools(), nil
+	}
+	if len(names) == 0 {
+		return nil, errdefs.Invalid("❌ Specify at least one tool or --all (available: %v)", toolchain.Names())
+	}
+
+	selected := make([]*toolchain.Tool, 0, len(names))
+	for _, name := range names {
+		tool, err := toolchain.Lookup(name)
+		if err != nil {
+			return nil, fmt.Errorf("❌ %w", err)
+		}
+		selected = append(selected, tool)
+	}
+	return selected, nil
+}
+
+// InstallTools installs each tool at version, or its pinned version when
+// version is empty
+func InstallTools(ctx context.Context, tools []*toolchain.Tool, version string) error {
+	installer, err := newInstaller()
+	if err != nil {
+		return err
+	}
+	defaults := config.GetManager().GetDefaults()
+
+	for _, tool := range tools {
+		resolved, err := installer.ResolveVersion(ctx, tool, version, defaults)
+		if err != nil {
+			return fmt.Errorf("❌ %w", err)
+		}
+
+		receipt, err := installer.Install(ctx, tool, resolved)
+		if err != nil {
+			return fmt.Errorf("❌ Error installing %s: %w", tool.Name, err)
+		}
+		fmt.Printf("✅ %s %s installed to %s\n", receipt.Name, receipt.Version, receipt.Path)
+	}
+
+	fmt.Printf("💡 Make sure %s is in your PATH\n", installer.BinDir)
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package tools
+
+import (
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+var (
+	upgradeCmd = &cobra.Command{
+		Use:   "upgrade [tool...]",
+		Short: "Upgrade tools to the latest release",
+		Long: `Upgrade tools to their latest release, or to --version.
+Use --pin to also record the new version in the configuration so
+the rest of the team picks it up.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			selected, err := SelectTools(args, upgradeAll)
+			if err != nil {
+				return err
+			}
+
+			installer, err := newInstaller()
+			if err != nil {
+				return err
+			}
+			manager := config.GetManager()
+
+			for _, tool := range selected {
+				version, err := installer.ResolveVersion(cmd.Context(), tool, upgradeVersion, manager.GetDefaults())
+				if err != nil {
+					return fmt.Errorf("❌ %w", err)
+				}
+
+				receipt, err := installer.Install(cmd.Context(), tool, version)
+				if err != nil {
+					return fmt.Errorf("❌ Error upgrading %s: %w", tool.Name, err)
+				}
+				fmt.Printf("✅ %s upgraded to %s\n", receipt.Name, receipt.Version)
+
+				if upgradePin {
+					if err := manager.SetDefault(tool.Name+"_version", receipt.Version); err != nil {
+						return fmt.Errorf("❌ Error pinning %s: %w", tool.Name, err)
+					}
+					fmt.Printf("📌 Pinned %s_version = %s\n", tool.Name, receipt.Version)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	upgradeAll     bool
+	upgradePin     bool
+	upgradeVersion string
+)
+
+func init() {
+	upgradeCmd.Flags().BoolVar(&upgradeAll, "all", false, i18n.T("Upgrade every managed tool."))
+	upgradeCmd.Flags().BoolVar(&upgradePin, "pin", false, i18n.T("Save the upgraded version in the configuration."))
+	upgradeCmd.Flags().StringVar(&upgradeVersion, "version", "latest", i18n.T("Version to upgrade to."))
+}
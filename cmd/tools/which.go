@@ -0,0 +1,46 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package tools
+
+import (
+	"fmt"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
+	"github.com/spf13/cobra"
+)
+
+var whichCmd = &cobra.Command{
+	Use:   "which <tool>",
+	Short: "Show the path of the binary that will be used for a tool",
+	Long: `Show the path of the binary that will be used for a tool. The project bin
+directory (./.blitzctl/bin) wins over ~/.blitzctl/bin, which wins over PATH.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool, err := toolchain.Lookup(args[0])
+		if err != nil {
+			return fmt.Errorf("❌ %w", err)
+		}
+
+		for _, binDir := range toolchain.BinDirs() {
+			path, managed, err := toolchain.NewInstaller(binDir).Which(tool)
+			if err == nil && managed {
+				fmt.Println(path)
+				return nil
+			}
+		}
+
+		installer, err := newInstaller()
+		if err != nil {
+			return err
+		}
+		path, _, err := installer.Which(tool)
+		if err != nil {
+			return fmt.Errorf("❌ %w", err)
+		}
+
+		fmt.Println(path)
+		fmt.Printf("💡 %s is not managed by blitzctl, run 'blitzctl tool install %s'\n", tool.Name, tool.Name)
+		return nil
+	},
+}
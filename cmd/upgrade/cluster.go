@@ -4,9 +4,8 @@ Copyright © 2025 Oneide Luiz Schneider
 package upgrade
 
 import (
-	"fmt"
-
 	"github.com/OneideLuizSchneider/blitzctl/cmd/cluster/provider"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
 	"github.com/spf13/cobra"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -17,14 +16,25 @@ var (
 		# Upgrade minikube (default provider)
 		blitzctl upgrade cluster
 
-		# Upgrade kind
+		# Upgrade kind to the configured kind_version
 		blitzctl upgrade cluster --provider kind
+
+		# Upgrade kind to the latest release
+		blitzctl upgrade cluster --provider kind --version latest
+
+		# Upgrade kind to a specific version
+		blitzctl upgrade cluster --provider kind --version v0.31.0
+
+		# Restore the previous kind binary
+		blitzctl upgrade cluster --provider kind --rollback
 	`))
 
 	clusterCmd = &cobra.Command{
-		Use:     "cluster",
-		Short:   "Upgrade a k8s tool",
-		Long:    `Upgrade a local k8s tool using the specified provider.`,
+		Use:   "cluster",
+		Short: "Upgrade a k8s tool",
+		Long: `Upgrade the CLI of the specified provider. The release is downloaded for
+your OS/arch, verified against its published sha256 checksum and atomically
+replaces the current binary, which is kept as a backup for --rollback.`,
 		Example: clusterExamples,
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -35,16 +45,28 @@ var (
 
 			clusterProviderInstance, ok := provider.GetProviderByType(providerType)
 			if !ok {
-				return fmt.Errorf("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
+				return errdefs.Unsupported("❌ Unsupported provider: %s (supported: minikube, kind)", clusterProvider)
 			}
 
-			return clusterProviderInstance.Upgrade(&provider.UpgradeOptions{})
+			return clusterProviderInstance.Upgrade(cmd.Context(), &provider.UpgradeOptions{
+				BinaryOptions: provider.BinaryOptions{
+					Version: binaryVersion,
+					BinDir:  binDir,
+				},
+				Rollback: rollback,
+			})
 		},
 	}
 
 	clusterProvider string
+	binaryVersion   string
+	binDir          string
+	rollback        bool
 )
 
 func init() {
 	clusterCmd.Flags().StringVarP(&clusterProvider, "provider", "p", string(provider.Minikube), i18n.T("Cluster provider (minikube or kind)."))
+	clusterCmd.Flags().StringVar(&binaryVersion, "version", "", i18n.T("Version to upgrade to, or 'latest' (default: the configured kind_version or minikube_version)."))
+	clusterCmd.Flags().StringVar(&binDir, "bin-dir", "", i18n.T("Directory of the binary to upgrade (default: the one on PATH)."))
+	clusterCmd.Flags().BoolVar(&rollback, "rollback", false, i18n.T("Restore the binary replaced by the previous upgrade."))
 }
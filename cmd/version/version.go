@@ -4,22 +4,115 @@ Copyright © 2025 Oneide Luiz Schneider
 package version
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
 
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
 	"github.com/OneideLuizSchneider/blitzctl/internal/version"
 )
 
-var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "Print the blitzctl version",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println(version.String())
-	},
+var (
+	versionExamples = templates.Examples(i18n.T(`
+		# Print the blitzctl version
+		blitzctl version
+
+		# Include build details and the detected client tools
+		blitzctl version --client-tools
+
+		# Machine readable output for bug reports
+		blitzctl version --client-tools --output json
+	`))
+
+	versionCmd = &cobra.Command{
+		Use:     "version",
+		Short:   "Print the blitzctl version",
+		Example: versionExamples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report := versionReport{Client: version.Get()}
+			if clientTools {
+				report.K8sVersion = config.GetManager().GetDefaults().K8sVersion
+				report.Tools = toolchain.Detect(report.K8sVersion)
+			}
+
+			switch output {
+			case "json":
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+			case "":
+				if !clientTools {
+					fmt.Println(version.String())
+					return nil
+				}
+				return printReport(report)
+			default:
+				return errdefs.Invalid("❌ Unsupported output format: %s (supported: json)", output)
+			}
+			return nil
+		},
+	}
+
+	output      string
+	clientTools bool
+)
+
+type versionReport struct {
+	Client     version.Info         `json:"client"`
+	K8sVersion string               `json:"k8sVersion,omitempty"`
+	Tools      []toolchain.Detected `json:"tools,omitempty"`
+}
+
+func printReport(report versionReport) error {
+	fmt.Printf("blitzctl: %s\n", report.Client.Version)
+	fmt.Printf("Commit: %s\n", report.Client.GitCommit)
+	fmt.Printf("Built: %s\n", report.Client.BuildDate)
+	fmt.Printf("Go: %s %s\n", report.Client.GoVersion, report.Client.Platform)
+	if report.Client.Module != "" {
+		fmt.Printf("Module: %s\n", report.Client.Module)
+	}
+	if modified := report.Client.Build["vcs.modified"]; modified == "true" {
+		fmt.Println("Source: modified (uncommitted changes)")
+	}
+
+	fmt.Printf("\nClient Tools (Kubernetes %s):\n", report.K8sVersion)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  NAME\tVERSION\tMINIMUM\tSTATUS\tNOTES")
+	for _, tool := range report.Tools {
+		icon := "✅"
+		switch tool.Status {
+		case toolchain.StatusMissing:
+			icon = "➖"
+		case toolchain.StatusOutdated, toolchain.StatusIncompatible:
+			icon = "⚠️"
+		case toolchain.StatusUnknown:
+			icon = "❓"
+		}
+		ver := tool.Version
+		if ver == "" {
+			ver = "-"
+		}
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s %s\t%s\n", tool.Name, ver, tool.Minimum, icon, tool.Status, tool.Message)
+	}
+	return w.Flush()
 }
 
 // GetVersionCmd exposes the version command to the root command.
 func GetVersionCmd() *cobra.Command {
 	return versionCmd
 }
+
+func init() {
+	versionCmd.Flags().StringVarP(&output, "output", "o", "", i18n.T("Output format (json)."))
+	versionCmd.Flags().BoolVar(&clientTools, "client-tools", false, i18n.T("Include build details and detected client tools."))
+}
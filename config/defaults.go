@@ -1,7 +1,11 @@
 package config
 
+import "time"
+
 // Global defaults for the CLI
-// CNI for Minikube: auto, bridge, calico, cilium, flannel, kindnet, or path to a CNI manifest (default: auto)
+// CNI for Minikube: auto, bridge, calico, cilium, flannel, kindnet, false, or path to a CNI manifest (default: auto)
+// CNI for Kind: kindnet, cilium, calico, flannel, none, or path to a CNI manifest (default: kindnet,
+// DefaultCni is minikube's and only applies to kind when cni is configured)
 // Driver for Kind: docker, containerd, or path to a driver binary (default: docker)
 // Driver for Minikube: docker, podman, virtualbox, vmware, kvm2, hyperkit, qemu, ssh, or path to a driver binary (default: docker)
 // - Minikube - container-runtime: docker, containerd, cri-o, or auto (default: auto)
@@ -14,7 +18,27 @@ const (
 	DefaultDriver      = "docker"
 	DefaultClusterName = "blitz-cluster1"
 	DefaultCni         = "cilium"
+	DefaultNodes       = 1
+	DefaultWaitTimeout = Duration(5 * time.Minute)
+	DefaultHookTimeout = Duration(5 * time.Minute)
 
 	// https://github.com/helm/helm/releases
 	DefaultHelmVersion = "3.21.4"
 )
+
+// Pinned versions for the tools managed by `blitzctl tool`.
+// Projects can override any of them in ./.blitzctl/config.yaml.
+const (
+	// https://kubernetes.io/releases/
+	DefaultKubectlVersion = DefaultK8sVersion
+	// https://github.com/kubernetes-sigs/kind/releases
+	DefaultKindVersion = "0.31.0"
+	// https://github.com/kubernetes/minikube/releases
+	DefaultMinikubeVersion = "1.38.1"
+	// https://github.com/derailed/k9s/releases
+	DefaultK9sVersion = "0.50.16"
+	// https://github.com/kubernetes-sigs/kustomize/releases
+	DefaultKustomizeVersion = "5.8.1"
+	// https://github.com/stern/stern/releases
+	DefaultSternVersion = "1.33.1"
+)
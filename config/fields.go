@@ -0,0 +1,273 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// field is a settable key of the defaults section, found by reflection so
+// new fields of Defaults, nested ones included, need no extra code
+type field struct {
+	// key is the dotted path of the yaml names, e.g. resources.cpus
+	key   string
+	index []int
+	typ   reflect.Type
+}
+
+var durationType = reflect.TypeFor[Duration]()
+
+// defaultsFields are the leaf fields of Defaults, in file order
+var defaultsFields = collectFields(reflect.TypeFor[Defaults](), "", nil)
+
+// collectFields returns the leaf fields of t, descending into nested structs
+func collectFields(t reflect.Type, prefix string, index []int) []field {
+	var fields []field
+	for i := range t.NumField() {
+		structField := t.Field(i)
+		name, _, _ := strings.Cut(structField.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		key := prefix + name
+		fieldIndex := append(slices.Clone(index), i)
+		if structField.Type.Kind() == reflect.Struct && structField.Type != durationType {
+			fields = append(fields, collectFields(structField.Type, key+".", fieldIndex)...)
+			continue
+		}
+		fields = append(fields, field{key: key, index: fieldIndex, typ: structField.Type})
+	}
+	return fields
+}
+
+// lookupField returns the field of key, accepting dashes for underscores
+func lookupField(key string) (field, error) {
+	key = normalizeKey(key)
+	i := slices.IndexFunc(defaultsFields, func(f field) bool { return f.key == key })
+	if i < 0 {
+		return field{}, errdefs.Invalid("unknown configuration key: %s", key).
+			WithHint("blitzctl config explain lists every key")
+	}
+	return defaultsFields[i], nil
+}
+
+// normalizeKey turns the dashed form of a key used on the command line
+// into the form used in the file
+func normalizeKey(key string) string {
+	return strings.ReplaceAll(key, "-", "_")
+}
+
+// DefaultsKeys returns the keys of the defaults section, in file order
+func DefaultsKeys() []string {
+	keys := make([]string, 0, len(defaultsFields))
+	for _, f := range defaultsFields {
+		keys = append(keys, f.key)
+	}
+	return keys
+}
+
+// KeyType describes the type of the value of key for humans
+func KeyType(key string) string {
+	f, err := lookupField(key)
+	if err != nil {
+		return ""
+	}
+	return typeName(f.typ)
+}
+
+func typeName(t reflect.Type) string {
+	switch {
+	case t == durationType:
+		return "duration"
+	case t.Kind() == reflect.Bool:
+		return "boolean"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		return "integer"
+	case t.Kind() == reflect.Slice:
+		return "list of " + typeName(t.Elem())
+	case t.Kind() == reflect.Struct:
+		return "object"
+	default:
+		return "string"
+	}
+}
+
+// value returns the field in defaults
+func (f field) value(defaults *Defaults) reflect.Value {
+	return reflect.ValueOf(defaults).Elem().FieldByIndex(f.index)
+}
+
+// parse converts raw into a value of the field, a list is comma separated
+func (f field) parse(raw string) (reflect.Value, error) {
+	if f.typ.Kind() != reflect.Slice {
+		return f.parseScalar(f.typ, raw)
+	}
+
+	list := reflect.MakeSlice(f.typ, 0, 0)
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		value, err := f.parseScalar(f.typ.Elem(), item)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		list = reflect.Append(list, value)
+	}
+	return list, nil
+}
+
+func (f field) parseScalar(t reflect.Type, raw string) (reflect.Value, error) {
+	value := reflect.New(t).Elem()
+	switch {
+	case t == durationType:
+		d, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil {
+			return value, fmt.Errorf("%q is not a duration (e.g. 90s, 5m)", raw)
+		}
+		value.SetInt(int64(d))
+	case t.Kind() == reflect.String:
+		if strings.HasSuffix(f.key, "_version") {
+			raw = normalizeVersion(raw)
+		}
+		value.SetString(raw)
+	case t.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return value, fmt.Errorf("%q is not a boolean (true or false)", raw)
+		}
+		value.SetBool(b)
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		i, err := strconv.ParseInt(strings.TrimSpace(raw), 10, t.Bits())
+		if err != nil {
+			return value, fmt.Errorf("%q is not an integer", raw)
+		}
+		value.SetInt(i)
+	case t.Kind() == reflect.Struct:
+		return value, errors.New("only the configuration file can set it, see 'blitzctl config edit'")
+	default:
+		return value, errdefs.Unsupported("%s of type %s cannot be set", f.key, t)
+	}
+	return value, nil
+}
+
+// validator is implemented by the structured values of the defaults, which
+// check themselves
+type validator interface {
+	Validate() error
+}
+
+// check validates value against the schema, every item of a list on its own
+func (f field) check(value reflect.Value) error {
+	if value.Kind() != reflect.Slice {
+		return f.checkItem(value)
+	}
+	for i := range value.Len() {
+		if err := f.checkItem(value.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f field) checkItem(value reflect.Value) error {
+	if v, ok := value.Interface().(validator); ok {
+		return v.Validate()
+	}
+	if validate := defaultsSchema[f.key]; validate != nil {
+		return validate(formatValue(value))
+	}
+	return nil
+}
+
+// formatValue renders a value the way it is typed on the command line
+func formatValue(value reflect.Value) string {
+	if value.Kind() == reflect.Slice {
+		items := make([]string, value.Len())
+		for i := range value.Len() {
+			items[i] = formatValue(value.Index(i))
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// FormatValue renders a value returned by GetDefaultIn the way it is typed
+// on the command line
+func FormatValue(value interface{}) string {
+	return formatValue(reflect.ValueOf(value))
+}
+
+// GetDefaultIn returns key of defaults
+func GetDefaultIn(defaults Defaults, key string) (interface{}, error) {
+	f, err := lookupField(key)
+	if err != nil {
+		return nil, err
+	}
+	return f.value(&defaults).Interface(), nil
+}
+
+// SetDefaultIn parses value for key, validates it and sets it in defaults
+func SetDefaultIn(defaults *Defaults, key, value string) error {
+	f, err := lookupField(key)
+	if err != nil {
+		return err
+	}
+	parsed, err := f.parse(value)
+	if err != nil {
+		return errdefs.Invalid("%s: %s", f.key, err)
+	}
+	if err := f.check(parsed); err != nil {
+		return errdefs.Invalid("%s: %s", f.key, err)
+	}
+	f.value(defaults).Set(parsed)
+	return nil
+}
+
+// AppendDefaultIn appends the comma separated items of value to the list
+// key of defaults, skipping the ones already there
+func AppendDefaultIn(defaults *Defaults, key, value string) error {
+	f, err := lookupField(key)
+	if err != nil {
+		return err
+	}
+	if f.typ.Kind() != reflect.Slice {
+		return errdefs.Invalid("%s is not a list, its type is %s", f.key, typeName(f.typ))
+	}
+	parsed, err := f.parse(value)
+	if err != nil {
+		return errdefs.Invalid("%s: %s", f.key, err)
+	}
+	if err := f.check(parsed); err != nil {
+		return errdefs.Invalid("%s: %s", f.key, err)
+	}
+
+	list := f.value(defaults)
+	for i := range parsed.Len() {
+		item := parsed.Index(i)
+		if !containsValue(list, item) {
+			list.Set(reflect.Append(list, item))
+		}
+	}
+	return nil
+}
+
+// containsValue reports whether the list holds item
+func containsValue(list, item reflect.Value) bool {
+	for i := range list.Len() {
+		if list.Index(i).Equal(item) {
+			return true
+		}
+	}
+	return false
+}
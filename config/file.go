@@ -0,0 +1,449 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// LockTimeout bounds how long a mutation waits for another blitzctl process
+// to finish updating the configuration file
+var LockTimeout = 30 * time.Second
+
+// lockRetryInterval is how often a held lock is retried
+const lockRetryInterval = 50 * time.Millisecond
+
+// configFilePath returns the file mutations are written to: the top file in
+// use, or ~/.blitzctl/config.yaml when none was found
+func (m *Manager) configFilePath() (string, error) {
+	if configFile := m.configFileUsed(); configFile != "" {
+		return configFile, nil
+	}
+	return GlobalConfigFile()
+}
+
+// GlobalConfigFile returns the user configuration file, ~/.blitzctl/config.yaml
+func GlobalConfigFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ConfigDirName, ConfigFileName+"."+ConfigFileType), nil
+}
+
+// LocalConfigFile returns the project configuration file, ./.blitzctl/config.yaml
+func LocalConfigFile() (string, error) {
+	dir, err := ProjectConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ConfigFileName+"."+ConfigFileType), nil
+}
+
+// lockFile takes an exclusive lock on path, through path.lock so the lock
+// survives the config file being replaced, and returns the function
+// releasing it
+func lockFile(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to create %s", filepath.Dir(path))
+	}
+
+	lockPath := path + ".lock"
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to open %s", lockPath)
+	}
+
+	deadline := time.Now().Add(LockTimeout)
+	for {
+		err := tryLock(f)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, errLocked) {
+			_ = f.Close()
+			return nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to lock %s", lockPath)
+		}
+		if time.Now().After(deadline) {
+			_ = f.Close()
+			return nil, errdefs.New(errdefs.KindConfig, "timed out after %s waiting for another blitzctl to release %s", LockTimeout, lockPath)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+
+	// Closing the file releases the lock as well, a failure to unlock first
+	// leaves nothing behind
+	return func() {
+		_ = unlock(f)
+		_ = f.Close()
+	}, nil
+}
+
+// reload replaces the in-memory configuration with the content of the
+// configuration files, each overriding the ones before it, picking up
+// changes made by other processes. Missing files are skipped, with none the
+// default configuration is used. The caller holds m.mu.
+func (m *Manager) reload() error {
+	config := GetDefaultConfig()
+	for i := range m.files {
+		v, err := readConfigFile(m.files[i].path)
+		if err != nil {
+			return err
+		}
+		if v != nil {
+			if err := v.Unmarshal(config); err != nil {
+				return errdefs.Wrap(errdefs.KindConfig, err, "failed to unmarshal %s", m.files[i].path)
+			}
+		}
+		m.files[i].v = v
+	}
+
+	m.config = config
+	return nil
+}
+
+// readConfigFile reads the configuration file at path, nil when it doesn't
+// exist
+func readConfigFile(path string) (*viper.Viper, error) {
+	v := newViper()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to read %s", path)
+	}
+	return v, nil
+}
+
+// loadFile returns the configuration of the file at path alone over the
+// built-in defaults
+func loadFile(path string) (*Config, error) {
+	config := GetDefaultConfig()
+	v, err := readConfigFile(path)
+	if err != nil || v == nil {
+		return config, err
+	}
+	if err := v.Unmarshal(config); err != nil {
+		return nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to unmarshal %s", path)
+	}
+	return config, nil
+}
+
+// readDoc parses the configuration file at path as a YAML node tree, a
+// missing or empty file yields a document holding only the apiVersion
+func readDoc(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to read %s", path)
+	}
+
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to parse %s", path)
+	}
+	if len(doc.Content) == 0 {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, errdefs.New(errdefs.KindConfig, "%s is not a mapping of configuration keys", path)
+	}
+	if mappingValue(root, "apiVersion") == nil {
+		setAPIVersion(root, CurrentAPIVersion)
+	}
+	return doc, nil
+}
+
+// writeDoc writes the YAML node tree doc to path atomically, the caller
+// holds the file lock
+func writeDoc(path string, doc *yaml.Node) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return errdefs.Wrap(errdefs.KindConfig, err, "failed to encode configuration")
+	}
+	if err := encoder.Close(); err != nil {
+		return errdefs.Wrap(errdefs.KindConfig, err, "failed to encode configuration")
+	}
+
+	if err := writeFileAtomic(path, buf.Bytes()); err != nil {
+		return errdefs.Wrap(errdefs.KindConfig, err, "failed to write %s", path)
+	}
+	return nil
+}
+
+// applyChanges edits doc with what differs between the configurations
+// before and after a mutation, plus the defaults keys given whatever their
+// value. Keys the mutation didn't touch are left as the file has them, so
+// built-in values are never written out.
+func applyChanges(doc *yaml.Node, before, after *Config, keys []string) error {
+	beforeDoc, err := toDoc(before)
+	if err != nil {
+		return err
+	}
+	afterDoc, err := toDoc(after)
+	if err != nil {
+		return err
+	}
+
+	root := doc.Content[0]
+	// Zero values are left out of the documents, a key set to one must not
+	// be removed before it is written in place
+	keep := map[string]bool{}
+	for _, key := range keys {
+		keep["defaults."+normalizeKey(key)] = true
+	}
+	if err := diffDoc(root, nil, beforeDoc, afterDoc, keep); err != nil {
+		return err
+	}
+	return setKeys(root, []string{"defaults"}, &after.Defaults, keys)
+}
+
+// setKeys writes the keys given of defaults under the mapping path of root,
+// zero values included
+func setKeys(root *yaml.Node, path []string, defaults *Defaults, keys []string) error {
+	for _, key := range keys {
+		f, err := lookupField(key)
+		if err != nil {
+			return err
+		}
+		value := &yaml.Node{}
+		if err := value.Encode(f.value(defaults).Interface()); err != nil {
+			return errdefs.Wrap(errdefs.KindConfig, err, "failed to encode %s", f.key)
+		}
+		setPath(root, append(slices.Clone(path), strings.Split(f.key, ".")...), value)
+	}
+	return nil
+}
+
+// toDoc returns config as the generic document it is written as
+func toDoc(config *Config) (map[string]any, error) {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to encode configuration")
+	}
+	doc := map[string]any{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to encode configuration")
+	}
+	return doc, nil
+}
+
+// diffDoc sets the values of after that differ from before in the mapping
+// node at path, descending into nested mappings, and removes those after
+// dropped, except the keys to keep
+func diffDoc(node *yaml.Node, path []string, before, after map[string]any, keep map[string]bool) error {
+	keys := slices.Sorted(maps.Keys(after))
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		keyPath := append(slices.Clone(path), key)
+		value, ok := after[key]
+		if !ok {
+			if !keep[strings.Join(keyPath, ".")] {
+				deletePath(node, keyPath)
+			}
+			continue
+		}
+		previous := before[key]
+		if reflect.DeepEqual(previous, value) {
+			continue
+		}
+
+		previousMap, previousIsMap := previous.(map[string]any)
+		valueMap, valueIsMap := value.(map[string]any)
+		if previousIsMap && valueIsMap {
+			if err := diffDoc(node, keyPath, previousMap, valueMap, keep); err != nil {
+				return err
+			}
+			continue
+		}
+
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(value); err != nil {
+			return errdefs.Wrap(errdefs.KindConfig, err, "failed to encode %s", strings.Join(keyPath, "."))
+		}
+		setPath(node, keyPath, valueNode)
+	}
+	return nil
+}
+
+// mappingValue returns the value of key in the mapping node, nil when the
+// key isn't there
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setPath sets the value at path under the mapping node, creating the
+// mappings along the way. A replaced value keeps its comments.
+func setPath(mapping *yaml.Node, path []string, value *yaml.Node) {
+	for i, key := range path {
+		child := mappingValue(mapping, key)
+		if i == len(path)-1 {
+			if child != nil {
+				value.HeadComment, value.LineComment, value.FootComment = child.HeadComment, child.LineComment, child.FootComment
+				*child = *value
+				return
+			}
+			mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+			return
+		}
+
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+		} else if child.Kind != yaml.MappingNode {
+			*child = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", HeadComment: child.HeadComment, LineComment: child.LineComment}
+		}
+		mapping = child
+	}
+}
+
+// deletePath removes the value at path under the mapping node, along with
+// the mappings it leaves empty
+func deletePath(mapping *yaml.Node, path []string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != path[0] {
+			continue
+		}
+		child := mapping.Content[i+1]
+		if len(path) > 1 {
+			if child.Kind != yaml.MappingNode {
+				return
+			}
+			deletePath(child, path[1:])
+			if len(child.Content) > 0 {
+				return
+			}
+		}
+		mapping.Content = slices.Delete(mapping.Content, i, i+2)
+		return
+	}
+}
+
+// ReadFile returns the path of the file mutations are written to and its
+// content, upgraded to CurrentAPIVersion first. A missing file has no content.
+func (m *Manager) ReadFile() (string, []byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.configFilePath()
+	if err != nil {
+		return "", nil, err
+	}
+	release, err := lockFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer release()
+
+	if err := m.migrateFile(path); err != nil {
+		return "", nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to read %s", path)
+	}
+	return path, data, nil
+}
+
+// ReplaceFile validates data and writes it as the configuration file, as is
+// so comments and ordering survive. original is the content returned by
+// ReadFile, the file is left alone when another process changed it since.
+func (m *Manager) ReplaceFile(original, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.configFilePath()
+	if err != nil {
+		return err
+	}
+	release, err := lockFile(path)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	current, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errdefs.Wrap(errdefs.KindConfig, err, "failed to read %s", path)
+	}
+	if !bytes.Equal(current, original) {
+		return errdefs.New(errdefs.KindConfig, "%s was changed by another process while it was edited", path)
+	}
+
+	if err := ValidateData(path, data); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return errdefs.Wrap(errdefs.KindConfig, err, "failed to write %s", path)
+	}
+	return m.reload()
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it over path, so readers never see a partially written file. The mode of
+// an existing file is kept.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	// Both fail harmlessly once the file is closed and renamed
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+	}()
+
+	mode := fs.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := f.Chmod(mode); err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		return err
+	}
+	// Flush before the rename so a crash never exposes an empty file
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,303 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readYAML returns the configuration file at path as a generic document
+func readYAML(t *testing.T, path string) map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := map[string]any{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("parse %s: %v", path, err)
+	}
+	return doc
+}
+
+// fileDefaults returns the defaults section of the configuration file at path
+func fileDefaults(t *testing.T, path string) map[string]any {
+	t.Helper()
+	defaults, _ := readYAML(t, path)["defaults"].(map[string]any)
+	return defaults
+}
+
+func TestSetWritesOnlyTheKeySet(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		want  map[string]any
+	}{
+		{name: "string", key: "driver", value: "docker", want: map[string]any{"driver": "docker"}},
+		{name: "built-in value", key: "cni", value: GetDefaultConfig().Defaults.CNI, want: map[string]any{"cni": GetDefaultConfig().Defaults.CNI}},
+		{name: "zero value", key: "wait", value: "false", want: map[string]any{"wait": false}},
+		{name: "nested key", key: "resources.cpus", value: "4", want: map[string]any{"resources": map[string]any{"cpus": 4}}},
+		{name: "dashed key", key: "k8s-version", value: "v1.34.0", want: map[string]any{"k8s_version": "1.34.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			m, err := OpenFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := m.SetDefault(tt.key, tt.value); err != nil {
+				t.Fatalf("SetDefault() error = %v", err)
+			}
+
+			doc := readYAML(t, path)
+			if doc["apiVersion"] != CurrentAPIVersion {
+				t.Errorf("apiVersion = %v, want %s", doc["apiVersion"], CurrentAPIVersion)
+			}
+			if len(doc) != 2 {
+				t.Errorf("file has keys %v, want only apiVersion and defaults", doc)
+			}
+			got := fileDefaults(t, path)
+			if !equalDoc(got, tt.want) {
+				t.Errorf("defaults = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// equalDoc compares generic documents, yaml.v3 decodes integers as int
+func equalDoc(a, b map[string]any) bool {
+	ya, errA := yaml.Marshal(a)
+	yb, errB := yaml.Marshal(b)
+	return errA == nil && errB == nil && string(ya) == string(yb)
+}
+
+func TestSetThenUnset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	m, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.SetDefault("nodes", "3"); err != nil {
+		t.Fatalf("SetDefault() error = %v", err)
+	}
+	if err := m.SetDefault("driver", "docker"); err != nil {
+		t.Fatalf("SetDefault() error = %v", err)
+	}
+	if got := m.GetConfig().Defaults.Nodes; got != 3 {
+		t.Fatalf("nodes = %d after set, want 3", got)
+	}
+
+	if err := m.UnsetDefault("nodes"); err != nil {
+		t.Fatalf("UnsetDefault() error = %v", err)
+	}
+	defaults := fileDefaults(t, path)
+	if _, ok := defaults["nodes"]; ok {
+		t.Errorf("nodes still in the file after unset: %v", defaults)
+	}
+	if defaults["driver"] != "docker" {
+		t.Errorf("driver = %v after unsetting nodes, want docker", defaults["driver"])
+	}
+	if got, want := m.GetConfig().Defaults.Nodes, GetDefaultConfig().Defaults.Nodes; got != want {
+		t.Errorf("nodes = %d after unset, want the built-in %d", got, want)
+	}
+
+	// The last key takes the defaults section with it
+	if err := m.UnsetDefault("driver"); err != nil {
+		t.Fatalf("UnsetDefault() error = %v", err)
+	}
+	if _, ok := readYAML(t, path)["defaults"]; ok {
+		t.Errorf("empty defaults section left in the file")
+	}
+
+	// Unsetting a key the file doesn't have is a no-op
+	if err := m.UnsetDefault("cni"); err != nil {
+		t.Errorf("UnsetDefault() of a missing key error = %v", err)
+	}
+	if err := m.UnsetDefault("no_such_key"); err == nil {
+		t.Errorf("UnsetDefault() of an unknown key succeeded")
+	}
+}
+
+func TestUpdateKeepsCommentsAndUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := `# team settings
+apiVersion: blitzctl/v1
+defaults:
+  driver: podman # rootless
+  nodes: 2
+  futureKey: kept
+`
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetDefault("driver", "docker"); err != nil {
+		t.Fatalf("SetDefault() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for _, want := range []string{"# team settings", "driver: docker # rootless", "nodes: 2", "futureKey: kept"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("file lost %q:\n%s", want, content)
+		}
+	}
+	if strings.Index(content, "driver:") > strings.Index(content, "nodes:") {
+		t.Errorf("driver moved after nodes:\n%s", content)
+	}
+}
+
+func TestLocalAndGlobalFilesMerge(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(ConfigEnvVar, "")
+	t.Setenv(ProfileEnvVar, "")
+	t.Chdir(project)
+
+	globalPath, err := GlobalConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	localPath, err := LocalConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	global, err := OpenFile(globalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := global.SetDefaults(map[string]string{"helm_version": "3.19.0", "nodes": "2"}); err != nil {
+		t.Fatalf("SetDefaults() error = %v", err)
+	}
+	local, err := OpenFile(localPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := local.SetDefault("nodes", "3"); err != nil {
+		t.Fatalf("SetDefault() error = %v", err)
+	}
+
+	if defaults := fileDefaults(t, localPath); len(defaults) != 1 {
+		t.Errorf("local file defaults = %v, want only nodes", defaults)
+	}
+
+	tests := []struct {
+		key      string
+		want     string
+		wantFile string
+	}{
+		{key: "helm_version", want: "3.19.0", wantFile: globalPath},
+		{key: "nodes", want: "3", wantFile: localPath},
+		{key: "driver", want: GetDefaultConfig().Defaults.Driver},
+	}
+
+	m := NewManager()
+	if err := m.Initialize(""); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if got := m.GetConfigFilePath(); got != localPath {
+		t.Errorf("GetConfigFilePath() = %q, want the project file %q", got, localPath)
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			value, err := m.GetDefault(tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := FormatValue(value); got != tt.want {
+				t.Errorf("%s = %s, want %s", tt.key, got, tt.want)
+			}
+
+			layers, err := m.Explain(tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, layer := range layers {
+				if layer.Source != SourceFile {
+					continue
+				}
+				if layer.Set != (tt.wantFile != "") || (layer.Set && layer.Name != tt.wantFile) {
+					t.Errorf("file layer = %s (set %v), want %q", layer.Name, layer.Set, tt.wantFile)
+				}
+			}
+		})
+	}
+
+	// Unsetting the project key uncovers the user one
+	if err := m.UnsetDefault("nodes"); err != nil {
+		t.Fatalf("UnsetDefault() error = %v", err)
+	}
+	if got := m.GetDefaults().Nodes; got != 2 {
+		t.Errorf("nodes = %d after unsetting the project value, want the user 2", got)
+	}
+}
+
+func TestAppendStartsFromTheMergedList(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(ConfigEnvVar, "")
+	t.Setenv(ProfileEnvVar, "")
+	t.Chdir(t.TempDir())
+
+	globalPath, err := GlobalConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	localPath, err := LocalConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	global, err := OpenFile(globalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := global.SetDefault("addons", "metrics-server"); err != nil {
+		t.Fatal(err)
+	}
+	// The project file exists, so it is the one written
+	local, err := OpenFile(localPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := local.SetDefault("nodes", "2"); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager()
+	if err := m.Initialize(""); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	for _, value := range []string{"dashboard", "metrics-server,dashboard"} {
+		if err := m.AppendDefault("addons", value); err != nil {
+			t.Fatalf("AppendDefault(%s) error = %v", value, err)
+		}
+	}
+
+	want := "metrics-server,dashboard"
+	if got := FormatValue(m.GetDefaults().Addons); got != want {
+		t.Errorf("addons = %s, want %s", got, want)
+	}
+	if got := fileDefaults(t, localPath)["addons"]; fmt.Sprint(got) != "[metrics-server dashboard]" {
+		t.Errorf("project file addons = %v, want the user list and dashboard", got)
+	}
+	if got := fileDefaults(t, globalPath)["addons"]; fmt.Sprint(got) != "[metrics-server]" {
+		t.Errorf("user file addons = %v, want it unchanged", got)
+	}
+}
@@ -0,0 +1,23 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"errors"
+	"os"
+)
+
+// errLocked is returned by tryLock when another process holds the lock
+var errLocked = errors.New("locked")
+
+// tryLock is a no-op on Windows, which blitzctl doesn't support. Writes are
+// still atomic, concurrent updates may be lost.
+func tryLock(f *os.File) error {
+	return nil
+}
+
+// unlock releases the lock taken by tryLock
+func unlock(f *os.File) error {
+	return nil
+}
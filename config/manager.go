@@ -4,11 +4,21 @@ Copyright © 2025 Oneide Luiz Schneider
 package config
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -22,80 +32,265 @@ const (
 	EnvPrefix = "BLITZCTL"
 )
 
-// Manager handles configuration management using Viper
+// Manager handles configuration management using Viper. The configuration
+// files found are layered, the project file over the user file over the
+// system one, and mutations go to the top file. Mutations reload it under
+// an exclusive lock and write it atomically, so concurrent blitzctl
+// processes don't lose each other's updates. Clusters and the current
+// context are kept in a separate StateStore.
 type Manager struct {
-	viper  *viper.Viper
+	mu sync.Mutex
+	// files are the configuration files in use, lowest precedence first
+	files  []configFile
 	config *Config
+	state  StateStore
+	// profile overrides Config.Profile, set from --profile
+	profile string
+}
+
+// configFile is a configuration file in use, v is nil until it exists
+type configFile struct {
+	path string
+	v    *viper.Viper
 }
 
 // NewManager creates a new configuration manager
 func NewManager() *Manager {
-	v := viper.New()
+	return &Manager{
+		config: GetDefaultConfig(),
+	}
+}
+
+// newViper returns a viper instance reading blitzctl configuration files
+func newViper() *viper.Viper {
+	v := viper.NewWithOptions(viper.WithDecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.TextUnmarshallerHookFunc(),
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	)))
 	v.SetConfigName(ConfigFileName)
 	v.SetConfigType(ConfigFileType)
 	v.SetEnvPrefix(EnvPrefix)
 	v.AutomaticEnv()
+	return v
+}
 
-	return &Manager{
-		viper:  v,
-		config: GetDefaultConfig(),
+// OpenFile returns a manager reading and writing the configuration file at
+// path alone, which doesn't need to exist yet
+func OpenFile(path string) (*Manager, error) {
+	m := NewManager()
+	m.files = []configFile{{path: path}}
+	if err := m.reload(); err != nil {
+		return nil, err
 	}
+	return m, nil
 }
 
 // Initialize sets up the configuration manager with search paths and loads config
 func (m *Manager) Initialize(configPath string) error {
-	// Set custom config path if provided
-	if configPath != "" {
-		m.viper.SetConfigFile(configPath)
-	} else {
-		// Add configuration search paths in priority order
-		m.addConfigPaths()
+	paths, err := configPaths(configPath)
+	if err != nil {
+		return err
 	}
 
 	// Load configuration
-	if err := m.loadConfig(); err != nil {
+	if len(paths) == 0 {
+		// Config file not found, use defaults and create directory structure
+		if err := m.ensureConfigDir(); err != nil {
+			return fmt.Errorf("failed to create config directory: %w", err)
+		}
+	}
+	m.files = make([]configFile, len(paths))
+	for i, path := range paths {
+		m.files[i].path = path
+	}
+	if err := m.reload(); err != nil {
+		return err
+	}
+
+	if err := validateEnv(); err != nil {
 		return err
 	}
 
+	if name := os.Getenv(ProfileEnvVar); name != "" {
+		if err := m.SetProfile(name); err != nil {
+			return err
+		}
+	}
+
+	// Keys of a file written by an older version are reported once it is
+	// migrated
+	m.warnUnknownKeys()
 	return nil
 }
 
-// addConfigPaths adds configuration search paths
-func (m *Manager) addConfigPaths() {
-	// Current directory (./.blitzctl)
-	if pwd, err := os.Getwd(); err == nil {
-		m.viper.AddConfigPath(filepath.Join(pwd, ConfigDirName))
+// configPaths returns the configuration files to load, lowest precedence
+// first: configPath or $BLITZCTL_CONFIG alone when set, which has to exist,
+// otherwise those of /etc/blitzctl, ~/.blitzctl and ./.blitzctl that exist
+func configPaths(configPath string) ([]string, error) {
+	if configPath == "" {
+		configPath = os.Getenv(ConfigEnvVar)
 	}
 
-	// User home directory (~/.blitzctl)
-	if home, err := os.UserHomeDir(); err == nil {
-		m.viper.AddConfigPath(filepath.Join(home, ConfigDirName))
+	// Set custom config path if provided
+	if configPath != "" {
+		if _, err := os.Stat(configPath); err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		return []string{configPath}, nil
 	}
 
-	// System-wide configuration (optional)
-	m.viper.AddConfigPath("/etc/blitzctl/")
+	var paths []string
+	for _, dir := range configDirs() {
+		path := filepath.Join(dir, ConfigFileName+"."+ConfigFileType)
+		if info, err := os.Stat(path); err != nil || info.IsDir() || slices.Contains(paths, path) {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// NeedsMigration reports whether a loaded configuration file was written by
+// an older version
+func (m *Manager) NeedsMigration() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.outdatedFiles()) > 0
+}
+
+// outdatedFiles returns the paths of the loaded configuration files written
+// by an older version. The caller holds m.mu.
+func (m *Manager) outdatedFiles() []string {
+	var paths []string
+	for _, file := range m.files {
+		if file.v != nil && file.v.GetString("apiVersion") != CurrentAPIVersion {
+			paths = append(paths, file.path)
+		}
+	}
+	return paths
+}
+
+// Migrate upgrades the loaded configuration files written by an older
+// version. They are rewritten so this happens once.
+func (m *Manager) Migrate() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	paths := m.outdatedFiles()
+	if len(paths) == 0 {
+		return nil
+	}
+	var problems []error
+	for _, path := range paths {
+		if err := m.migrateLocked(path); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if err := m.reload(); err != nil {
+		problems = append(problems, err)
+	}
+	for _, path := range paths {
+		warnUnknownKeys(path)
+	}
+	return errors.Join(problems...)
 }
 
-// loadConfig loads the configuration from file or creates default
-func (m *Manager) loadConfig() error {
-	// Try to read existing config
-	if err := m.viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			// Config file not found, use defaults and create directory structure
-			if err := m.ensureConfigDir(); err != nil {
-				return fmt.Errorf("failed to create config directory: %w", err)
+// migrateLocked migrates the configuration file at path under its lock
+func (m *Manager) migrateLocked(path string) error {
+	release, err := lockFile(path)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return m.migrateFile(path)
+}
+
+// warnUnknownKeys logs the keys of the loaded configuration files the
+// schema doesn't define, skipping those still to be migrated
+func (m *Manager) warnUnknownKeys() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, file := range m.files {
+		if file.v != nil && file.v.GetString("apiVersion") == CurrentAPIVersion {
+			warnUnknownKeys(file.path)
+		}
+	}
+}
+
+// warnUnknownKeys logs the keys of the configuration file at path the
+// schema doesn't define
+func warnUnknownKeys(path string) {
+	if data, err := os.ReadFile(path); err == nil {
+		for _, problem := range UnknownKeys(data) {
+			slog.Warn("Ignoring unknown configuration key", "file", path, "problem", problem)
+		}
+	}
+}
+
+// FileStatus is a configuration file and the apiVersion it was written with
+type FileStatus struct {
+	Path       string
+	APIVersion string
+}
+
+// NeedsMigration reports whether the file was written by an older version
+func (f FileStatus) NeedsMigration() bool {
+	return f.APIVersion != CurrentAPIVersion
+}
+
+// CheckFiles finds the configuration files Initialize would load and checks
+// them without migrating or writing anything. A file of an older apiVersion
+// is only checked to parse, its values are validated once it is migrated.
+func CheckFiles(configPath string) ([]FileStatus, error) {
+	paths, err := configPaths(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []FileStatus
+	for _, path := range paths {
+		v, err := readConfigFile(path)
+		if err != nil {
+			return files, err
+		}
+		file := FileStatus{Path: path, APIVersion: v.GetString("apiVersion")}
+		files = append(files, file)
+		if file.NeedsMigration() {
+			if _, err := migrationStart(path, file.APIVersion); err != nil {
+				return files, err
 			}
-			return nil
+		} else if err := ValidateFile(path); err != nil {
+			return files, err
 		}
-		return fmt.Errorf("failed to read config file: %w", err)
 	}
+	return files, validateEnv()
+}
+
+// ProjectConfigDir returns the project-specific configuration directory (./.blitzctl)
+func ProjectConfigDir() (string, error) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return filepath.Join(pwd, ConfigDirName), nil
+}
+
+// configDirs returns the configuration search paths, lowest precedence first
+func configDirs() []string {
+	// System-wide configuration (optional)
+	dirs := []string{"/etc/blitzctl"}
 
-	// Unmarshal config into struct
-	if err := m.viper.Unmarshal(m.config); err != nil {
-		return fmt.Errorf("failed to unmarshal config: %w", err)
+	// User home directory (~/.blitzctl)
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ConfigDirName))
 	}
 
-	return nil
+	// Current directory (./.blitzctl)
+	if projectDir, err := ProjectConfigDir(); err == nil {
+		dirs = append(dirs, projectDir)
+	}
+	return dirs
 }
 
 // ensureConfigDir ensures the configuration directory exists
@@ -111,150 +306,392 @@ func (m *Manager) ensureConfigDir() error {
 
 // GetConfig returns the current configuration
 func (m *Manager) GetConfig() *Config {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.config
 }
 
-// GetDefaults returns the default values
+// GetDefaults returns the default values with the active profile applied
 func (m *Manager) GetDefaults() Defaults {
-	return m.config.Defaults
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.effectiveDefaults()
 }
 
-// SaveConfig saves the current configuration to file
+// SaveConfig saves the current configuration to file, overwriting changes
+// made by other processes since it was loaded. Values inherited from the
+// files below it are copied into it. The mutators below reload the file
+// first and should be preferred.
 func (m *Manager) SaveConfig() error {
-	// Update viper with current config values
-	m.viper.Set("defaults", m.config.Defaults)
-	m.viper.Set("clusters", m.config.Clusters)
-	if m.config.CurrentContext != nil {
-		m.viper.Set("current_context", m.config.CurrentContext)
-	}
+	current := m.GetConfig()
+	return m.update(func(config *Config) error {
+		*config = *current
+		return nil
+	})
+}
 
-	// Determine config file path
-	configFile := m.viper.ConfigFileUsed()
-	if configFile == "" {
-		home, err := os.UserHomeDir()
+// update applies mutate to the top configuration file freshly read from
+// disk and saves what it changed, along with the defaults keys given, which
+// are written even when their value didn't change. The file lock is held
+// throughout.
+func (m *Manager) update(mutate func(config *Config) error, keys ...string) error {
+	return m.edit(func(path string, doc *yaml.Node) error {
+		before, err := loadFile(path)
 		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
+			return err
 		}
-		configDir := filepath.Join(home, ConfigDirName)
-		if err := os.MkdirAll(configDir, 0755); err != nil {
-			return fmt.Errorf("failed to create config directory: %w", err)
+		after, err := loadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := mutate(after); err != nil {
+			return err
 		}
-		configFile = filepath.Join(configDir, ConfigFileName+"."+ConfigFileType)
+		return applyChanges(doc, before, after, keys)
+	})
+}
+
+// edit applies change to the YAML document of the top configuration file
+// and writes it, holding the file lock throughout. Comments, ordering and
+// keys the schema doesn't know survive.
+func (m *Manager) edit(change func(path string, doc *yaml.Node) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.configFilePath()
+	if err != nil {
+		return err
+	}
+	release, err := lockFile(path)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := m.migrateFile(path); err != nil {
+		return err
+	}
+	doc, err := readDoc(path)
+	if err != nil {
+		return err
+	}
+	if err := change(path, doc); err != nil {
+		return err
+	}
+	if err := writeDoc(path, doc); err != nil {
+		return err
 	}
 
-	return m.viper.WriteConfigAs(configFile)
+	// A file created by this update joins the files in use
+	if len(m.files) == 0 {
+		m.files = []configFile{{path: path}}
+	}
+	// Pick up the keys the file now holds
+	return m.reload()
 }
 
-// SetDefault sets a default configuration value
-func (m *Manager) SetDefault(key string, value interface{}) error {
-	switch key {
-	case "k8s_version", "k8s-version":
-		m.config.Defaults.K8sVersion = value.(string)
-	case "driver":
-		m.config.Defaults.Driver = value.(string)
-	case "cluster_name", "cluster-name":
-		m.config.Defaults.ClusterName = value.(string)
-	case "cni":
-		m.config.Defaults.CNI = value.(string)
-	case "helm_version", "helm-version":
-		m.config.Defaults.HelmVersion = value.(string)
-	default:
-		return fmt.Errorf("unknown configuration key: %s", key)
+// SetDefault parses and sets a default configuration value. Profiles
+// still override it.
+func (m *Manager) SetDefault(key, value string) error {
+	f, err := lookupField(key)
+	if err != nil {
+		return err
 	}
+	return m.update(func(config *Config) error {
+		return SetDefaultIn(&config.Defaults, key, value)
+	}, f.key)
+}
 
-	return m.SaveConfig()
+// SetDefaults parses and sets several default values at once, nothing is
+// saved unless every value is valid
+func (m *Manager) SetDefaults(values map[string]string) error {
+	var keys []string
+	for key := range values {
+		f, err := lookupField(key)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, f.key)
+	}
+	return m.update(func(config *Config) error {
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			if err := SetDefaultIn(&config.Defaults, key, values[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, keys...)
+}
+
+// AppendDefault appends the comma separated items of value to a list
+// default. The list starts from the value the files give together, so the
+// items of the files below the top one are kept.
+func (m *Manager) AppendDefault(key, value string) error {
+	f, err := lookupField(key)
+	if err != nil {
+		return err
+	}
+	return m.update(func(config *Config) error {
+		if err := m.reload(); err != nil {
+			return err
+		}
+		if merged := f.value(&m.config.Defaults); merged.Kind() == reflect.Slice {
+			list := reflect.AppendSlice(reflect.MakeSlice(merged.Type(), 0, merged.Len()), merged)
+			f.value(&config.Defaults).Set(list)
+		}
+		return AppendDefaultIn(&config.Defaults, key, value)
+	}, f.key)
 }
 
-// GetDefault gets a default configuration value
+// UnsetDefault removes a default configuration value from the file, the
+// files below it or the built-in value apply again
+func (m *Manager) UnsetDefault(key string) error {
+	f, err := lookupField(key)
+	if err != nil {
+		return err
+	}
+	return m.edit(func(path string, doc *yaml.Node) error {
+		deletePath(doc.Content[0], append([]string{"defaults"}, strings.Split(f.key, ".")...))
+		return nil
+	})
+}
+
+// GetDefault gets a default configuration value, resolved through every layer
 func (m *Manager) GetDefault(key string) (interface{}, error) {
-	switch key {
-	case "k8s_version", "k8s-version":
-		return m.config.Defaults.K8sVersion, nil
-	case "driver":
-		return m.config.Defaults.Driver, nil
-	case "cluster_name", "cluster-name":
-		return m.config.Defaults.ClusterName, nil
-	case "cni":
-		return m.config.Defaults.CNI, nil
-	case "helm_version", "helm-version":
-		return m.config.Defaults.HelmVersion, nil
-	default:
-		return nil, fmt.Errorf("unknown configuration key: %s", key)
-	}
-}
-
-// AddCluster adds a cluster to the configuration
-func (m *Manager) AddCluster(cluster ClusterInfo) error {
-	// Check if cluster already exists
-	for i, existing := range m.config.Clusters {
-		if existing.Name == cluster.Name && existing.Provider == cluster.Provider {
-			// Update existing cluster
-			m.config.Clusters[i] = cluster
-			return m.SaveConfig()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return GetDefaultIn(m.effectiveDefaults(), key)
+}
+
+// SetStateStore replaces where clusters and the current context are kept
+func (m *Manager) SetStateStore(store StateStore) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = store
+}
+
+// StateStore returns where clusters and the current context are kept
+func (m *Manager) StateStore() (StateStore, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stateStore()
+}
+
+// stateStore returns the state store, defaulting to the XDG state file. The
+// caller holds m.mu.
+func (m *Manager) stateStore() (StateStore, error) {
+	if m.state == nil {
+		store, err := DefaultStateStore()
+		if err != nil {
+			return nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to locate the state file")
 		}
+		m.state = store
 	}
+	return m.state, nil
+}
+
+// loadState returns the current machine state
+func (m *Manager) loadState() (*State, error) {
+	store, err := m.StateStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.Load()
+}
 
-	// Add new cluster
-	m.config.Clusters = append(m.config.Clusters, cluster)
-	return m.SaveConfig()
+// updateState applies mutate to the machine state and saves it
+func (m *Manager) updateState(mutate func(state *State) error) error {
+	store, err := m.StateStore()
+	if err != nil {
+		return err
+	}
+	return store.Update(mutate)
 }
 
-// RemoveCluster removes a cluster from the configuration
+// AddCluster adds a cluster to the state, replacing an existing cluster
+// with the same name and provider
+func (m *Manager) AddCluster(cluster ClusterInfo) error {
+	return m.updateState(func(state *State) error {
+		if i := findCluster(state.Clusters, cluster.Name, cluster.Provider); i >= 0 {
+			state.Clusters[i] = cluster
+			return nil
+		}
+		state.Clusters = append(state.Clusters, cluster)
+		return nil
+	})
+}
+
+// UpdateCluster applies mutate to a tracked cluster and saves it
+func (m *Manager) UpdateCluster(name, provider string, mutate func(cluster *ClusterInfo) error) error {
+	return m.updateState(func(state *State) error {
+		i := findCluster(state.Clusters, name, provider)
+		if i < 0 {
+			return errdefs.NotFound("cluster %s (%s) not found", name, provider)
+		}
+		return mutate(&state.Clusters[i])
+	})
+}
+
+// RemoveCluster removes a cluster from the state
 func (m *Manager) RemoveCluster(name, provider string) error {
-	for i, cluster := range m.config.Clusters {
-		if cluster.Name == name && cluster.Provider == provider {
-			// Remove cluster
-			m.config.Clusters = append(m.config.Clusters[:i], m.config.Clusters[i+1:]...)
-
-			// Clear current context if it was pointing to this cluster
-			if m.config.CurrentContext != nil &&
-				m.config.CurrentContext.Cluster == name &&
-				m.config.CurrentContext.Provider == provider {
-				m.config.CurrentContext = nil
-			}
+	return m.updateState(func(state *State) error {
+		i := findCluster(state.Clusters, name, provider)
+		if i < 0 {
+			return errdefs.NotFound("cluster %s (%s) not found", name, provider)
+		}
+		state.Clusters = slices.Delete(state.Clusters, i, i+1)
 
-			return m.SaveConfig()
+		// Clear current context if it was pointing to this cluster
+		if state.CurrentContext != nil &&
+			state.CurrentContext.Cluster == name &&
+			state.CurrentContext.Provider == provider {
+			state.CurrentContext = nil
 		}
-	}
-	return fmt.Errorf("cluster %s (%s) not found", name, provider)
+		return nil
+	})
 }
 
 // GetCluster gets a cluster by name and provider
 func (m *Manager) GetCluster(name, provider string) (*ClusterInfo, error) {
-	for _, cluster := range m.config.Clusters {
-		if cluster.Name == name && cluster.Provider == provider {
-			return &cluster, nil
-		}
+	state, err := m.loadState()
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("cluster %s (%s) not found", name, provider)
+
+	i := findCluster(state.Clusters, name, provider)
+	if i < 0 {
+		return nil, errdefs.NotFound("cluster %s (%s) not found", name, provider)
+	}
+	return &state.Clusters[i], nil
+}
+
+// findCluster returns the index of a cluster in clusters, -1 if it isn't there
+func findCluster(clusters []ClusterInfo, name, provider string) int {
+	return slices.IndexFunc(clusters, func(cluster ClusterInfo) bool {
+		return cluster.Name == name && cluster.Provider == provider
+	})
 }
 
-// ListClusters returns all configured clusters
+// ListClusters returns all tracked clusters
 func (m *Manager) ListClusters() []ClusterInfo {
-	return m.config.Clusters
+	state, err := m.loadState()
+	if err != nil {
+		slog.Warn("Failed to read cluster state", "error", err)
+		return nil
+	}
+	return state.Clusters
 }
 
-// SetCurrentContext sets the current active cluster context
-func (m *Manager) SetCurrentContext(clusterName, provider string) error {
-	// Verify cluster exists
-	if _, err := m.GetCluster(clusterName, provider); err != nil {
-		return err
+// AddSnapshot records a snapshot, replacing the one of the same cluster
+// with the same name
+func (m *Manager) AddSnapshot(snapshot SnapshotInfo) error {
+	return m.updateState(func(state *State) error {
+		if i := findSnapshot(state.Snapshots, snapshot.Cluster.Name, snapshot.Cluster.Provider, snapshot.Name); i >= 0 {
+			state.Snapshots[i] = snapshot
+			return nil
+		}
+		state.Snapshots = append(state.Snapshots, snapshot)
+		return nil
+	})
+}
+
+// GetSnapshot gets a snapshot of a cluster by name
+func (m *Manager) GetSnapshot(cluster, provider, name string) (*SnapshotInfo, error) {
+	state, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+
+	i := findSnapshot(state.Snapshots, cluster, provider, name)
+	if i < 0 {
+		return nil, errdefs.NotFound("snapshot %s of cluster %s (%s) not found", name, cluster, provider)
 	}
+	return &state.Snapshots[i], nil
+}
+
+// RemoveSnapshot removes a snapshot from the state
+func (m *Manager) RemoveSnapshot(cluster, provider, name string) error {
+	return m.updateState(func(state *State) error {
+		i := findSnapshot(state.Snapshots, cluster, provider, name)
+		if i < 0 {
+			return errdefs.NotFound("snapshot %s of cluster %s (%s) not found", name, cluster, provider)
+		}
+		state.Snapshots = slices.Delete(state.Snapshots, i, i+1)
+		return nil
+	})
+}
+
+// findSnapshot returns the index of a snapshot in snapshots, -1 if it isn't there
+func findSnapshot(snapshots []SnapshotInfo, cluster, provider, name string) int {
+	return slices.IndexFunc(snapshots, func(snapshot SnapshotInfo) bool {
+		return snapshot.Name == name && snapshot.Cluster.Name == cluster && snapshot.Cluster.Provider == provider
+	})
+}
 
-	m.config.CurrentContext = &CurrentContext{
-		Cluster:  clusterName,
-		Provider: provider,
+// ListSnapshots returns all recorded snapshots
+func (m *Manager) ListSnapshots() []SnapshotInfo {
+	state, err := m.loadState()
+	if err != nil {
+		slog.Warn("Failed to read cluster state", "error", err)
+		return nil
 	}
+	return state.Snapshots
+}
+
+// SetCurrentContext sets the current active cluster context
+func (m *Manager) SetCurrentContext(clusterName, provider string) error {
+	return m.updateState(func(state *State) error {
+		// Verify cluster exists
+		if findCluster(state.Clusters, clusterName, provider) < 0 {
+			return errdefs.NotFound("cluster %s (%s) not found", clusterName, provider)
+		}
 
-	return m.SaveConfig()
+		state.CurrentContext = &CurrentContext{
+			Cluster:  clusterName,
+			Provider: provider,
+		}
+		return nil
+	})
 }
 
 // GetCurrentContext returns the current active cluster context
 func (m *Manager) GetCurrentContext() *CurrentContext {
-	return m.config.CurrentContext
+	state, err := m.loadState()
+	if err != nil {
+		slog.Warn("Failed to read cluster state", "error", err)
+		return nil
+	}
+	return state.CurrentContext
 }
 
-// GetConfigFilePath returns the path to the configuration file being used
+// GetConfigFilePath returns the path to the configuration file being used,
+// the top one when several are layered
 func (m *Manager) GetConfigFilePath() string {
-	return m.viper.ConfigFileUsed()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.configFileUsed()
+}
+
+// GetConfigFilePaths returns the paths of the layered configuration files
+// that exist, lowest precedence first
+func (m *Manager) GetConfigFilePaths() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var paths []string
+	for _, file := range m.files {
+		if file.v != nil {
+			paths = append(paths, file.path)
+		}
+	}
+	return paths
+}
+
+// configFileUsed returns the path of the top configuration file, empty
+// when there is none. The caller holds m.mu.
+func (m *Manager) configFileUsed() string {
+	if len(m.files) == 0 {
+		return ""
+	}
+	return m.files[len(m.files)-1].path
 }
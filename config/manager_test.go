@@ -0,0 +1,168 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// Environment of the re-exec'd test binary running TestConcurrentWorker
+const (
+	workerEnv       = "BLITZCTL_TEST_WORKER"
+	workerConfigEnv = "BLITZCTL_TEST_WORKER_CONFIG"
+	workerStateEnv  = "BLITZCTL_TEST_WORKER_STATE"
+)
+
+// updatesPerWorker is how many profiles and clusters each worker adds
+const updatesPerWorker = 5
+
+// openWorker returns a manager of its own over the config and state files,
+// like a separate blitzctl process has
+func openWorker(configPath, statePath string) (*Manager, error) {
+	m, err := OpenFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	m.SetStateStore(NewFileStateStore(statePath))
+	return m, nil
+}
+
+// runWorker adds updatesPerWorker profiles through update and as many
+// clusters through AddCluster, all named after worker
+func runWorker(m *Manager, worker string) error {
+	for i := range updatesPerWorker {
+		name := fmt.Sprintf("%s-%d", worker, i)
+		err := m.update(func(config *Config) error {
+			if config.Profiles == nil {
+				config.Profiles = map[string]Defaults{}
+			}
+			config.Profiles[name] = Defaults{Nodes: i + 1}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("update %s: %w", name, err)
+		}
+		if err := m.AddCluster(ClusterInfo{Name: name, Provider: "kind"}); err != nil {
+			return fmt.Errorf("add cluster %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// TestConcurrentWorker is the body of the worker processes started by
+// TestConcurrentUpdates, it does nothing when run directly
+func TestConcurrentWorker(t *testing.T) {
+	worker := os.Getenv(workerEnv)
+	if worker == "" {
+		t.Skip("only runs as a worker process of TestConcurrentUpdates")
+	}
+	m, err := openWorker(os.Getenv(workerConfigEnv), os.Getenv(workerStateEnv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runWorker(m, worker); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConcurrentUpdates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts several processes")
+	}
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	statePath := filepath.Join(dir, "state.json")
+
+	var workers []string
+	var wg sync.WaitGroup
+	errs := make(chan error, 32)
+
+	// Managers of their own, locking the files against each other
+	for i := range 6 {
+		worker := "goroutine" + strconv.Itoa(i)
+		workers = append(workers, worker)
+		wg.Go(func() {
+			m, err := openWorker(configPath, statePath)
+			if err == nil {
+				err = runWorker(m, worker)
+			}
+			if err != nil {
+				errs <- fmt.Errorf("%s: %w", worker, err)
+			}
+		})
+	}
+
+	// One manager shared by goroutines, serialized by its mutex
+	shared, err := openWorker(configPath, statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 3 {
+		worker := "shared" + strconv.Itoa(i)
+		workers = append(workers, worker)
+		wg.Go(func() {
+			if err := runWorker(shared, worker); err != nil {
+				errs <- fmt.Errorf("%s: %w", worker, err)
+			}
+		})
+	}
+
+	// Other processes
+	for i := range 4 {
+		worker := "process" + strconv.Itoa(i)
+		workers = append(workers, worker)
+		cmd := exec.Command(os.Args[0], "-test.run=^TestConcurrentWorker$", "-test.count=1")
+		cmd.Env = append(os.Environ(),
+			workerEnv+"="+worker,
+			workerConfigEnv+"="+configPath,
+			workerStateEnv+"="+statePath,
+		)
+		wg.Go(func() {
+			if output, err := cmd.CombinedOutput(); err != nil {
+				errs <- fmt.Errorf("%s: %w\n%s", worker, err, output)
+			}
+		})
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if t.Failed() {
+		return
+	}
+
+	m, err := openWorker(configPath, statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := m.GetConfig()
+	clusters := m.ListClusters()
+	if want := len(workers) * updatesPerWorker; len(config.Profiles) != want || len(clusters) != want {
+		t.Errorf("got %d profiles and %d clusters, want %d of each", len(config.Profiles), len(clusters), want)
+	}
+	for _, worker := range workers {
+		for i := range updatesPerWorker {
+			name := fmt.Sprintf("%s-%d", worker, i)
+			if profile, ok := config.Profiles[name]; !ok || profile.Nodes != i+1 {
+				t.Errorf("profile %s lost, got %+v", name, profile)
+			}
+			if _, err := m.GetCluster(name, "kind"); err != nil {
+				t.Errorf("cluster %s lost: %v", name, err)
+			}
+		}
+	}
+
+	if err := ValidateFile(configPath); err != nil {
+		t.Errorf("config file left invalid: %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentAPIVersion is the schema version of the configuration file
+const CurrentAPIVersion = "blitzctl/v1"
+
+// migration upgrades a configuration document from one apiVersion to the next
+type migration struct {
+	from    string
+	to      string
+	migrate func(m *Manager, root *yaml.Node) error
+}
+
+// migrations is the upgrade path of the configuration file, in order. Files
+// written before apiVersion existed have an empty version.
+var migrations = []migration{
+	{from: "", to: "blitzctl/v1", migrate: migrateToV1},
+}
+
+// apiVersionOf returns the apiVersion of the root mapping of a
+// configuration document
+func apiVersionOf(root *yaml.Node) string {
+	if version := mappingValue(root, "apiVersion"); version != nil && version.Kind == yaml.ScalarNode {
+		return version.Value
+	}
+	return ""
+}
+
+// setAPIVersion sets the apiVersion of the root mapping of a configuration
+// document, adding it as the first key when it isn't there
+func setAPIVersion(root *yaml.Node, version string) {
+	if value := mappingValue(root, "apiVersion"); value != nil {
+		*value = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: version, LineComment: value.LineComment}
+		return
+	}
+	root.Content = append([]*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "apiVersion"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: version},
+	}, root.Content...)
+}
+
+// migrateFile upgrades the configuration file at path to CurrentAPIVersion,
+// saving the original next to it first. The migrations edit the YAML node
+// tree, so comments, ordering and unknown keys survive. The caller holds the
+// file lock.
+func (m *Manager) migrateFile(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return errdefs.Wrap(errdefs.KindConfig, err, "failed to read %s", path)
+	}
+
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return errdefs.Wrap(errdefs.KindConfig, err, "failed to parse %s", path)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Tag == "!!null" {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return errdefs.New(errdefs.KindConfig, "%s is not a mapping of configuration keys", path)
+	}
+
+	from := apiVersionOf(root)
+	if from == CurrentAPIVersion {
+		return nil
+	}
+
+	start, err := migrationStart(path, from)
+	if err != nil {
+		return err
+	}
+
+	backup := backupPath(path, from)
+	if err := writeFileAtomic(backup, data); err != nil {
+		return errdefs.Wrap(errdefs.KindConfig, err, "failed to back up %s", path)
+	}
+
+	for _, step := range migrations[start:] {
+		if err := step.migrate(m, root); err != nil {
+			return errdefs.Wrap(errdefs.KindConfig, err, "failed to migrate %s to %s", path, step.to).
+				WithHint("the original file is saved as %s", backup)
+		}
+		setAPIVersion(root, step.to)
+	}
+
+	if err := writeDoc(path, doc); err != nil {
+		return err
+	}
+
+	slog.Info("Migrated configuration file", "file", path, "to", CurrentAPIVersion, "backup", backup)
+	return nil
+}
+
+// migrationStart returns the index of the first migration upgrading a file
+// at path written with apiVersion from
+func migrationStart(path, from string) (int, error) {
+	for i, step := range migrations {
+		if step.from == from {
+			return i, nil
+		}
+	}
+	return -1, errdefs.New(errdefs.KindConfig, "%s has unsupported apiVersion %q, this blitzctl understands up to %s", path, from, CurrentAPIVersion).
+		WithHint("blitzctl self-update")
+}
+
+// backupPath names the copy of path kept before migrating it from version
+func backupPath(path, version string) string {
+	if version == "" {
+		version = "v0"
+	}
+	return path + "." + strings.ReplaceAll(version, "/", "-") + ".bak"
+}
+
+// migrateToV1 moves clusters and the current context, which older versions
+// kept in the config file, into the state store and drops the "v" prefix
+// some versions were written with
+func migrateToV1(m *Manager, root *yaml.Node) error {
+	if mappingValue(root, "clusters") != nil {
+		if err := m.moveStateOut(root); err != nil {
+			return err
+		}
+	}
+	deletePath(root, []string{"clusters"})
+	deletePath(root, []string{"current_context"})
+
+	if defaults := mappingValue(root, "defaults"); defaults != nil && defaults.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(defaults.Content); i += 2 {
+			value := defaults.Content[i+1]
+			if value.Kind == yaml.ScalarNode && value.ShortTag() == "!!str" && strings.HasSuffix(defaults.Content[i].Value, "_version") {
+				value.Value = normalizeVersion(value.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// moveStateOut adds the clusters and current context of the root mapping of
+// a configuration document to the state store. Clusters already in the store
+// win.
+func (m *Manager) moveStateOut(root *yaml.Node) error {
+	var legacy struct {
+		Clusters       []ClusterInfo   `yaml:"clusters"`
+		CurrentContext *CurrentContext `yaml:"current_context"`
+	}
+	if err := root.Decode(&legacy); err != nil {
+		return errdefs.Wrap(errdefs.KindConfig, err, "failed to read clusters")
+	}
+
+	store, err := m.stateStore()
+	if err != nil {
+		return err
+	}
+
+	moved := 0
+	err = store.Update(func(state *State) error {
+		for _, cluster := range legacy.Clusters {
+			if findCluster(state.Clusters, cluster.Name, cluster.Provider) < 0 {
+				state.Clusters = append(state.Clusters, cluster)
+				moved++
+			}
+		}
+		if state.CurrentContext == nil && legacy.CurrentContext != nil && legacy.CurrentContext.Cluster != "" {
+			state.CurrentContext = legacy.CurrentContext
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Moved cluster state out of the configuration file", "clusters", moved, "to", store.Location())
+	return nil
+}
+
+// normalizeVersion strips the "v" prefix and surrounding whitespace
+func normalizeVersion(version string) string {
+	return strings.TrimPrefix(strings.TrimSpace(version), "v")
+}
@@ -0,0 +1,284 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// legacyConfig is a configuration file written before apiVersion existed,
+// when clusters were kept in it
+const legacyConfig = `defaults:
+  driver: docker
+  kind_version: v0.29.0
+  helm_version: " 3.19.0"
+clusters:
+  - name: dev
+    provider: kind
+    k8s_version: 1.34.0
+  - name: ci
+    provider: minikube
+current_context:
+  cluster: dev
+  provider: kind
+`
+
+func TestMigrateFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		state        []ClusterInfo
+		wantErr      string
+		wantBackup   string
+		wantDefaults map[string]any
+		wantClusters map[string]string
+		wantContext  string
+	}{
+		{
+			name:         "file without apiVersion",
+			content:      legacyConfig,
+			wantBackup:   "config.yaml.v0.bak",
+			wantDefaults: map[string]any{"driver": "docker", "kind_version": "0.29.0", "helm_version": "3.19.0"},
+			wantClusters: map[string]string{"dev": "1.34.0", "ci": ""},
+			wantContext:  "dev",
+		},
+		{
+			name:         "clusters already in the state store win",
+			content:      legacyConfig,
+			state:        []ClusterInfo{{Name: "dev", Provider: "kind", K8sVersion: "1.35.0"}},
+			wantBackup:   "config.yaml.v0.bak",
+			wantDefaults: map[string]any{"driver": "docker", "kind_version": "0.29.0", "helm_version": "3.19.0"},
+			wantClusters: map[string]string{"dev": "1.35.0", "ci": ""},
+			wantContext:  "dev",
+		},
+		{
+			name:         "file without clusters",
+			content:      "defaults:\n  nodes: 2\n",
+			wantBackup:   "config.yaml.v0.bak",
+			wantDefaults: map[string]any{"nodes": 2},
+			wantClusters: map[string]string{},
+		},
+		{
+			name:         "empty file",
+			content:      "",
+			wantBackup:   "config.yaml.v0.bak",
+			wantClusters: map[string]string{},
+		},
+		{
+			name:    "newer apiVersion",
+			content: "apiVersion: blitzctl/v9\ndefaults:\n  nodes: 2\n",
+			wantErr: `unsupported apiVersion "blitzctl/v9"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			store := NewFileStateStore(filepath.Join(dir, "state.json"))
+			if len(tt.state) > 0 {
+				err := store.Update(func(state *State) error {
+					state.Clusters = tt.state
+					return nil
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			m := NewManager()
+			m.SetStateStore(store)
+
+			err := m.migrateFile(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("migrateFile() error = %v, want %q", err, tt.wantErr)
+				}
+				if data, _ := os.ReadFile(path); string(data) != tt.content {
+					t.Errorf("file changed despite the error:\n%s", data)
+				}
+				if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+					t.Errorf("files left next to the config file: %v", entries)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("migrateFile() error = %v", err)
+			}
+
+			if backup, err := os.ReadFile(filepath.Join(dir, tt.wantBackup)); err != nil || string(backup) != tt.content {
+				t.Errorf("backup %s = %q (%v), want the original file", tt.wantBackup, backup, err)
+			}
+
+			doc := readYAML(t, path)
+			if doc["apiVersion"] != CurrentAPIVersion {
+				t.Errorf("apiVersion = %v, want %s", doc["apiVersion"], CurrentAPIVersion)
+			}
+			for _, key := range []string{"clusters", "current_context"} {
+				if _, ok := doc[key]; ok {
+					t.Errorf("%s left in the config file", key)
+				}
+			}
+			if got := fileDefaults(t, path); !equalDoc(got, tt.wantDefaults) {
+				t.Errorf("defaults = %v, want %v", got, tt.wantDefaults)
+			}
+			if err := ValidateFile(path); err != nil {
+				t.Errorf("migrated file is invalid: %v", err)
+			}
+
+			state, err := store.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			clusters := map[string]string{}
+			for _, cluster := range state.Clusters {
+				clusters[cluster.Name] = cluster.K8sVersion
+			}
+			if !equalDoc(toAny(clusters), toAny(tt.wantClusters)) {
+				t.Errorf("state clusters = %v, want %v", clusters, tt.wantClusters)
+			}
+			context := ""
+			if state.CurrentContext != nil {
+				context = state.CurrentContext.Cluster
+			}
+			if context != tt.wantContext {
+				t.Errorf("current context = %q, want %q", context, tt.wantContext)
+			}
+		})
+	}
+}
+
+// toAny converts a map of strings into a generic document for equalDoc
+func toAny(m map[string]string) map[string]any {
+	doc := make(map[string]any, len(m))
+	for key, value := range m {
+		doc[key] = value
+	}
+	return doc
+}
+
+func TestMigrateFileIsIdempotent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte(legacyConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetStateStore(NewFileStateStore(filepath.Join(dir, "state.json")))
+
+	if !m.NeedsMigration() {
+		t.Fatal("NeedsMigration() = false for a file without apiVersion")
+	}
+	if err := m.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if m.NeedsMigration() {
+		t.Error("NeedsMigration() = true after Migrate()")
+	}
+	if got := m.GetDefaults().KindVersion; got != "0.29.0" {
+		t.Errorf("kind_version = %q after Migrate(), want 0.29.0", got)
+	}
+
+	migrated, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Migrate(); err != nil {
+		t.Fatalf("second Migrate() error = %v", err)
+	}
+	if again, _ := os.ReadFile(path); string(again) != string(migrated) {
+		t.Errorf("second Migrate() rewrote the file:\n%s", again)
+	}
+	if got := len(m.ListClusters()); got != 2 {
+		t.Errorf("got %d clusters after migrating twice, want 2", got)
+	}
+}
+
+func TestMigrateFileKeepsComments(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := `# Team defaults
+defaults:
+  nodes: 3 # enough for the demo
+  driver: docker
+  kind_version: v0.29.0
+clusters:
+  - name: dev
+    provider: kind
+# Read by our own tooling
+team: platform
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager()
+	m.SetStateStore(NewFileStateStore(filepath.Join(dir, "state.json")))
+	if err := m.migrateFile(path); err != nil {
+		t.Fatalf("migrateFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated := string(data)
+	if !strings.HasPrefix(migrated, "apiVersion: "+CurrentAPIVersion+"\n") {
+		t.Errorf("apiVersion isn't the first key:\n%s", migrated)
+	}
+	for _, want := range []string{"# Team defaults", "# enough for the demo", "# Read by our own tooling", "team: platform", "kind_version: 0.29.0"} {
+		if !strings.Contains(migrated, want) {
+			t.Errorf("migrated file lost %q:\n%s", want, migrated)
+		}
+	}
+	if strings.Contains(migrated, "clusters:") {
+		t.Errorf("clusters left in the migrated file:\n%s", migrated)
+	}
+	if strings.Index(migrated, "nodes:") > strings.Index(migrated, "driver:") {
+		t.Errorf("nodes moved after driver:\n%s", migrated)
+	}
+}
+
+func TestMigrationStart(t *testing.T) {
+	tests := []struct {
+		from    string
+		want    int
+		wantErr bool
+	}{
+		{from: "", want: 0},
+		{from: "blitzctl/v9", wantErr: true},
+		{from: "v1", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := migrationStart("config.yaml", tt.from)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("migrationStart(%q) error = %v, wantErr %v", tt.from, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got != tt.want {
+			t.Errorf("migrationStart(%q) = %d, want %d", tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestBackupPath(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "", want: "config.yaml.v0.bak"},
+		{version: "blitzctl/v1", want: "config.yaml.blitzctl-v1.bak"},
+	}
+	for _, tt := range tests {
+		if got := backupPath("config.yaml", tt.version); got != tt.want {
+			t.Errorf("backupPath(%q) = %s, want %s", tt.version, got, tt.want)
+		}
+	}
+}
@@ -4,54 +4,316 @@ Copyright © 2025 Oneide Luiz Schneider
 package config
 
 import (
+	"path"
+	"reflect"
+	"slices"
 	"time"
 )
 
-// Config represents the complete blitzctl configuration
+// Config represents the blitzctl preferences. Clusters and the current
+// context are machine state and live in the StateStore.
 type Config struct {
-	Defaults       Defaults        `yaml:"defaults" mapstructure:"defaults"`
-	Clusters       []ClusterInfo   `yaml:"clusters" mapstructure:"clusters"`
-	CurrentContext *CurrentContext `yaml:"current_context,omitempty" mapstructure:"current_context"`
+	// APIVersion is the schema version of the file, see CurrentAPIVersion
+	APIVersion string   `yaml:"apiVersion" mapstructure:"apiVersion"`
+	Defaults   Defaults `yaml:"defaults" mapstructure:"defaults"`
+
+	// Profile is the profile used when --profile isn't given, empty for none
+	Profile string `yaml:"profile,omitempty" mapstructure:"profile"`
+	// Profiles are named overlays of Defaults, only the keys they set
+	// replace the defaults
+	Profiles map[string]Defaults `yaml:"profiles,omitempty" mapstructure:"profiles"`
+	// Templates are named cluster shapes for `blitzctl create cluster --template`
+	Templates map[string]ClusterTemplate `yaml:"templates,omitempty" mapstructure:"templates"`
 }
 
-// Defaults holds the default configuration values
+// Defaults holds the default configuration values. Empty values are left
+// out of the file, the keys a profile leaves out keep the value of the
+// defaults.
 type Defaults struct {
-	K8sVersion  string `yaml:"k8s_version" mapstructure:"k8s_version"`
-	Driver      string `yaml:"driver" mapstructure:"driver"`
-	ClusterName string `yaml:"cluster_name" mapstructure:"cluster_name"`
-	CNI         string `yaml:"cni" mapstructure:"cni"`
-	HelmVersion string `yaml:"helm_version" mapstructure:"helm_version"`
+	K8sVersion  string `yaml:"k8s_version,omitempty" mapstructure:"k8s_version"`
+	Driver      string `yaml:"driver,omitempty" mapstructure:"driver"`
+	ClusterName string `yaml:"cluster_name,omitempty" mapstructure:"cluster_name"`
+	CNI         string `yaml:"cni,omitempty" mapstructure:"cni"`
+
+	// Cluster shape and readiness, see `blitzctl create cluster`
+	Nodes       int       `yaml:"nodes,omitempty" mapstructure:"nodes"`
+	Resources   Resources `yaml:"resources,omitempty" mapstructure:"resources"`
+	ExtraConfig []string  `yaml:"extra_config,omitempty" mapstructure:"extra_config"`
+	Wait        bool      `yaml:"wait,omitempty" mapstructure:"wait"`
+	WaitTimeout Duration  `yaml:"wait_timeout,omitempty" mapstructure:"wait_timeout"`
+
+	// Addons enabled on every new cluster, see `blitzctl addon list`
+	Addons []string `yaml:"addons,omitempty" mapstructure:"addons"`
+	// Helm charts installed in order on every new cluster, see
+	// `blitzctl charts apply`
+	Charts []Chart `yaml:"charts,omitempty" mapstructure:"charts"`
+	// Bootstrap is a directory of manifests applied to every new cluster
+	// last, see `blitzctl create cluster --bootstrap-dir`
+	Bootstrap string `yaml:"bootstrap,omitempty" mapstructure:"bootstrap"`
+	// Commands run around cluster operations
+	Hooks Hooks `yaml:"hooks,omitempty" mapstructure:"hooks"`
+
+	HelmVersion string `yaml:"helm_version,omitempty" mapstructure:"helm_version"`
+
+	// Tool versions pinned for `blitzctl tool`
+	KubectlVersion   string `yaml:"kubectl_version,omitempty" mapstructure:"kubectl_version"`
+	KindVersion      string `yaml:"kind_version,omitempty" mapstructure:"kind_version"`
+	MinikubeVersion  string `yaml:"minikube_version,omitempty" mapstructure:"minikube_version"`
+	K9sVersion       string `yaml:"k9s_version,omitempty" mapstructure:"k9s_version"`
+	KustomizeVersion string `yaml:"kustomize_version,omitempty" mapstructure:"kustomize_version"`
+	SternVersion     string `yaml:"stern_version,omitempty" mapstructure:"stern_version"`
+}
+
+// Resources bound what each minikube node may use, zero leaves minikube's default
+type Resources struct {
+	CPUs   int    `yaml:"cpus,omitempty" mapstructure:"cpus"`
+	Memory string `yaml:"memory,omitempty" mapstructure:"memory"`
+}
+
+// Chart is a Helm chart installed into clusters, one piece of a team's
+// platform stack
+type Chart struct {
+	// Chart is the name of the chart in Repo, an oci:// reference or a
+	// local path
+	Chart string `yaml:"chart" mapstructure:"chart"`
+	// Repo is the chart repository URL, empty for an oci:// reference or a
+	// local path
+	Repo string `yaml:"repo,omitempty" mapstructure:"repo"`
+	// Version pins the chart, the latest version when empty
+	Version string `yaml:"version,omitempty" mapstructure:"version"`
+	// Release names the release, the base name of Chart when empty
+	Release string `yaml:"release,omitempty" mapstructure:"release"`
+	// Namespace of the release, created when missing (default: default)
+	Namespace string `yaml:"namespace,omitempty" mapstructure:"namespace"`
+	// ValuesFile is a YAML file of values for the chart, relative to the
+	// working directory
+	ValuesFile string `yaml:"values_file,omitempty" mapstructure:"values_file"`
+}
+
+// ReleaseName returns the name of the release of the chart
+func (c Chart) ReleaseName() string {
+	if c.Release != "" {
+		return c.Release
+	}
+	return path.Base(c.Chart)
+}
+
+// ReleaseNamespace returns the namespace of the release of the chart
+func (c Chart) ReleaseNamespace() string {
+	if c.Namespace != "" {
+		return c.Namespace
+	}
+	return "default"
+}
+
+// String renders the chart as chart@version
+func (c Chart) String() string {
+	if c.Version == "" {
+		return c.Chart
+	}
+	return c.Chart + "@" + c.Version
+}
+
+// Hooks are the commands run around cluster operations, in order
+type Hooks struct {
+	PreCreate  []Hook `yaml:"pre_create,omitempty" mapstructure:"pre_create"`
+	PostCreate []Hook `yaml:"post_create,omitempty" mapstructure:"post_create"`
+	PreDelete  []Hook `yaml:"pre_delete,omitempty" mapstructure:"pre_delete"`
+	PostDelete []Hook `yaml:"post_delete,omitempty" mapstructure:"post_delete"`
+	PostStart  []Hook `yaml:"post_start,omitempty" mapstructure:"post_start"`
+}
+
+// What a hook's failure does
+const (
+	// HookAbort fails the operation, a pre hook stops it from running
+	HookAbort = "abort"
+	// HookWarn logs a warning and carries on
+	HookWarn = "warn"
+)
+
+// Hook is a command run around a cluster operation
+type Hook struct {
+	// Name labels the hook in the logs, the command when empty
+	Name string `yaml:"name,omitempty" mapstructure:"name"`
+	// Run is the command, run by sh -c (cmd /C on Windows)
+	Run string `yaml:"run" mapstructure:"run"`
+	// Timeout bounds the command, DefaultHookTimeout when zero
+	Timeout Duration `yaml:"timeout,omitempty" mapstructure:"timeout"`
+	// OnFailure is HookAbort (default) or HookWarn
+	OnFailure string `yaml:"on_failure,omitempty" mapstructure:"on_failure"`
+}
+
+// Label names the hook in messages
+func (h Hook) Label() string {
+	if h.Name != "" {
+		return h.Name
+	}
+	return h.Run
+}
+
+// String renders the hook as its label
+func (h Hook) String() string {
+	return h.Label()
+}
+
+// Duration is a time.Duration written as "5m0s" rather than nanoseconds
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Overlay returns d with every non-zero value of profile applied on top
+func (d Defaults) Overlay(profile Defaults) Defaults {
+	overlay(reflect.ValueOf(&d).Elem(), reflect.ValueOf(profile))
+	return d
+}
+
+// overlay sets the non-zero fields of src in dst, field by field in nested structs
+func overlay(dst, src reflect.Value) {
+	for i := range src.NumField() {
+		if src.Field(i).IsZero() {
+			continue
+		}
+		if src.Field(i).Kind() == reflect.Struct {
+			overlay(dst.Field(i), src.Field(i))
+			continue
+		}
+		dst.Field(i).Set(src.Field(i))
+	}
 }
 
 // ClusterInfo represents information about a managed cluster
 type ClusterInfo struct {
-	Name       string            `yaml:"name" mapstructure:"name"`
-	Provider   string            `yaml:"provider" mapstructure:"provider"`
-	K8sVersion string            `yaml:"k8s_version" mapstructure:"k8s_version"`
-	Status     string            `yaml:"status" mapstructure:"status"`
-	CreatedAt  time.Time         `yaml:"created_at" mapstructure:"created_at"`
-	Driver     string            `yaml:"driver,omitempty" mapstructure:"driver"`
-	CNI        string            `yaml:"cni,omitempty" mapstructure:"cni"`
-	Options    map[string]string `yaml:"options,omitempty" mapstructure:"options"`
+	Name       string            `json:"name" yaml:"name" mapstructure:"name"`
+	Provider   string            `json:"provider" yaml:"provider" mapstructure:"provider"`
+	K8sVersion string            `json:"k8s_version" yaml:"k8s_version" mapstructure:"k8s_version"`
+	Status     string            `json:"status" yaml:"status" mapstructure:"status"`
+	CreatedAt  time.Time         `json:"created_at" yaml:"created_at" mapstructure:"created_at"`
+	Driver     string            `json:"driver,omitempty" yaml:"driver,omitempty" mapstructure:"driver"`
+	CNI        string            `json:"cni,omitempty" yaml:"cni,omitempty" mapstructure:"cni"`
+	Options    map[string]string `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options"`
+	Profile    string            `json:"profile,omitempty" yaml:"profile,omitempty" mapstructure:"profile"`
+	Nodes      int               `json:"nodes,omitempty" yaml:"nodes,omitempty" mapstructure:"nodes"`
+	// ExtraConfig is the component configuration the cluster was created with
+	ExtraConfig []string    `json:"extra_config,omitempty" yaml:"extra_config,omitempty" mapstructure:"extra_config"`
+	Addons      []AddonInfo `json:"addons,omitempty" yaml:"addons,omitempty" mapstructure:"addons"`
+}
+
+// ClusterTemplate is the shape of a cluster, saved from a tracked cluster to
+// create identical ones
+type ClusterTemplate struct {
+	Provider   string `yaml:"provider" mapstructure:"provider"`
+	K8sVersion string `yaml:"k8s_version,omitempty" mapstructure:"k8s_version"`
+	Driver     string `yaml:"driver,omitempty" mapstructure:"driver"`
+	CNI        string `yaml:"cni,omitempty" mapstructure:"cni"`
+	Nodes      int    `yaml:"nodes,omitempty" mapstructure:"nodes"`
+	// Options are the provider options of the cluster, e.g. memory and cpus
+	Options map[string]string `yaml:"options,omitempty" mapstructure:"options"`
+	// ExtraConfig is the component configuration, e.g. kubelet.max-pods=150
+	ExtraConfig []string `yaml:"extra_config,omitempty" mapstructure:"extra_config"`
+	Addons      []string `yaml:"addons,omitempty" mapstructure:"addons"`
+}
+
+// Template returns the shape of the cluster, without its name and status
+func (c ClusterInfo) Template() ClusterTemplate {
+	template := ClusterTemplate{
+		Provider:    c.Provider,
+		K8sVersion:  c.K8sVersion,
+		Driver:      c.Driver,
+		CNI:         c.CNI,
+		Nodes:       c.Nodes,
+		ExtraConfig: slices.Clone(c.ExtraConfig),
+	}
+	for k, v := range c.Options {
+		// Options repeat the driver and CNI, an empty one was never set
+		if v == "" || k == "driver" || k == "cni" {
+			continue
+		}
+		if template.Options == nil {
+			template.Options = map[string]string{}
+		}
+		template.Options[k] = v
+	}
+	for _, addon := range c.Addons {
+		template.Addons = append(template.Addons, addon.Name)
+	}
+	return template
+}
+
+// AddonInfo records an addon enabled on a cluster
+type AddonInfo struct {
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+	// Version is empty when the provider's own addon was used
+	Version string `json:"version,omitempty" yaml:"version,omitempty" mapstructure:"version"`
+	// Method is how it was installed: manifest, helm, or the provider name
+	Method    string    `json:"method" yaml:"method" mapstructure:"method"`
+	EnabledAt time.Time `json:"enabled_at" yaml:"enabled_at" mapstructure:"enabled_at"`
+}
+
+// SnapshotInfo records a snapshot of a cluster. The committed node images
+// are kept by the container engine, the rest of the snapshot in Dir.
+type SnapshotInfo struct {
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+	// Cluster is the cluster as tracked when the snapshot was saved
+	Cluster   ClusterInfo `json:"cluster" yaml:"cluster" mapstructure:"cluster"`
+	CreatedAt time.Time   `json:"created_at" yaml:"created_at" mapstructure:"created_at"`
+	// Engine is the container engine running the nodes, docker or podman
+	Engine string         `json:"engine" yaml:"engine" mapstructure:"engine"`
+	Dir    string         `json:"dir" yaml:"dir" mapstructure:"dir"`
+	Nodes  []SnapshotNode `json:"nodes" yaml:"nodes" mapstructure:"nodes"`
+	// Size is the size in bytes of the files in Dir
+	Size int64 `json:"size" yaml:"size" mapstructure:"size"`
+}
+
+// SnapshotNode records the snapshot of one node container
+type SnapshotNode struct {
+	Name  string `json:"name" yaml:"name" mapstructure:"name"`
+	Image string `json:"image" yaml:"image" mapstructure:"image"`
+	// Archive is the file in the snapshot directory holding the node's /var
+	Archive string `json:"archive" yaml:"archive" mapstructure:"archive"`
 }
 
 // CurrentContext represents the current active cluster context
 type CurrentContext struct {
-	Cluster  string `yaml:"cluster" mapstructure:"cluster"`
-	Provider string `yaml:"provider" mapstructure:"provider"`
+	Cluster  string `json:"cluster" yaml:"cluster" mapstructure:"cluster"`
+	Provider string `json:"provider" yaml:"provider" mapstructure:"provider"`
 }
 
 // GetDefaultConfig returns a Config struct with default values
 func GetDefaultConfig() *Config {
 	return &Config{
+		APIVersion: CurrentAPIVersion,
 		Defaults: Defaults{
 			K8sVersion:  DefaultK8sVersion,
 			Driver:      DefaultDriver,
 			ClusterName: DefaultClusterName,
 			CNI:         DefaultCni,
+			Nodes:       DefaultNodes,
+			WaitTimeout: DefaultWaitTimeout,
 			HelmVersion: DefaultHelmVersion,
+
+			KubectlVersion:   DefaultKubectlVersion,
+			KindVersion:      DefaultKindVersion,
+			MinikubeVersion:  DefaultMinikubeVersion,
+			K9sVersion:       DefaultK9sVersion,
+			KustomizeVersion: DefaultKustomizeVersion,
+			SternVersion:     DefaultSternVersion,
 		},
-		Clusters:       []ClusterInfo{},
-		CurrentContext: nil,
 	}
 }
@@ -0,0 +1,201 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// ConfigEnvVar selects the configuration file when --config isn't given
+	ConfigEnvVar = EnvPrefix + "_CONFIG"
+	// ProfileEnvVar selects the profile when --profile isn't given
+	ProfileEnvVar = EnvPrefix + "_PROFILE"
+)
+
+// Source is a layer a default value can come from
+type Source string
+
+// The layers of a default value, highest precedence first
+const (
+	SourceFlag    Source = "flag"
+	SourceEnv     Source = "environment"
+	SourceProfile Source = "profile"
+	SourceFile    Source = "config file"
+	SourceBuiltin Source = "built-in"
+)
+
+// Layer is the value one source gives a defaults key
+type Layer struct {
+	Source Source
+	// Name identifies the layer: the flag, variable, profile or file
+	Name  string
+	Value string
+	// Set reports whether the layer gives the key a value at all
+	Set bool
+
+	value reflect.Value
+}
+
+var (
+	flagsMu sync.Mutex
+	// boundFlags are the flags overriding each defaults key, several
+	// commands may bind a flag to the same key
+	boundFlags = map[string][]*pflag.Flag{}
+)
+
+// BindFlag makes flag, when given on the command line, override the
+// defaults key. The flag must be of the key's type, a list flag for a list.
+func BindFlag(flag *pflag.Flag, key string) {
+	if flag == nil {
+		panic(fmt.Sprintf("❌ Failed to bind a missing flag to %q", key))
+	}
+	if _, err := lookupField(key); err != nil {
+		panic(fmt.Sprintf("❌ Failed to bind flag --%s: %v", flag.Name, err))
+	}
+
+	flagsMu.Lock()
+	defer flagsMu.Unlock()
+	boundFlags[key] = append(boundFlags[key], flag)
+}
+
+// EnvVar returns the environment variable overriding the defaults key
+func EnvVar(key string) string {
+	return EnvPrefix + "_DEFAULTS_" + strings.ToUpper(strings.ReplaceAll(normalizeKey(key), ".", "_"))
+}
+
+// validateEnv checks every BLITZCTL_DEFAULTS_* variable that is set
+func validateEnv() error {
+	var problems []error
+	for _, f := range defaultsFields {
+		raw, ok := os.LookupEnv(EnvVar(f.key))
+		if !ok {
+			continue
+		}
+		value, err := f.parse(raw)
+		if err == nil {
+			err = f.check(value)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", EnvVar(f.key), err))
+		}
+	}
+	if err := errors.Join(problems...); err != nil {
+		return errdefs.Wrap(errdefs.KindInvalid, err, "invalid environment variable")
+	}
+	return nil
+}
+
+// Explain returns every layer that can set key, highest precedence first.
+// The first layer that is set gives the effective value.
+func (m *Manager) Explain(key string) ([]Layer, error) {
+	f, err := lookupField(key)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.layers(f), nil
+}
+
+// Configured reports whether a layer above the built-in values, a flag, the
+// environment, the profile or a file, sets key
+func (m *Manager) Configured(key string) bool {
+	f, err := lookupField(key)
+	if err != nil {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, layer := range m.layers(f) {
+		if layer.Set {
+			return layer.Source != SourceBuiltin
+		}
+	}
+	return false
+}
+
+// layers returns the layers of f. The caller holds m.mu.
+func (m *Manager) layers(f field) []Layer {
+	var layers []Layer
+
+	flagsMu.Lock()
+	flags := boundFlags[f.key]
+	flagsMu.Unlock()
+	if len(flags) > 0 {
+		layer := Layer{Source: SourceFlag, Name: "--" + flags[0].Name}
+		for _, flag := range flags {
+			if !flag.Changed {
+				continue
+			}
+			layer.Name = "--" + flag.Name
+			if list, ok := flag.Value.(pflag.SliceValue); ok && f.typ.Kind() == reflect.Slice {
+				layer.value, layer.Set = reflect.ValueOf(list.GetSlice()), true
+			} else if value, err := f.parse(flag.Value.String()); err == nil {
+				layer.value, layer.Set = value, true
+			}
+			break
+		}
+		layers = append(layers, layer)
+	}
+
+	env := Layer{Source: SourceEnv, Name: EnvVar(f.key)}
+	if raw, ok := os.LookupEnv(env.Name); ok {
+		// Initialize rejects variables that don't parse
+		if value, err := f.parse(raw); err == nil {
+			env.value, env.Set = value, true
+		}
+	}
+	layers = append(layers, env)
+
+	if name := m.activeProfile(); name != "" {
+		profile := m.config.Profiles[name]
+		layers = append(layers, Layer{Source: SourceProfile, Name: name, value: f.value(&profile), Set: m.profileSets(name, f)})
+	}
+
+	// The top file setting the key wins
+	file := Layer{Source: SourceFile, Name: m.configFileUsed(), value: f.value(&m.config.Defaults)}
+	for i := len(m.files) - 1; i >= 0; i-- {
+		if v := m.files[i].v; v != nil && v.InConfig("defaults."+f.key) {
+			file.Name, file.Set = m.files[i].path, true
+			break
+		}
+	}
+	layers = append(layers, file)
+
+	builtin := GetDefaultConfig().Defaults
+	layers = append(layers, Layer{Source: SourceBuiltin, value: f.value(&builtin), Set: true})
+
+	for i := range layers {
+		if layers[i].Set {
+			layers[i].Value = formatValue(layers[i].value)
+		}
+	}
+	return layers
+}
+
+// effectiveDefaults returns the defaults resolved through every layer.
+// The caller holds m.mu.
+func (m *Manager) effectiveDefaults() Defaults {
+	var defaults Defaults
+	for _, f := range defaultsFields {
+		for _, layer := range m.layers(f) {
+			if layer.Set {
+				f.value(&defaults).Set(layer.value)
+				break
+			}
+		}
+	}
+	return defaults
+}
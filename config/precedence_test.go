@@ -0,0 +1,174 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+// bindTestFlag binds a flag given on the command line as value to key for
+// the duration of the test
+func bindTestFlag(t *testing.T, key, value string, list bool) {
+	t.Helper()
+	flags := pflag.NewFlagSet(t.Name(), pflag.ContinueOnError)
+	if list {
+		flags.StringSlice(key, nil, "")
+	} else {
+		flags.String(key, "", "")
+	}
+	if err := flags.Set(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	flagsMu.Lock()
+	bound := boundFlags[key]
+	flagsMu.Unlock()
+	t.Cleanup(func() {
+		flagsMu.Lock()
+		defer flagsMu.Unlock()
+		boundFlags[key] = bound
+	})
+	BindFlag(flags.Lookup(key), key)
+}
+
+func TestExplain(t *testing.T) {
+	builtinNodes := strconv.Itoa(GetDefaultConfig().Defaults.Nodes)
+
+	tests := []struct {
+		name       string
+		key        string
+		list       bool
+		file       string
+		profile    string
+		env        string
+		flag       string
+		want       string
+		wantSource Source
+	}{
+		{name: "built-in", key: "nodes", want: builtinNodes, wantSource: SourceBuiltin},
+		{name: "file over built-in", key: "nodes", file: "2", want: "2", wantSource: SourceFile},
+		{name: "profile over file", key: "nodes", file: "2", profile: "3", want: "3", wantSource: SourceProfile},
+		{name: "profile sets false", key: "wait", file: "true", profile: "false", want: "false", wantSource: SourceProfile},
+		{name: "environment over profile", key: "nodes", file: "2", profile: "3", env: "4", want: "4", wantSource: SourceEnv},
+		{name: "flag over environment", key: "nodes", file: "2", profile: "3", env: "4", flag: "5", want: "5", wantSource: SourceFlag},
+		{name: "flag over file", key: "nodes", file: "2", flag: "5", want: "5", wantSource: SourceFlag},
+		{name: "list from the environment", key: "addons", list: true, file: "ingress", env: "metrics-server, dashboard", want: "metrics-server,dashboard", wantSource: SourceEnv},
+		{name: "list flag", key: "addons", list: true, env: "ingress", flag: "metrics-server,dashboard", want: "metrics-server,dashboard", wantSource: SourceFlag},
+		{name: "version from the environment", key: "kind_version", env: "v0.30.0", want: "0.30.0", wantSource: SourceEnv},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := OpenFile(filepath.Join(t.TempDir(), "config.yaml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.file != "" {
+				if err := m.SetDefault(tt.key, tt.file); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.profile != "" {
+				var profile Defaults
+				if err := SetDefaultIn(&profile, tt.key, tt.profile); err != nil {
+					t.Fatal(err)
+				}
+				if err := m.CreateProfile("test", profile, tt.key); err != nil {
+					t.Fatal(err)
+				}
+				if err := m.SetProfile("test"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.env != "" {
+				t.Setenv(EnvVar(tt.key), tt.env)
+			}
+			if tt.flag != "" {
+				bindTestFlag(t, tt.key, tt.flag, tt.list)
+			}
+
+			layers, err := m.Explain(tt.key)
+			if err != nil {
+				t.Fatalf("Explain() error = %v", err)
+			}
+			var winner *Layer
+			for i := range layers {
+				if layers[i].Set {
+					winner = &layers[i]
+					break
+				}
+			}
+			if winner == nil {
+				t.Fatalf("no layer sets %s: %+v", tt.key, layers)
+			}
+			if winner.Source != tt.wantSource || winner.Value != tt.want {
+				t.Errorf("effective layer = %s %q, want %s %q", winner.Source, winner.Value, tt.wantSource, tt.want)
+			}
+			if last := layers[len(layers)-1]; last.Source != SourceBuiltin || !last.Set {
+				t.Errorf("last layer = %+v, want the built-in value", last)
+			}
+
+			// The defaults resolve to the value Explain reports
+			value, err := GetDefaultIn(m.GetDefaults(), tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := FormatValue(value); got != tt.want {
+				t.Errorf("GetDefaults() %s = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvVar(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "nodes", want: "BLITZCTL_DEFAULTS_NODES"},
+		{key: "k8s-version", want: "BLITZCTL_DEFAULTS_K8S_VERSION"},
+		{key: "resources.cpus", want: "BLITZCTL_DEFAULTS_RESOURCES_CPUS"},
+	}
+	for _, tt := range tests {
+		if got := EnvVar(tt.key); got != tt.want {
+			t.Errorf("EnvVar(%q) = %s, want %s", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestConfigured(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  string
+		want bool
+	}{
+		{name: "built-in only"},
+		{name: "config file", file: "calico", want: true},
+		{name: "file set to the built-in value", file: DefaultCni, want: true},
+		{name: "environment", env: "flannel", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := OpenFile(filepath.Join(t.TempDir(), "config.yaml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.file != "" {
+				if err := m.SetDefault("cni", tt.file); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.env != "" {
+				t.Setenv(EnvVar("cni"), tt.env)
+			}
+			if got := m.Configured("cni"); got != tt.want {
+				t.Errorf("Configured(cni) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
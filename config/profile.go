@@ -0,0 +1,160 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProfile names the plain defaults, without any profile applied
+const DefaultProfile = "default"
+
+// SetProfile selects the profile applied to the defaults for this process,
+// overriding the profile saved in the configuration file
+func (m *Manager) SetProfile(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if name != DefaultProfile {
+		if _, ok := m.config.Profiles[name]; !ok {
+			return errdefs.NotFound("profile %q not found", name).
+				WithHint("blitzctl config profile list")
+		}
+	}
+	m.profile = name
+	return nil
+}
+
+// ActiveProfile returns the name of the profile applied to the defaults,
+// empty when none is
+func (m *Manager) ActiveProfile() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.activeProfile()
+}
+
+// activeProfile returns the profile applied to the defaults. The caller
+// holds m.mu.
+func (m *Manager) activeProfile() string {
+	name := m.config.Profile
+	if m.profile != "" {
+		name = m.profile
+	}
+	if _, ok := m.config.Profiles[name]; !ok {
+		return ""
+	}
+	return name
+}
+
+// ProfileSets reports whether the named profile sets key, to a zero value
+// like false or 0 as well
+func (m *Manager) ProfileSets(name, key string) bool {
+	f, err := lookupField(key)
+	if err != nil {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.profileSets(name, f)
+}
+
+// profileSets reports whether a configuration file sets f in the named
+// profile. The caller holds m.mu.
+func (m *Manager) profileSets(name string, f field) bool {
+	for _, file := range m.files {
+		if file.v != nil && file.v.InConfig("profiles."+name+"."+f.key) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListProfiles returns the names of the profiles, sorted
+func (m *Manager) ListProfiles() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Sorted(maps.Keys(m.config.Profiles))
+}
+
+// GetProfile returns the overlay of the named profile
+func (m *Manager) GetProfile(name string) (Defaults, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	profile, ok := m.config.Profiles[name]
+	if !ok {
+		return Defaults{}, errdefs.NotFound("profile %q not found", name)
+	}
+	return profile, nil
+}
+
+// CreateProfile saves a new profile. The keys given are saved whatever
+// their value, so the profile can set one to false or 0.
+func (m *Manager) CreateProfile(name string, profile Defaults, keys ...string) error {
+	if err := validateProfileName(name); err != nil {
+		return errdefs.Wrap(errdefs.KindInvalid, err, "invalid profile name")
+	}
+	if err := errors.Join(validateDefaults("profiles."+name, profile, true)...); err != nil {
+		return errdefs.Wrap(errdefs.KindInvalid, err, "invalid profile %q", name)
+	}
+
+	return m.edit(func(path string, doc *yaml.Node) error {
+		before, err := loadFile(path)
+		if err != nil {
+			return err
+		}
+		if _, ok := before.Profiles[name]; ok {
+			return errdefs.AlreadyExists("profile %q already exists", name).
+				WithHint("blitzctl config profile delete %s", name)
+		}
+		after, err := loadFile(path)
+		if err != nil {
+			return err
+		}
+		if after.Profiles == nil {
+			after.Profiles = map[string]Defaults{}
+		}
+		after.Profiles[name] = profile
+		if err := applyChanges(doc, before, after, nil); err != nil {
+			return err
+		}
+		return setKeys(doc.Content[0], []string{"profiles", name}, &profile, keys)
+	})
+}
+
+// UseProfile saves the profile applied when --profile isn't given,
+// DefaultProfile applies none
+func (m *Manager) UseProfile(name string) error {
+	return m.update(func(config *Config) error {
+		if name == DefaultProfile {
+			config.Profile = ""
+			return nil
+		}
+		if _, ok := config.Profiles[name]; !ok {
+			return errdefs.NotFound("profile %q not found", name)
+		}
+		config.Profile = name
+		return nil
+	})
+}
+
+// DeleteProfile removes a profile, no longer using it if it was in use
+func (m *Manager) DeleteProfile(name string) error {
+	return m.update(func(config *Config) error {
+		if _, ok := config.Profiles[name]; !ok {
+			return errdefs.NotFound("profile %q not found", name)
+		}
+		delete(config.Profiles, name)
+		if config.Profile == name {
+			config.Profile = ""
+		}
+		return nil
+	})
+}
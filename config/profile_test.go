@@ -0,0 +1,162 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// profileConfig is a configuration file with two profiles, ci in use
+const profileConfig = `apiVersion: blitzctl/v1
+defaults:
+  nodes: 2
+  wait: true
+profile: ci
+profiles:
+  ci:
+    nodes: 1
+    wait: false
+  big:
+    nodes: 5
+`
+
+// writeConfig writes profileConfig to a temporary file and returns its path
+func writeConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(profileConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestProfileResolution(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         string
+		flag        string
+		wantErr     errdefs.Kind
+		wantProfile string
+		wantNodes   int
+		wantWait    bool
+	}{
+		{name: "saved profile", wantProfile: "ci", wantNodes: 1, wantWait: false},
+		{name: "environment over the saved profile", env: "big", wantProfile: "big", wantNodes: 5, wantWait: true},
+		{name: "flag over the environment", env: "big", flag: "ci", wantProfile: "ci", wantNodes: 1, wantWait: false},
+		{name: "default applies none", flag: DefaultProfile, wantNodes: 2, wantWait: true},
+		{name: "unknown profile", flag: "staging", wantErr: errdefs.KindNotFound},
+		{name: "unknown profile from the environment", env: "staging", wantErr: errdefs.KindNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ProfileEnvVar, tt.env)
+
+			m := NewManager()
+			err := m.Initialize(writeConfig(t))
+			if err == nil && tt.flag != "" {
+				err = m.SetProfile(tt.flag)
+			}
+			if got := errdefs.KindOf(err); got != tt.wantErr {
+				t.Fatalf("error = %v, want kind %q", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := m.ActiveProfile(); got != tt.wantProfile {
+				t.Errorf("ActiveProfile() = %q, want %q", got, tt.wantProfile)
+			}
+			defaults := m.GetDefaults()
+			if defaults.Nodes != tt.wantNodes || defaults.Wait != tt.wantWait {
+				t.Errorf("defaults nodes=%d wait=%v, want nodes=%d wait=%v", defaults.Nodes, defaults.Wait, tt.wantNodes, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestCreateProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		values  Defaults
+		keys    []string
+		wantErr errdefs.Kind
+		// wantSet are the keys the saved profile sets
+		wantSet []string
+	}{
+		{name: "values", profile: "laptop", values: Defaults{Nodes: 1, CNI: "calico"}, wantSet: []string{"cni", "nodes"}},
+		{name: "zero value kept", profile: "laptop", keys: []string{"wait"}, wantSet: []string{"wait"}},
+		{name: "existing profile", profile: "ci", values: Defaults{Nodes: 3}, wantErr: errdefs.KindAlreadyExists},
+		{name: "reserved name", profile: DefaultProfile, wantErr: errdefs.KindInvalid},
+		{name: "invalid name", profile: "My Profile", wantErr: errdefs.KindInvalid},
+		{name: "invalid value", profile: "laptop", values: Defaults{Nodes: -1}, wantErr: errdefs.KindInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := OpenFile(writeConfig(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = m.CreateProfile(tt.profile, tt.values, tt.keys...)
+			if got := errdefs.KindOf(err); got != tt.wantErr {
+				t.Fatalf("CreateProfile() error = %v, want kind %q", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if !slices.Contains(m.ListProfiles(), tt.profile) {
+				t.Errorf("ListProfiles() = %v, want %s", m.ListProfiles(), tt.profile)
+			}
+			var set []string
+			for _, key := range DefaultsKeys() {
+				if m.ProfileSets(tt.profile, key) {
+					set = append(set, key)
+				}
+			}
+			slices.Sort(set)
+			if !slices.Equal(set, tt.wantSet) {
+				t.Errorf("profile sets %v, want %v", set, tt.wantSet)
+			}
+		})
+	}
+}
+
+func TestUseAndDeleteProfile(t *testing.T) {
+	m, err := OpenFile(writeConfig(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		name        string
+		run         func() error
+		wantErr     errdefs.Kind
+		wantProfile string
+	}{
+		{name: "use big", run: func() error { return m.UseProfile("big") }, wantProfile: "big"},
+		{name: "use an unknown profile", run: func() error { return m.UseProfile("staging") }, wantErr: errdefs.KindNotFound, wantProfile: "big"},
+		{name: "delete ci", run: func() error { return m.DeleteProfile("ci") }, wantProfile: "big"},
+		{name: "delete the profile in use", run: func() error { return m.DeleteProfile("big") }},
+		{name: "delete an unknown profile", run: func() error { return m.DeleteProfile("big") }, wantErr: errdefs.KindNotFound},
+		{name: "use default", run: func() error { return m.UseProfile(DefaultProfile) }},
+	}
+	for _, step := range steps {
+		if err := step.run(); errdefs.KindOf(err) != step.wantErr {
+			t.Fatalf("%s: error = %v, want kind %q", step.name, err, step.wantErr)
+		}
+		if got := m.ActiveProfile(); got != step.wantProfile {
+			t.Errorf("%s: ActiveProfile() = %q, want %q", step.name, got, step.wantProfile)
+		}
+	}
+	if profiles := m.ListProfiles(); len(profiles) != 0 {
+		t.Errorf("ListProfiles() = %v, want none left", profiles)
+	}
+}
@@ -0,0 +1,306 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+)
+
+// KnownDrivers are the minikube drivers blitzctl accepts by name, a path to
+// a driver binary is accepted too
+var KnownDrivers = []string{
+	"docker", "podman", "none", "ssh",
+	"virtualbox", "vmware", "parallels", "hyperv",
+	"kvm2", "qemu", "qemu2", "hyperkit", "vfkit", "krunkit",
+}
+
+// KnownProviders are the cluster providers blitzctl drives
+var KnownProviders = []string{"minikube", "kind"}
+
+// KnownCNIs are the CNIs blitzctl accepts by name, a path to a CNI manifest
+// is accepted too. false and none both leave the cluster without a CNI.
+var KnownCNIs = []string{"auto", "bridge", "calico", "cilium", "flannel", "kindnet", "false", "none"}
+
+// defaultsSchema checks the values of the defaults section, lists item by
+// item. Keys without an entry accept any value of their type.
+var defaultsSchema = map[string]func(value string) error{
+	"k8s_version":       validateVersion,
+	"driver":            oneOfOrPath(KnownDrivers),
+	"cluster_name":      validateClusterName,
+	"cni":               oneOfOrPath(KnownCNIs),
+	"nodes":             atLeast(1),
+	"resources.cpus":    atLeast(0),
+	"resources.memory":  validateMemory,
+	"wait_timeout":      validatePositiveDuration,
+	"helm_version":      validateVersion,
+	"kubectl_version":   validateVersion,
+	"kind_version":      validateVersion,
+	"minikube_version":  validateVersion,
+	"k9s_version":       validateVersion,
+	"kustomize_version": validateVersion,
+	"stern_version":     validateVersion,
+}
+
+// Validate checks config against the schema and returns every problem found
+func Validate(config *Config) error {
+	return errors.Join(validate(config)...)
+}
+
+func validate(config *Config) []error {
+	var problems []error
+
+	if config.APIVersion != CurrentAPIVersion {
+		problems = append(problems, fmt.Errorf("apiVersion: %q is not %q", config.APIVersion, CurrentAPIVersion))
+	}
+
+	problems = append(problems, validateDefaults("defaults", config.Defaults, false)...)
+
+	for _, name := range slices.Sorted(maps.Keys(config.Profiles)) {
+		if err := validateProfileName(name); err != nil {
+			problems = append(problems, fmt.Errorf("profiles.%s: %w", name, err))
+		}
+		problems = append(problems, validateDefaults("profiles."+name, config.Profiles[name], true)...)
+	}
+	if _, ok := config.Profiles[config.Profile]; config.Profile != "" && !ok {
+		problems = append(problems, fmt.Errorf("profile: %q is not one of the profiles", config.Profile))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(config.Templates)) {
+		if err := validateTemplateName(name); err != nil {
+			problems = append(problems, fmt.Errorf("templates.%s: %w", name, err))
+		}
+		if err := config.Templates[name].Validate(); err != nil {
+			problems = append(problems, fmt.Errorf("templates.%s: %w", name, err))
+		}
+	}
+
+	return problems
+}
+
+// validateDefaults checks every field of defaults, found under section.
+// Profiles leave the fields they don't override empty.
+func validateDefaults(section string, defaults Defaults, skipEmpty bool) []error {
+	var problems []error
+	for _, f := range defaultsFields {
+		value := f.value(&defaults)
+		if skipEmpty && value.IsZero() {
+			continue
+		}
+		if err := f.check(value); err != nil {
+			problems = append(problems, fmt.Errorf("%s.%s: %w", section, f.key, err))
+		}
+	}
+	return problems
+}
+
+// ValidateFile strictly decodes the configuration file at path, reporting
+// unknown keys, and validates its values
+func ValidateFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errdefs.Wrap(errdefs.KindConfig, err, "failed to read %s", path)
+	}
+	return ValidateData(path, data)
+}
+
+// ValidateData is ValidateFile for a configuration document that isn't on
+// disk yet, path only names it in errors
+func ValidateData(path string, data []byte) error {
+	config := GetDefaultConfig()
+	config.APIVersion = ""
+	var problems []string
+	if err := decodeStrict(data, config); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return errdefs.Wrap(errdefs.KindConfig, err, "❌ failed to parse %s", path)
+		}
+		problems = append(problems, typeErr.Errors...)
+	}
+	for _, problem := range validate(config) {
+		problems = append(problems, problem.Error())
+	}
+
+	if len(problems) > 0 {
+		return errdefs.Invalid("❌ %s is not valid:\n  - %s", path, strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// UnknownKeys returns a description of every key in data that the schema
+// doesn't define
+func UnknownKeys(data []byte) []string {
+	var typeErr *yaml.TypeError
+	if err := decodeStrict(data, GetDefaultConfig()); errors.As(err, &typeErr) {
+		return typeErr.Errors
+	}
+	return nil
+}
+
+// decodeStrict decodes data into config, failing on keys the schema doesn't define
+func decodeStrict(data []byte, config *Config) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	// An empty file decodes to io.EOF
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// validateVersion accepts a semantic version without the "v" prefix
+func validateVersion(value string) error {
+	if strings.HasPrefix(value, "v") {
+		return fmt.Errorf("%q must not start with \"v\"", value)
+	}
+	if _, err := utilversion.ParseSemantic(value); err != nil {
+		return fmt.Errorf("%q is not a semantic version (e.g. 1.33.4)", value)
+	}
+	return nil
+}
+
+// atLeast accepts an integer not below minimum
+func atLeast(minimum int) func(value string) error {
+	return func(value string) error {
+		if n, err := strconv.Atoi(value); err != nil || n < minimum {
+			return fmt.Errorf("%q must be an integer of at least %d", value, minimum)
+		}
+		return nil
+	}
+}
+
+// memoryPattern matches the sizes minikube accepts, e.g. 4096, 4g or 2048mb
+var memoryPattern = regexp.MustCompile(`(?i)^[0-9]+([kmgt]b?|b)?$`)
+
+// validateMemory accepts a size minikube understands, or "max"
+func validateMemory(value string) error {
+	if value == "" || value == "max" || memoryPattern.MatchString(value) {
+		return nil
+	}
+	return fmt.Errorf("%q is not a size (e.g. 4g, 8192mb or max)", value)
+}
+
+// validatePositiveDuration accepts a duration above zero
+func validatePositiveDuration(value string) error {
+	if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+		return fmt.Errorf("%q must be a duration above zero (e.g. 5m)", value)
+	}
+	return nil
+}
+
+// validateClusterName accepts a DNS-1123 label, which both providers turn
+// into container, node and kubeconfig context names
+func validateClusterName(value string) error {
+	if problems := validation.IsDNS1123Label(value); len(problems) > 0 {
+		return fmt.Errorf("%q is not a valid cluster name: %s", value, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// Validate checks the chart can be located and its release named
+func (c Chart) Validate() error {
+	if c.Chart == "" {
+		return errors.New("chart is required")
+	}
+	if c.Repo != "" {
+		if strings.HasPrefix(c.Chart, "oci://") {
+			return fmt.Errorf("%s: an oci:// chart takes no repo", c.Chart)
+		}
+		if u, err := url.Parse(c.Repo); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s: repo %q is not an http(s) URL", c.Chart, c.Repo)
+		}
+	}
+	if problems := validation.IsDNS1123Label(c.ReleaseName()); len(problems) > 0 {
+		return fmt.Errorf("%s: %q is not a valid release name: %s", c.Chart, c.ReleaseName(), strings.Join(problems, ", "))
+	}
+	if problems := validation.IsDNS1123Label(c.ReleaseNamespace()); len(problems) > 0 {
+		return fmt.Errorf("%s: %q is not a valid namespace: %s", c.Chart, c.ReleaseNamespace(), strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// Validate checks the hook has a command and a known failure policy
+func (h Hook) Validate() error {
+	if strings.TrimSpace(h.Run) == "" {
+		return errors.New("run is required")
+	}
+	if h.Timeout < 0 {
+		return fmt.Errorf("%s: timeout must not be negative", h.Label())
+	}
+	if h.OnFailure != "" && h.OnFailure != HookAbort && h.OnFailure != HookWarn {
+		return fmt.Errorf("%s: on_failure %q is not %s or %s", h.Label(), h.OnFailure, HookAbort, HookWarn)
+	}
+	return nil
+}
+
+// Validate checks the template names a provider and holds values create
+// accepts
+func (t ClusterTemplate) Validate() error {
+	if !slices.Contains(KnownProviders, t.Provider) {
+		return fmt.Errorf("provider %q is not one of %s", t.Provider, strings.Join(KnownProviders, ", "))
+	}
+	values := map[string]string{
+		"k8s_version":      t.K8sVersion,
+		"driver":           t.Driver,
+		"cni":              t.CNI,
+		"resources.cpus":   t.Options["cpus"],
+		"resources.memory": t.Options["memory"],
+	}
+	if t.Nodes != 0 {
+		values["nodes"] = strconv.Itoa(t.Nodes)
+	}
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if values[key] == "" {
+			continue
+		}
+		if err := defaultsSchema[key](values[key]); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// validateTemplateName accepts a DNS-1123 label
+func validateTemplateName(name string) error {
+	if problems := validation.IsDNS1123Label(name); len(problems) > 0 {
+		return fmt.Errorf("%q is not a valid template name: %s", name, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// validateProfileName accepts a DNS-1123 label other than DefaultProfile
+func validateProfileName(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("%q is reserved for the plain defaults", DefaultProfile)
+	}
+	if problems := validation.IsDNS1123Label(name); len(problems) > 0 {
+		return fmt.Errorf("%q is not a valid profile name: %s", name, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// oneOfOrPath accepts one of known or a path
+func oneOfOrPath(known []string) func(value string) error {
+	return func(value string) error {
+		if slices.Contains(known, value) || strings.ContainsRune(value, os.PathSeparator) {
+			return nil
+		}
+		return fmt.Errorf("%q is not one of %s or a path", value, strings.Join(known, ", "))
+	}
+}
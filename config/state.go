@@ -0,0 +1,152 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+const (
+	// StateDirName is the name of the state directory under $XDG_STATE_HOME
+	StateDirName = "blitzctl"
+	// StateFileName is the name of the state file
+	StateFileName = "state.json"
+	// DataDirName is the name of the data directory under $XDG_DATA_HOME
+	DataDirName = "blitzctl"
+)
+
+// State is what blitzctl records about this machine: the clusters it
+// created, their snapshots and the current context. Unlike Config it is never meant to be
+// edited by hand or shared between machines.
+type State struct {
+	Clusters       []ClusterInfo   `json:"clusters"`
+	CurrentContext *CurrentContext `json:"current_context,omitempty"`
+	Snapshots      []SnapshotInfo  `json:"snapshots,omitempty"`
+}
+
+// StateStore persists the machine state
+type StateStore interface {
+	// Load returns the current state, an empty one when nothing was saved yet
+	Load() (*State, error)
+	// Update applies mutate to the current state and saves it atomically
+	// with respect to other blitzctl processes. Nothing is saved when
+	// mutate returns an error.
+	Update(mutate func(state *State) error) error
+	// Location describes where the state is kept
+	Location() string
+}
+
+// StateDir returns $XDG_STATE_HOME/blitzctl, defaulting to ~/.local/state/blitzctl
+func StateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, StateDirName), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "state", StateDirName), nil
+}
+
+// DataDir returns $XDG_DATA_HOME/blitzctl, defaulting to ~/.local/share/blitzctl.
+// It holds the bulky files blitzctl keeps, like cluster snapshots.
+func DataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, DataDirName), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", DataDirName), nil
+}
+
+// FileStateStore keeps the state in a JSON file
+type FileStateStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStateStore returns a store backed by the JSON file at path
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path: path}
+}
+
+// DefaultStateStore returns the store backed by StateDir()/state.json
+func DefaultStateStore() (*FileStateStore, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewFileStateStore(filepath.Join(dir, StateFileName)), nil
+}
+
+// Location implements StateStore
+func (s *FileStateStore) Location() string {
+	return s.path
+}
+
+// Load implements StateStore
+func (s *FileStateStore) Load() (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Update implements StateStore
+func (s *FileStateStore) Update(mutate func(state *State) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	release, err := lockFile(s.path)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	state, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := mutate(state); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return errdefs.Wrap(errdefs.KindConfig, err, "failed to encode state")
+	}
+	if err := writeFileAtomic(s.path, append(data, '\n')); err != nil {
+		return errdefs.Wrap(errdefs.KindConfig, err, "failed to write %s", s.path)
+	}
+	return nil
+}
+
+func (s *FileStateStore) load() (*State, error) {
+	state := &State{Clusters: []ClusterInfo{}}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to read %s", s.path)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, errdefs.Wrap(errdefs.KindConfig, err, "failed to parse %s", s.path).
+			WithHint("fix or remove the file, blitzctl only loses track of its clusters")
+	}
+	return state, nil
+}
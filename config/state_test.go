@@ -0,0 +1,182 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+func TestStateAndDataDir(t *testing.T) {
+	home := t.TempDir()
+	xdg := t.TempDir()
+
+	tests := []struct {
+		name  string
+		env   string
+		dir   func() (string, error)
+		value string
+		want  string
+	}{
+		{name: "state from XDG_STATE_HOME", env: "XDG_STATE_HOME", dir: StateDir, value: xdg, want: filepath.Join(xdg, StateDirName)},
+		{name: "state default", env: "XDG_STATE_HOME", dir: StateDir, want: filepath.Join(home, ".local", "state", StateDirName)},
+		{name: "relative XDG_STATE_HOME ignored", env: "XDG_STATE_HOME", dir: StateDir, value: "state", want: filepath.Join(home, ".local", "state", StateDirName)},
+		{name: "data from XDG_DATA_HOME", env: "XDG_DATA_HOME", dir: DataDir, value: xdg, want: filepath.Join(xdg, DataDirName)},
+		{name: "data default", env: "XDG_DATA_HOME", dir: DataDir, want: filepath.Join(home, ".local", "share", DataDirName)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", home)
+			t.Setenv(tt.env, tt.value)
+			got, err := tt.dir()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("dir = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileStateStore(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		mutate   func(state *State) error
+		want     []string
+		wantKind errdefs.Kind
+	}{
+		{name: "no state yet", want: []string{}},
+		{
+			name:    "cluster added",
+			content: `{"clusters": [{"name": "dev", "provider": "kind"}]}`,
+			mutate: func(state *State) error {
+				state.Clusters = append(state.Clusters, ClusterInfo{Name: "ci", Provider: "minikube"})
+				return nil
+			},
+			want: []string{"dev", "ci"},
+		},
+		{
+			name:    "failed mutation saves nothing",
+			content: `{"clusters": [{"name": "dev", "provider": "kind"}]}`,
+			mutate: func(state *State) error {
+				state.Clusters = nil
+				return errdefs.NotFound("cluster ci (kind) not found")
+			},
+			want:     []string{"dev"},
+			wantKind: errdefs.KindNotFound,
+		},
+		{name: "corrupt file", content: `{"clusters": [`, wantKind: errdefs.KindConfig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "blitzctl", StateFileName)
+			if tt.content != "" {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			store := NewFileStateStore(path)
+
+			var err error
+			if tt.mutate != nil {
+				err = store.Update(tt.mutate)
+			} else {
+				_, err = store.Load()
+			}
+			if got := errdefs.KindOf(err); got != tt.wantKind {
+				t.Fatalf("error = %v, want kind %q", err, tt.wantKind)
+			}
+			if tt.want == nil {
+				return
+			}
+
+			state, err := store.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, cluster := range state.Clusters {
+				got = append(got, cluster.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("clusters = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("clusters = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestClusterState(t *testing.T) {
+	dir := t.TempDir()
+	m, err := OpenFile(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetStateStore(NewFileStateStore(filepath.Join(dir, StateFileName)))
+
+	steps := []struct {
+		name     string
+		run      func() error
+		wantKind errdefs.Kind
+	}{
+		{name: "add dev", run: func() error { return m.AddCluster(ClusterInfo{Name: "dev", Provider: "kind", Nodes: 1}) }},
+		{name: "add dev on minikube", run: func() error { return m.AddCluster(ClusterInfo{Name: "dev", Provider: "minikube"}) }},
+		{name: "replace dev", run: func() error { return m.AddCluster(ClusterInfo{Name: "dev", Provider: "kind", Nodes: 3}) }},
+		{name: "use dev", run: func() error { return m.SetCurrentContext("dev", "kind") }},
+		{name: "use an unknown cluster", run: func() error { return m.SetCurrentContext("ci", "kind") }, wantKind: errdefs.KindNotFound},
+		{name: "update an unknown cluster", run: func() error {
+			return m.UpdateCluster("ci", "kind", func(cluster *ClusterInfo) error { return nil })
+		}, wantKind: errdefs.KindNotFound},
+		{name: "snapshot dev", run: func() error {
+			return m.AddSnapshot(SnapshotInfo{Name: "before", Cluster: ClusterInfo{Name: "dev", Provider: "kind"}})
+		}},
+	}
+	for _, step := range steps {
+		if err := step.run(); errdefs.KindOf(err) != step.wantKind {
+			t.Fatalf("%s: error = %v, want kind %q", step.name, err, step.wantKind)
+		}
+	}
+
+	if clusters := m.ListClusters(); len(clusters) != 2 {
+		t.Errorf("ListClusters() = %+v, want dev on kind and minikube", clusters)
+	}
+	if cluster, err := m.GetCluster("dev", "kind"); err != nil || cluster.Nodes != 3 {
+		t.Errorf("GetCluster(dev, kind) = %+v, %v, want the replaced cluster", cluster, err)
+	}
+	if current := m.GetCurrentContext(); current == nil || current.Cluster != "dev" || current.Provider != "kind" {
+		t.Errorf("GetCurrentContext() = %+v, want dev on kind", current)
+	}
+	if _, err := m.GetSnapshot("dev", "kind", "before"); err != nil {
+		t.Errorf("GetSnapshot() error = %v", err)
+	}
+
+	// Removing the current cluster clears the current context, the config
+	// file is never written
+	if err := m.RemoveCluster("dev", "kind"); err != nil {
+		t.Fatal(err)
+	}
+	if current := m.GetCurrentContext(); current != nil {
+		t.Errorf("GetCurrentContext() = %+v after removing the cluster, want none", current)
+	}
+	if err := m.RemoveCluster("dev", "kind"); errdefs.KindOf(err) != errdefs.KindNotFound {
+		t.Errorf("second RemoveCluster() error = %v, want not found", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "config.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("cluster state written to the config file, stat error = %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package config
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// ListTemplates returns the names of the cluster templates, sorted
+func (m *Manager) ListTemplates() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Sorted(maps.Keys(m.config.Templates))
+}
+
+// GetTemplate returns the named cluster template
+func (m *Manager) GetTemplate(name string) (ClusterTemplate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	template, ok := m.config.Templates[name]
+	if !ok {
+		return ClusterTemplate{}, errdefs.NotFound("template %q not found", name).
+			WithHint("blitzctl template list")
+	}
+	return template, nil
+}
+
+// SaveTemplate saves a cluster template, replacing one of the same name
+// only with replace
+func (m *Manager) SaveTemplate(name string, template ClusterTemplate, replace bool) error {
+	if err := validateTemplateName(name); err != nil {
+		return errdefs.Wrap(errdefs.KindInvalid, err, "invalid template name")
+	}
+	if err := template.Validate(); err != nil {
+		return errdefs.Wrap(errdefs.KindInvalid, err, "invalid template %q", name)
+	}
+
+	return m.update(func(config *Config) error {
+		if _, ok := config.Templates[name]; ok && !replace {
+			return errdefs.AlreadyExists("template %q already exists", name).
+				WithHint("replace it with --force")
+		}
+		if config.Templates == nil {
+			config.Templates = map[string]ClusterTemplate{}
+		}
+		config.Templates[name] = template
+		return nil
+	})
+}
+
+// DeleteTemplate removes a cluster template
+func (m *Manager) DeleteTemplate(name string) error {
+	return m.update(func(config *Config) error {
+		if _, ok := config.Templates[name]; !ok {
+			return errdefs.NotFound("template %q not found", name)
+		}
+		delete(config.Templates, name)
+		return nil
+	})
+}
+
+// FindCluster returns the tracked cluster called name, of provider unless
+// it is empty
+func (m *Manager) FindCluster(name, provider string) (*ClusterInfo, error) {
+	if provider != "" {
+		return m.GetCluster(name, provider)
+	}
+
+	state, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+	var found []ClusterInfo
+	for _, cluster := range state.Clusters {
+		if cluster.Name == name {
+			found = append(found, cluster)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, errdefs.NotFound("cluster %s not found", name).WithHint("blitzctl config list")
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, errdefs.Invalid("there is a %s and a %s cluster called %s", found[0].Provider, found[1].Provider, name).
+			WithHint("pick one with --provider")
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package addons
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+	"github.com/OneideLuizSchneider/blitzctl/internal/helm"
+	"github.com/OneideLuizSchneider/blitzctl/internal/kube"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Ways an addon is installed, recorded with the cluster
+const (
+	MethodManifest = "manifest"
+	MethodHelm     = "helm"
+)
+
+// Addon is a declarative description of a component installed into a
+// cluster after it is created, either a manifest bundle or a Helm chart
+type Addon struct {
+	Name        string
+	Description string
+	// Version pins the manifests or the chart
+	Version string
+	// Embedded are manifests shipped with blitzctl, applied in order before
+	// Manifests
+	Embedded []string
+	// Manifests are URL templates rendered with Version, applied in order
+	Manifests []string
+	// Chart is installed with Helm instead of Manifests when set
+	Chart *Chart
+	// Minikube is the name of minikube's own addon, preferred on minikube
+	// clusters when set
+	Minikube string
+}
+
+// Chart locates the Helm chart of an addon
+type Chart struct {
+	Repo      string
+	Name      string
+	Release   string
+	Namespace string
+	Values    map[string]interface{}
+}
+
+// builtinAddons is the registry of addons blitzctl knows how to install
+var builtinAddons = []*Addon{
+	{
+		Name:        "metrics-server",
+		Description: "Resource metrics for kubectl top and autoscaling",
+		// https://github.com/kubernetes-sigs/metrics-server/releases
+		Version: "3.13.0",
+		Chart: &Chart{
+			Repo:      "https://kubernetes-sigs.github.io/metrics-server/",
+			Name:      "metrics-server",
+			Release:   "metrics-server",
+			Namespace: "kube-system",
+			// Local kubelets serve self-signed certificates
+			Values: map[string]interface{}{"args": []interface{}{"--kubelet-insecure-tls"}},
+		},
+		Minikube: "metrics-server",
+	},
+	{
+		Name:        "ingress-nginx",
+		Description: "NGINX Ingress controller",
+		// https://github.com/kubernetes/ingress-nginx/releases
+		Version:   "1.13.0",
+		Manifests: []string{"https://raw.githubusercontent.com/kubernetes/ingress-nginx/controller-v{{.Version}}/deploy/static/provider/cloud/deploy.yaml"},
+		Minikube:  "ingress",
+	},
+	{
+		Name:        "cert-manager",
+		Description: "X.509 certificate management",
+		// https://github.com/cert-manager/cert-manager/releases
+		Version:   "1.18.2",
+		Manifests: []string{"https://github.com/cert-manager/cert-manager/releases/download/v{{.Version}}/cert-manager.yaml"},
+	},
+	{
+		Name:        "dashboard",
+		Description: "Kubernetes Dashboard web UI",
+		// https://github.com/kubernetes/dashboard/releases
+		Version: "7.13.0",
+		Chart: &Chart{
+			Repo:      "https://kubernetes.github.io/dashboard/",
+			Name:      "kubernetes-dashboard",
+			Release:   "kubernetes-dashboard",
+			Namespace: "kubernetes-dashboard",
+		},
+		Minikube: "dashboard",
+	},
+}
+
+// httpClient downloads manifests
+var httpClient = &http.Client{Timeout: 2 * time.Minute}
+
+// Addons returns the registered addons
+func Addons() []*Addon {
+	return builtinAddons
+}
+
+// Names returns the names of the registered addons, sorted
+func Names() []string {
+	names := make([]string, 0, len(builtinAddons))
+	for _, a := range builtinAddons {
+		names = append(names, a.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Lookup returns the addon called name
+func Lookup(name string) (*Addon, error) {
+	for _, a := range builtinAddons {
+		if a.Name == name {
+			return a, nil
+		}
+	}
+	return nil, errdefs.NotFound("unknown addon %s (available: %s)", name, strings.Join(Names(), ", ")).
+		WithHint("blitzctl addon list")
+}
+
+// Method returns how the addon is installed when the provider has no
+// addon of its own
+func (a *Addon) Method() string {
+	if a.Chart != nil {
+		return MethodHelm
+	}
+	return MethodManifest
+}
+
+// ManifestURLs renders the manifest URLs for Version
+func (a *Addon) ManifestURLs() ([]string, error) {
+	urls := make([]string, 0, len(a.Manifests))
+	for _, text := range a.Manifests {
+		tmpl, err := template.New(a.Name).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest URL for %s: %w", a.Name, err)
+		}
+		var url bytes.Buffer
+		if err := tmpl.Execute(&url, struct{ Version string }{a.Version}); err != nil {
+			return nil, fmt.Errorf("failed to render manifest URL for %s: %w", a.Name, err)
+		}
+		urls = append(urls, url.String())
+	}
+	return urls, nil
+}
+
+// Install installs the addon into the cluster of kubeContext with Helm or
+// its manifests, waiting up to timeout for a chart to be ready
+func (a *Addon) Install(ctx context.Context, kubeContext string, timeout time.Duration) error {
+	if a.Chart != nil {
+		_, err := helm.Upgrade(ctx, kubeContext, a.release(), timeout)
+		return err
+	}
+
+	objects, err := a.objects(ctx)
+	if err != nil {
+		return err
+	}
+	applier, err := kube.NewApplier(kubeContext)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		if err := applier.Apply(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Uninstall removes what Install created, manifest objects in reverse order
+func (a *Addon) Uninstall(ctx context.Context, kubeContext string) error {
+	if a.Chart != nil {
+		return helm.Uninstall(ctx, kubeContext, a.Chart.Release, a.Chart.Namespace)
+	}
+
+	objects, err := a.objects(ctx)
+	if err != nil {
+		return err
+	}
+	applier, err := kube.NewApplier(kubeContext)
+	if err != nil {
+		return err
+	}
+	for _, obj := range slices.Backward(objects) {
+		if err := applier.Delete(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// release describes the Helm release of a chart addon
+func (a *Addon) release() helm.Release {
+	return helm.Release{
+		Name:      a.Chart.Release,
+		Namespace: a.Chart.Namespace,
+		Repo:      a.Chart.Repo,
+		Chart:     a.Chart.Name,
+		Version:   a.Version,
+		Values:    a.Chart.Values,
+	}
+}
+
+// objects reads the embedded manifests of the addon, downloads the others
+// and decodes them
+func (a *Addon) objects(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	urls, err := a.ManifestURLs()
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, name := range a.Embedded {
+		data, err := manifests.ReadFile(path.Join("manifests", name))
+		if err != nil {
+			return nil, fmt.Errorf("missing embedded manifest %s: %w", name, err)
+		}
+		decoded, err := kube.DecodeManifests(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		objects = append(objects, decoded...)
+	}
+	for _, url := range urls {
+		data, err := download(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := kube.DecodeManifests(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", url, err)
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, nil
+}
+
+// download returns the content at url
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return data, nil
+}
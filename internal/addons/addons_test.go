@@ -0,0 +1,160 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package addons
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// configMaps returns a manifest of a ConfigMap for every name
+func configMaps(names ...string) []byte {
+	var manifest strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&manifest, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s\n---\n", name)
+	}
+	return []byte(manifest.String())
+}
+
+func TestManifestURLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		addon   Addon
+		want    []string
+		wantErr bool
+	}{
+		{name: "no manifests", addon: Addon{Name: "chart", Chart: &Chart{}}, want: []string{}},
+		{
+			name:  "version rendered",
+			addon: Addon{Name: "ingress", Version: "1.13.0", Manifests: []string{"https://example.com/v{{.Version}}/deploy.yaml", "https://example.com/crds.yaml"}},
+			want:  []string{"https://example.com/v1.13.0/deploy.yaml", "https://example.com/crds.yaml"},
+		},
+		{name: "invalid template", addon: Addon{Name: "broken", Manifests: []string{"https://example.com/{{.Version"}}, wantErr: true},
+		{name: "unknown field", addon: Addon{Name: "broken", Manifests: []string{"https://example.com/{{.Release}}"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.addon.ManifestURLs()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ManifestURLs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !slices.Equal(got, tt.want) {
+				t.Errorf("ManifestURLs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuiltinAddons(t *testing.T) {
+	for _, addon := range append(slices.Clone(Addons()), CNIs()...) {
+		t.Run(addon.Name, func(t *testing.T) {
+			if addon.Version == "" {
+				t.Error("no version pinned")
+			}
+			if addon.Chart == nil && len(addon.Manifests) == 0 && len(addon.Embedded) == 0 {
+				t.Error("neither a chart nor manifests")
+			}
+			urls, err := addon.ManifestURLs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, url := range urls {
+				if strings.Contains(url, "{{") || !strings.Contains(url, addon.Version) {
+					t.Errorf("manifest URL %s is not rendered for %s", url, addon.Version)
+				}
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name       string
+		lookup     func(name string) (*Addon, error)
+		addon      string
+		wantMethod string
+		wantKind   errdefs.Kind
+	}{
+		{name: "chart addon", lookup: Lookup, addon: "metrics-server", wantMethod: MethodHelm},
+		{name: "manifest addon", lookup: Lookup, addon: "cert-manager", wantMethod: MethodManifest},
+		{name: "unknown addon", lookup: Lookup, addon: "istio", wantKind: errdefs.KindNotFound},
+		{name: "embedded CNI", lookup: LookupCNI, addon: "flannel", wantMethod: MethodManifest},
+		{name: "unknown CNI", lookup: LookupCNI, addon: "weave", wantKind: errdefs.KindNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addon, err := tt.lookup(tt.addon)
+			if got := errdefs.KindOf(err); got != tt.wantKind {
+				t.Fatalf("lookup(%s) error = %v, want kind %q", tt.addon, err, tt.wantKind)
+			}
+			if err == nil && addon.Method() != tt.wantMethod {
+				t.Errorf("Method() = %s, want %s", addon.Method(), tt.wantMethod)
+			}
+		})
+	}
+}
+
+func TestObjects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.0.0/first.yaml":
+			_, _ = w.Write(configMaps("first", "second"))
+		case "/v1.0.0/third.yaml":
+			_, _ = w.Write(configMaps("third"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		addon     Addon
+		wantFirst string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:  "downloaded in order",
+			addon: Addon{Name: "test", Version: "1.0.0", Manifests: []string{server.URL + "/v{{.Version}}/first.yaml", server.URL + "/v{{.Version}}/third.yaml"}},
+			want:  []string{"first", "second", "third"},
+		},
+		{
+			name:      "embedded before downloaded",
+			addon:     Addon{Name: "test", Version: "1.0.0", Embedded: []string{"flannel.yaml"}, Manifests: []string{server.URL + "/v{{.Version}}/third.yaml"}},
+			wantFirst: "kube-flannel",
+		},
+		{name: "missing embedded manifest", addon: Addon{Name: "test", Embedded: []string{"weave.yaml"}}, wantErr: true},
+		{name: "download fails", addon: Addon{Name: "test", Version: "2.0.0", Manifests: []string{server.URL + "/v{{.Version}}/first.yaml"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, err := tt.addon.objects(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("objects() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var names []string
+			for _, obj := range objects {
+				names = append(names, obj.GetName())
+			}
+			if tt.want != nil && !slices.Equal(names, tt.want) {
+				t.Errorf("objects() = %v, want %v", names, tt.want)
+			}
+			if tt.wantFirst != "" && (names[0] != tt.wantFirst || names[len(names)-1] != "third") {
+				t.Errorf("objects() = %v, want the embedded %s first and the downloaded last", names, tt.wantFirst)
+			}
+		})
+	}
+}
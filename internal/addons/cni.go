@@ -0,0 +1,97 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package addons
+
+import (
+	"embed"
+	"slices"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// KindPodSubnet is the pod network kind assigns by default, the CNIs below
+// are configured for it
+const KindPodSubnet = "10.244.0.0/16"
+
+// manifests are the manifests shipped with blitzctl
+//
+//go:embed manifests
+var manifests embed.FS
+
+// builtinCNIs are the CNIs blitzctl installs on clusters created without
+// one, set up for kind nodes
+var builtinCNIs = []*Addon{
+	{
+		Name:        "cilium",
+		Description: "eBPF based networking and network policies",
+		// https://github.com/cilium/cilium/releases
+		Version: "1.18.2",
+		Chart: &Chart{
+			Repo:      "https://helm.cilium.io/",
+			Name:      "cilium",
+			Release:   "cilium",
+			Namespace: "kube-system",
+			// Pod CIDRs come from the nodes, images loaded with kind load are used
+			Values: map[string]interface{}{
+				"ipam":     map[string]interface{}{"mode": "kubernetes"},
+				"image":    map[string]interface{}{"pullPolicy": "IfNotPresent"},
+				"operator": map[string]interface{}{"replicas": 1},
+			},
+		},
+	},
+	{
+		Name:        "calico",
+		Description: "Calico networking and network policies, through the Tigera operator",
+		// https://github.com/projectcalico/calico/releases
+		Version: "v3.30.3",
+		Chart: &Chart{
+			Repo:      "https://docs.tigera.io/calico/charts",
+			Name:      "tigera-operator",
+			Release:   "calico",
+			Namespace: "tigera-operator",
+			Values: map[string]interface{}{
+				"installation": map[string]interface{}{
+					"calicoNetwork": map[string]interface{}{
+						"ipPools": []interface{}{
+							map[string]interface{}{"cidr": KindPodSubnet},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Name:        "flannel",
+		Description: "Flannel VXLAN overlay",
+		// https://github.com/flannel-io/flannel/releases
+		Version:  "0.27.0",
+		Embedded: []string{"flannel.yaml"},
+	},
+}
+
+// CNIs returns the registered CNIs
+func CNIs() []*Addon {
+	return builtinCNIs
+}
+
+// CNINames returns the names of the registered CNIs, sorted
+func CNINames() []string {
+	names := make([]string, 0, len(builtinCNIs))
+	for _, c := range builtinCNIs {
+		names = append(names, c.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// LookupCNI returns the CNI called name
+func LookupCNI(name string) (*Addon, error) {
+	for _, c := range builtinCNIs {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return nil, errdefs.NotFound("unknown CNI %s (available: %s)", name, strings.Join(CNINames(), ", "))
+}
@@ -0,0 +1,174 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/kube"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// Load reads the objects of the manifests under dir. A directory with a
+// kustomization file is built with kustomize, any other directory has its
+// YAML files and subdirectories read in lexical order.
+func Load(dir string) ([]*unstructured.Unstructured, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	if IsKustomization(dir) {
+		return build(dir)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var objects []*unstructured.Unstructured
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.IsDir() {
+			loaded, err := Load(path)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, loaded...)
+			continue
+		}
+
+		if ext := filepath.Ext(entry.Name()); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := kube.DecodeManifests(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, nil
+}
+
+// IsKustomization reports whether dir holds a kustomization file
+func IsKustomization(dir string) bool {
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// build runs kustomize build on dir
+func build(dir string) ([]*unstructured.Unstructured, error) {
+	resources, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("kustomize build %s: %w", dir, err)
+	}
+	data, err := resources.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("kustomize build %s: %w", dir, err)
+	}
+	objects, err := kube.DecodeManifests(data)
+	if err != nil {
+		return nil, fmt.Errorf("kustomize build %s: %w", dir, err)
+	}
+	return objects, nil
+}
+
+// Apply server-side applies objects to the cluster of kubeContext and writes
+// the result of each to out. CustomResourceDefinitions and Namespaces go
+// first, every CRD is waited on for up to timeout to be established before
+// the objects that may depend on it, the rest keep their order. Every object
+// is tried, the error counts the ones that failed.
+func Apply(ctx context.Context, kubeContext string, objects []*unstructured.Unstructured, timeout time.Duration, out io.Writer) error {
+	applier, err := kube.NewApplier(kubeContext)
+	if err != nil {
+		return err
+	}
+
+	ordered := order(objects)
+	failed := 0
+	var crds []string
+	for i, obj := range ordered {
+		if err := applier.Apply(ctx, obj); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			_, _ = fmt.Fprintf(out, "  ❌ %s: %s\n", kube.Describe(obj), unwrapApply(err))
+			failed++
+		} else {
+			_, _ = fmt.Fprintf(out, "  ✅ %s\n", kube.Describe(obj))
+			if kube.IsCRD(obj) {
+				crds = append(crds, obj.GetName())
+			}
+		}
+
+		// CRDs come first, they are waited on together after the last one
+		if kube.IsCRD(obj) && (i+1 == len(ordered) || !kube.IsCRD(ordered[i+1])) {
+			for _, name := range crds {
+				if err := applier.WaitEstablished(ctx, name, timeout); err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					_, _ = fmt.Fprintf(out, "  ❌ %s\n", err)
+					failed++
+				}
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d objects failed", failed, len(ordered))
+	}
+	return nil
+}
+
+// order moves CustomResourceDefinitions, then Namespaces, ahead of the other
+// objects, keeping the order within each group
+func order(objects []*unstructured.Unstructured) []*unstructured.Unstructured {
+	rank := func(obj *unstructured.Unstructured) int {
+		switch {
+		case kube.IsCRD(obj):
+			return 0
+		case obj.GetKind() == "Namespace" && obj.GroupVersionKind().Group == "":
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	ordered := slices.Clone(objects)
+	slices.SortStableFunc(ordered, func(a, b *unstructured.Unstructured) int {
+		return rank(a) - rank(b)
+	})
+	return ordered
+}
+
+// unwrapApply drops the "failed to apply <object>" prefix the result line
+// already says
+func unwrapApply(err error) string {
+	if inner := errors.Unwrap(err); inner != nil {
+		return inner.Error()
+	}
+	return err.Error()
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// object returns an object of kind in apiVersion named name
+func object(apiVersion, kind, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	return obj
+}
+
+// names returns the names of objects, in order
+func names(objects []*unstructured.Unstructured) []string {
+	var names []string
+	for _, obj := range objects {
+		names = append(names, obj.GetName())
+	}
+	return names
+}
+
+func TestOrder(t *testing.T) {
+	crd := func(name string) *unstructured.Unstructured {
+		return object("apiextensions.k8s.io/v1", "CustomResourceDefinition", name)
+	}
+	namespace := func(name string) *unstructured.Unstructured {
+		return object("v1", "Namespace", name)
+	}
+	deployment := func(name string) *unstructured.Unstructured {
+		return object("apps/v1", "Deployment", name)
+	}
+
+	tests := []struct {
+		name    string
+		objects []*unstructured.Unstructured
+		want    []string
+	}{
+		{name: "empty"},
+		{
+			name:    "already ordered",
+			objects: []*unstructured.Unstructured{crd("crd"), namespace("ns"), deployment("app")},
+			want:    []string{"crd", "ns", "app"},
+		},
+		{
+			name:    "CRDs, then namespaces first",
+			objects: []*unstructured.Unstructured{deployment("app"), namespace("ns"), crd("crd")},
+			want:    []string{"crd", "ns", "app"},
+		},
+		{
+			name: "order kept within each group",
+			objects: []*unstructured.Unstructured{
+				deployment("app1"), namespace("ns1"), crd("crd1"),
+				deployment("app2"), namespace("ns2"), crd("crd2"),
+			},
+			want: []string{"crd1", "crd2", "ns1", "ns2", "app1", "app2"},
+		},
+		{
+			name: "same kinds of other groups are not moved",
+			objects: []*unstructured.Unstructured{
+				deployment("app"),
+				object("example.com/v1", "Namespace", "custom-ns"),
+				object("example.com/v1", "CustomResourceDefinition", "custom-crd"),
+				namespace("ns"),
+			},
+			want: []string{"ns", "app", "custom-ns", "custom-crd"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := names(tt.objects)
+			got := names(order(tt.objects))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("order() = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(names(tt.objects), before) {
+				t.Errorf("order() reordered its argument to %v", names(tt.objects))
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"b-app.yaml":        "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n",
+		"a-namespace.yml":   "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: ns\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n",
+		"c-nested/crd.yaml": "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: crd\n",
+		"README.md":         "not a manifest\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	objects, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got, want := names(objects), []string{"ns", "settings", "app", "crd"}; !slices.Equal(got, want) {
+		t.Errorf("Load() = %v, want %v in lexical file order", got, want)
+	}
+
+	if _, err := Load(filepath.Join(dir, "b-app.yaml")); err == nil {
+		t.Error("Load() of a file succeeded, want an error")
+	}
+}
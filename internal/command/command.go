@@ -0,0 +1,157 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// stderrTail is how much of a command's stderr is kept for error reports
+const stderrTail = 4096
+
+// InterruptGrace is how long a cancelled command has to exit after being
+// interrupted before it is killed
+var InterruptGrace = 10 * time.Second
+
+// Context returns a command that is interrupted, rather than killed, when ctx
+// is cancelled so provider CLIs get a chance to clean up after themselves.
+// It is killed if it has not exited after InterruptGrace.
+func Context(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Cancel = func() error {
+		if err := cmd.Process.Signal(os.Interrupt); err != nil {
+			// Interrupt is not supported on every platform (e.g. Windows)
+			return cmd.Process.Kill()
+		}
+		return nil
+	}
+	cmd.WaitDelay = InterruptGrace
+	return cmd
+}
+
+// Run runs cmd, keeping the tail of its stderr while still streaming it to
+// cmd.Stderr, and returns an *errdefs.CommandError when it fails
+func Run(cmd *exec.Cmd) error {
+	tail := &tailBuffer{max: stderrTail}
+	if cmd.Stderr == nil {
+		cmd.Stderr = tail
+	} else {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, tail)
+	}
+
+	start := logStart(cmd)
+	err := cmd.Run()
+	logFinish(cmd, start, err)
+	if err != nil {
+		return newError(cmd, err, tail.String())
+	}
+	return nil
+}
+
+// Output runs cmd and returns its stdout, or an *errdefs.CommandError
+// carrying the captured stderr when it fails
+func Output(cmd *exec.Cmd) ([]byte, error) {
+	tail := &tailBuffer{max: stderrTail}
+	if cmd.Stderr == nil {
+		cmd.Stderr = tail
+	} else {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, tail)
+	}
+
+	start := logStart(cmd)
+	output, err := cmd.Output()
+	logFinish(cmd, start, err)
+	if err != nil {
+		return output, newError(cmd, err, tail.String())
+	}
+	return output, nil
+}
+
+// CombinedOutput runs cmd and returns its stdout and stderr interleaved, or
+// an *errdefs.CommandError when it fails
+func CombinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	start := logStart(cmd)
+	err := cmd.Run()
+	logFinish(cmd, start, err)
+	if err != nil {
+		return output.Bytes(), newError(cmd, err, output.String())
+	}
+	return output.Bytes(), nil
+}
+
+func logStart(cmd *exec.Cmd) time.Time {
+	slog.Debug("running command", "args", cmd.Args)
+	return time.Now()
+}
+
+func logFinish(cmd *exec.Cmd, start time.Time, err error) {
+	exitCode := 0
+	if cmd.ProcessState != nil {
+		exitCode = cmd.ProcessState.ExitCode()
+	} else if err != nil {
+		exitCode = -1
+	}
+
+	attrs := []any{
+		"args", cmd.Args,
+		"duration", time.Since(start).Round(time.Millisecond),
+		"exit_code", exitCode,
+	}
+	if err != nil && exitCode == -1 {
+		attrs = append(attrs, "error", err)
+	}
+	slog.Debug("command finished", attrs...)
+}
+
+func newError(cmd *exec.Cmd, err error, stderr string) *errdefs.CommandError {
+	exitCode := -1
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	}
+	return &errdefs.CommandError{
+		Args:     cmd.Args,
+		ExitCode: exitCode,
+		Stderr:   stderr,
+		Err:      err,
+	}
+}
+
+// tailBuffer keeps the last max bytes written to it
+type tailBuffer struct {
+	mu   sync.Mutex
+	max  int
+	data []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data = append(b.data, p...)
+	if len(b.data) > b.max {
+		b.data = b.data[len(b.data)-b.max:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.data)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package command
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+func TestContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupting relies on SIGINT")
+	}
+	grace := InterruptGrace
+	t.Cleanup(func() { InterruptGrace = grace })
+
+	tests := []struct {
+		name string
+		// script runs under sh, ready is printed once it traps SIGINT
+		script       string
+		grace        time.Duration
+		wantExitCode int
+		wantStderr   string
+	}{
+		{
+			name:         "interrupted command cleans up",
+			script:       `trap 'echo cleaned up >&2; exit 3' INT; echo ready; while :; do sleep 0.01; done`,
+			grace:        5 * time.Second,
+			wantExitCode: 3,
+			wantStderr:   "cleaned up",
+		},
+		{
+			name:         "command ignoring the interrupt is killed",
+			script:       `trap '' INT; echo ready; while :; do sleep 0.01; done`,
+			grace:        100 * time.Millisecond,
+			wantExitCode: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			InterruptGrace = tt.grace
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cmd := Context(ctx, "sh", "-c", tt.script)
+			stdout, err := cmd.StdoutPipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			done := make(chan error, 1)
+			go func() { done <- Run(cmd) }()
+
+			// Cancel once the trap is installed
+			buf := make([]byte, len("ready"))
+			if _, err := stdout.Read(buf); err != nil {
+				t.Fatal(err)
+			}
+			cancel()
+
+			select {
+			case err = <-done:
+			case <-time.After(10 * time.Second):
+				t.Fatal("command still running after being canceled")
+			}
+			var commandErr *errdefs.CommandError
+			if !errors.As(err, &commandErr) {
+				t.Fatalf("Run() error = %v, want a CommandError", err)
+			}
+			if commandErr.ExitCode != tt.wantExitCode {
+				t.Errorf("exit code = %d, want %d", commandErr.ExitCode, tt.wantExitCode)
+			}
+			if !strings.Contains(commandErr.Stderr, tt.wantStderr) {
+				t.Errorf("stderr = %q, want %q", commandErr.Stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found")
+	}
+
+	tests := []struct {
+		name         string
+		script       string
+		wantErr      bool
+		wantExitCode int
+		wantMessage  string
+	}{
+		{name: "success", script: "exit 0"},
+		{name: "failure", script: "echo first >&2; echo 'last line' >&2; exit 2", wantErr: true, wantExitCode: 2, wantMessage: "sh exited with code 2: last line"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Run(exec.Command("sh", "-c", tt.script))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			var commandErr *errdefs.CommandError
+			if !errors.As(err, &commandErr) || commandErr.ExitCode != tt.wantExitCode {
+				t.Errorf("Run() error = %#v, want exit code %d", err, tt.wantExitCode)
+			}
+			if err.Error() != tt.wantMessage {
+				t.Errorf("Run() error = %q, want %q", err, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestTailBuffer(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{name: "under the limit", writes: []string{"ab", "cd"}, want: "abcd"},
+		{name: "keeps the tail", writes: []string{"abcd", "efgh"}, want: "cdefgh"},
+		{name: "one large write", writes: []string{"abcdefghij"}, want: "efghij"},
+	}
+	for _, tt := range tests {
+		b := &tailBuffer{max: 6}
+		for _, write := range tt.writes {
+			if n, err := b.Write([]byte(write)); n != len(write) || err != nil {
+				t.Fatalf("Write(%q) = %d, %v", write, n, err)
+			}
+		}
+		if got := b.String(); got != tt.want {
+			t.Errorf("%s: String() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/command"
+)
+
+// Engine runs the docker or podman CLI, both take the same arguments for
+// what blitzctl does with node containers
+type Engine struct {
+	Bin string
+}
+
+// New returns the engine running bin, docker or podman
+func New(bin string) *Engine {
+	return &Engine{Bin: bin}
+}
+
+// Container is the part of the inspect output needed to create the same
+// container again
+type Container struct {
+	Name   string `json:"Name"`
+	Config struct {
+		Hostname string            `json:"Hostname"`
+		Env      []string          `json:"Env"`
+		Labels   map[string]string `json:"Labels"`
+		Tty      bool              `json:"Tty"`
+	} `json:"Config"`
+	HostConfig struct {
+		Privileged    bool                     `json:"Privileged"`
+		SecurityOpt   []string                 `json:"SecurityOpt"`
+		Tmpfs         map[string]string        `json:"Tmpfs"`
+		Binds         []string                 `json:"Binds"`
+		PortBindings  map[string][]PortBinding `json:"PortBindings"`
+		RestartPolicy struct {
+			Name              string `json:"Name"`
+			MaximumRetryCount int    `json:"MaximumRetryCount"`
+		} `json:"RestartPolicy"`
+		CgroupnsMode string   `json:"CgroupnsMode"`
+		Devices      []Device `json:"Devices"`
+		Init         *bool    `json:"Init"`
+		Memory       int64    `json:"Memory"`
+		NanoCpus     int64    `json:"NanoCpus"`
+	} `json:"HostConfig"`
+	Mounts          []Mount `json:"Mounts"`
+	NetworkSettings struct {
+		Networks map[string]Endpoint `json:"Networks"`
+	} `json:"NetworkSettings"`
+	State struct {
+		Running bool `json:"Running"`
+	} `json:"State"`
+}
+
+// PortBinding publishes a container port on the host
+type PortBinding struct {
+	HostIP   string `json:"HostIp"`
+	HostPort string `json:"HostPort"`
+}
+
+// Device is a host device added to the container
+type Device struct {
+	PathOnHost        string `json:"PathOnHost"`
+	PathInContainer   string `json:"PathInContainer"`
+	CgroupPermissions string `json:"CgroupPermissions"`
+}
+
+// Mount is a volume or bind mount of the container
+type Mount struct {
+	Type        string `json:"Type"`
+	Name        string `json:"Name"`
+	Source      string `json:"Source"`
+	Destination string `json:"Destination"`
+	RW          bool   `json:"RW"`
+}
+
+// Endpoint is the connection of the container to a network
+type Endpoint struct {
+	IPAMConfig *struct {
+		IPv4Address string `json:"IPv4Address"`
+		IPv6Address string `json:"IPv6Address"`
+	} `json:"IPAMConfig"`
+}
+
+// Network is the part of the network inspect output needed to create the
+// same network again. Docker and podman describe subnets differently.
+type Network struct {
+	Name       string            `json:"Name"`
+	Driver     string            `json:"Driver"`
+	EnableIPv6 bool              `json:"EnableIPv6"`
+	Options    map[string]string `json:"Options"`
+	Labels     map[string]string `json:"Labels"`
+	IPAM       struct {
+		Config []Subnet `json:"Config"`
+	} `json:"IPAM"`
+	// Podman
+	PodmanName    string   `json:"name"`
+	PodmanDriver  string   `json:"driver"`
+	PodmanIPv6    bool     `json:"ipv6_enabled"`
+	PodmanSubnets []Subnet `json:"subnets"`
+}
+
+// Subnet is an address range of a network
+type Subnet struct {
+	Subnet  string `json:"Subnet"`
+	Gateway string `json:"Gateway"`
+}
+
+// anonymousVolume matches the generated names of anonymous volumes
+var anonymousVolume = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Inspect returns the configuration of a container
+func (e *Engine) Inspect(ctx context.Context, name string) (*Container, error) {
+	output, err := command.Output(command.Context(ctx, e.Bin, "container", "inspect", name))
+	if err != nil {
+		return nil, err
+	}
+	var containers []*Container
+	if err := json.Unmarshal(output, &containers); err != nil {
+		return nil, fmt.Errorf("failed to parse %s inspect output: %w", e.Bin, err)
+	}
+	if len(containers) != 1 {
+		return nil, fmt.Errorf("%s inspect returned %d containers for %s", e.Bin, len(containers), name)
+	}
+	container := containers[0]
+	container.Name = strings.TrimPrefix(container.Name, "/")
+	return container, nil
+}
+
+// Stop stops containers
+func (e *Engine) Stop(ctx context.Context, names ...string) error {
+	return e.run(ctx, append([]string{"stop"}, names...)...)
+}
+
+// Start starts containers in order
+func (e *Engine) Start(ctx context.Context, names ...string) error {
+	for _, name := range names {
+		if err := e.run(ctx, "start", name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove removes containers along with their anonymous volumes
+func (e *Engine) Remove(ctx context.Context, names ...string) error {
+	return e.run(ctx, append([]string{"rm", "--force", "--volumes"}, names...)...)
+}
+
+// Commit saves the filesystem of a container as image. Volumes are not part
+// of it, see Export.
+func (e *Engine) Commit(ctx context.Context, name, image string) error {
+	return e.run(ctx, "commit", name, image)
+}
+
+// RemoveImage removes images
+func (e *Engine) RemoveImage(ctx context.Context, images ...string) error {
+	return e.run(ctx, append([]string{"rmi", "--force"}, images...)...)
+}
+
+// Export writes a tar archive of path in a container, volumes included, to w
+func (e *Engine) Export(ctx context.Context, name, path string, w io.Writer) error {
+	cmd := command.Context(ctx, e.Bin, "cp", name+":"+path, "-")
+	cmd.Stdout = w
+	return command.Run(cmd)
+}
+
+// Import extracts a tar archive read from r into dir in a container
+func (e *Engine) Import(ctx context.Context, name, dir string, r io.Reader) error {
+	cmd := command.Context(ctx, e.Bin, "cp", "-", name+":"+dir)
+	cmd.Stdin = r
+	return command.Run(cmd)
+}
+
+// Create creates, without starting it, a container from image configured
+// like c
+func (e *Engine) Create(ctx context.Context, c *Container, image string) error {
+	return e.run(ctx, append([]string{"create"}, CreateArgs(c, image)...)...)
+}
+
+// CreateArgs returns the create arguments for a container from image
+// configured like c
+func CreateArgs(c *Container, image string) []string {
+	args := []string{"--name", c.Name}
+	if c.Config.Hostname != "" {
+		args = append(args, "--hostname", c.Config.Hostname)
+	}
+	if c.Config.Tty {
+		args = append(args, "--tty")
+	}
+	for _, key := range sortedKeys(c.Config.Labels) {
+		args = append(args, "--label", key+"="+c.Config.Labels[key])
+	}
+	for _, env := range c.Config.Env {
+		args = append(args, "--env", env)
+	}
+
+	host := c.HostConfig
+	if host.Privileged {
+		args = append(args, "--privileged")
+	}
+	for _, opt := range host.SecurityOpt {
+		args = append(args, "--security-opt", opt)
+	}
+	for _, path := range sortedKeys(host.Tmpfs) {
+		if opts := host.Tmpfs[path]; opts != "" {
+			path += ":" + opts
+		}
+		args = append(args, "--tmpfs", path)
+	}
+	for _, bind := range host.Binds {
+		args = append(args, "--volume", bind)
+	}
+	for _, mount := range c.Mounts {
+		switch {
+		case mount.Type != "volume":
+			// Bind mounts come from Binds
+		case anonymousVolume.MatchString(mount.Name):
+			args = append(args, "--volume", mount.Destination)
+		default:
+			args = append(args, "--volume", mount.Name+":"+mount.Destination)
+		}
+	}
+	for _, port := range sortedKeys(host.PortBindings) {
+		for _, binding := range host.PortBindings[port] {
+			args = append(args, "--publish", binding.HostIP+":"+binding.HostPort+":"+port)
+		}
+	}
+	if host.RestartPolicy.Name != "" && host.RestartPolicy.Name != "no" {
+		policy := host.RestartPolicy.Name
+		if host.RestartPolicy.MaximumRetryCount > 0 {
+			policy += fmt.Sprintf(":%d", host.RestartPolicy.MaximumRetryCount)
+		}
+		args = append(args, "--restart", policy)
+	}
+	if host.CgroupnsMode != "" {
+		args = append(args, "--cgroupns", host.CgroupnsMode)
+	}
+	for _, device := range host.Devices {
+		spec := device.PathOnHost + ":" + device.PathInContainer
+		if device.CgroupPermissions != "" {
+			spec += ":" + device.CgroupPermissions
+		}
+		args = append(args, "--device", spec)
+	}
+	if host.Init != nil && *host.Init {
+		args = append(args, "--init")
+	}
+	if host.Memory > 0 {
+		args = append(args, fmt.Sprintf("--memory=%db", host.Memory))
+	}
+	if host.NanoCpus > 0 {
+		args = append(args, fmt.Sprintf("--cpus=%g", float64(host.NanoCpus)/1e9))
+	}
+
+	// A container joins a single network on create, the nodes have one
+	if networks := c.Networks(); len(networks) > 0 {
+		args = append(args, "--network", networks[0])
+		if ipam := c.NetworkSettings.Networks[networks[0]].IPAMConfig; ipam != nil {
+			if ipam.IPv4Address != "" {
+				args = append(args, "--ip", ipam.IPv4Address)
+			}
+			if ipam.IPv6Address != "" {
+				args = append(args, "--ip6", ipam.IPv6Address)
+			}
+		}
+	}
+	return append(args, image)
+}
+
+// Networks returns the names of the networks a container is connected to
+func (c *Container) Networks() []string {
+	return sortedKeys(c.NetworkSettings.Networks)
+}
+
+// InspectNetwork returns the configuration of a network
+func (e *Engine) InspectNetwork(ctx context.Context, name string) (*Network, error) {
+	output, err := command.Output(command.Context(ctx, e.Bin, "network", "inspect", name))
+	if err != nil {
+		return nil, err
+	}
+	var networks []*Network
+	if err := json.Unmarshal(output, &networks); err != nil {
+		return nil, fmt.Errorf("failed to parse %s network inspect output: %w", e.Bin, err)
+	}
+	if len(networks) != 1 {
+		return nil, fmt.Errorf("%s network inspect returned %d networks for %s", e.Bin, len(networks), name)
+	}
+	network := networks[0]
+	if network.Name == "" {
+		network.Name, network.Driver, network.EnableIPv6 = network.PodmanName, network.PodmanDriver, network.PodmanIPv6
+		network.IPAM.Config = network.PodmanSubnets
+	}
+	return network, nil
+}
+
+// NetworkExists reports whether a network exists
+func (e *Engine) NetworkExists(ctx context.Context, name string) bool {
+	return e.run(ctx, "network", "inspect", name) == nil
+}
+
+// CreateNetwork creates a network configured like n
+func (e *Engine) CreateNetwork(ctx context.Context, n *Network) error {
+	args := []string{"network", "create"}
+	if n.Driver != "" {
+		args = append(args, "--driver", n.Driver)
+	}
+	if n.EnableIPv6 {
+		args = append(args, "--ipv6")
+	}
+	for _, subnet := range n.IPAM.Config {
+		if subnet.Subnet == "" {
+			continue
+		}
+		args = append(args, "--subnet", subnet.Subnet)
+		if subnet.Gateway != "" {
+			args = append(args, "--gateway", subnet.Gateway)
+		}
+	}
+	for _, key := range sortedKeys(n.Options) {
+		args = append(args, "--opt", key+"="+n.Options[key])
+	}
+	for _, key := range sortedKeys(n.Labels) {
+		args = append(args, "--label", key+"="+n.Labels[key])
+	}
+	return e.run(ctx, append(args, n.Name)...)
+}
+
+// run runs the engine, its stderr kept for the error
+func (e *Engine) run(ctx context.Context, args ...string) error {
+	_, err := command.Output(command.Context(ctx, e.Bin, args...))
+	return err
+}
+
+// sortedKeys returns the keys of m in order, for reproducible arguments
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,329 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/command"
+)
+
+// Thresholds used by the built-in checks
+const (
+	// kind recommends these for multi-node clusters:
+	// https://kind.sigs.k8s.io/docs/user/known-issues/#pod-errors-due-to-too-many-open-files
+	MinInotifyWatches   = 524288
+	MinInotifyInstances = 512
+
+	DiskWarnBytes = 10 << 30
+	DiskFailBytes = 2 << 30
+
+	MemoryWarnBytes = 4 << 30
+	MemoryFailBytes = 2 << 30
+)
+
+// CommonPorts are host ports local clusters usually need
+var CommonPorts = []int{80, 443, 6443, 8443}
+
+func init() {
+	Register(
+		NewCheck("container-engine", checkContainerEngine),
+		NewCheck("cgroup", checkCgroup),
+		NewCheck("inotify", checkInotify),
+		NewCheck("disk", checkDisk),
+		NewCheck("memory", checkMemory),
+		NewCheck("ports", checkPorts),
+		NewCheck("kubeconfig", checkKubeconfig),
+	)
+}
+
+// checkContainerEngine verifies that at least one of docker or podman is
+// installed and its daemon answers
+func checkContainerEngine(ctx context.Context) Result {
+	var found, reachable, problems []string
+
+	for _, engine := range []string{"docker", "podman"} {
+		if _, err := exec.LookPath(engine); err != nil {
+			continue
+		}
+		found = append(found, engine)
+
+		if err := EngineReachable(ctx, engine); err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		reachable = append(reachable, engine)
+	}
+
+	switch {
+	case len(found) == 0:
+		return Result{
+			Status:  Fail,
+			Message: "neither docker nor podman is installed",
+			Hint:    "blitzctl install container --driver docker",
+		}
+	case len(reachable) == 0:
+		return Result{
+			Status:  Fail,
+			Message: "daemon not reachable (" + strings.Join(problems, "; ") + ")",
+			Hint:    "start Docker Desktop, run 'sudo systemctl start docker', or 'podman machine start'",
+		}
+	default:
+		return Result{Status: Pass, Message: strings.Join(reachable, ", ") + " reachable"}
+	}
+}
+
+// EngineReachable reports whether the docker or podman daemon answers
+func EngineReachable(ctx context.Context, engine string) error {
+	if output, err := command.CombinedOutput(exec.CommandContext(ctx, engine, "info")); err != nil {
+		return fmt.Errorf("%s: %s", engine, firstLine(string(output), err))
+	}
+	return nil
+}
+
+// checkCgroup reports the cgroup version, kind and minikube work best on v2
+func checkCgroup(ctx context.Context) Result {
+	if runtime.GOOS != "linux" {
+		return Result{Status: Skip, Message: "only relevant on Linux (engines run in a VM on " + runtime.GOOS + ")"}
+	}
+	if CgroupVersion() == 2 {
+		return Result{Status: Pass, Message: "cgroup v2"}
+	}
+	return Result{
+		Status:  Warn,
+		Message: "cgroup v1",
+		Hint:    "newer Kubernetes releases deprecate cgroup v1; boot with systemd.unified_cgroup_hierarchy=1",
+	}
+}
+
+// CgroupVersion returns the cgroup version of the host, 2 when the unified
+// hierarchy is mounted. It is only meaningful on Linux.
+func CgroupVersion() int {
+	if _, err := os.Stat("/sys/fs/cgroup/cgroup.controllers"); err == nil {
+		return 2
+	}
+	return 1
+}
+
+// checkInotify catches the classic "too many open files" failure of
+// multi-node kind clusters
+func checkInotify(ctx context.Context) Result {
+	if runtime.GOOS != "linux" {
+		return Result{Status: Skip, Message: "only relevant on Linux"}
+	}
+
+	watches, errW := readSysctl("/proc/sys/fs/inotify/max_user_watches")
+	instances, errI := readSysctl("/proc/sys/fs/inotify/max_user_instances")
+	if errW != nil || errI != nil {
+		return Result{Status: Skip, Message: "could not read inotify limits"}
+	}
+
+	message := fmt.Sprintf("max_user_watches=%d, max_user_instances=%d", watches, instances)
+	if watches < MinInotifyWatches || instances < MinInotifyInstances {
+		return Result{
+			Status:  Warn,
+			Message: message,
+			Hint: fmt.Sprintf("sudo sysctl fs.inotify.max_user_watches=%d fs.inotify.max_user_instances=%d",
+				MinInotifyWatches, MinInotifyInstances),
+		}
+	}
+	return Result{Status: Pass, Message: message}
+}
+
+// checkDisk verifies there is room for node images and volumes
+func checkDisk(ctx context.Context) Result {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return Result{Status: Skip, Message: err.Error()}
+	}
+
+	free, err := freeBytes(home)
+	if err != nil {
+		return Result{Status: Skip, Message: fmt.Sprintf("could not read free space: %v", err)}
+	}
+
+	message := fmt.Sprintf("%.1f GiB free in %s", float64(free)/(1<<30), home)
+	hint := "free up space, e.g. 'docker system prune' or 'minikube delete --all'"
+	switch {
+	case free < DiskFailBytes:
+		return Result{Status: Fail, Message: message, Hint: hint}
+	case free < DiskWarnBytes:
+		return Result{Status: Warn, Message: message, Hint: hint}
+	default:
+		return Result{Status: Pass, Message: message}
+	}
+}
+
+// checkMemory verifies there is enough available memory for a cluster node
+func checkMemory(ctx context.Context) Result {
+	if runtime.GOOS != "linux" {
+		return Result{Status: Skip, Message: "only checked on Linux (engines run in a VM on " + runtime.GOOS + ")"}
+	}
+
+	available, err := availableMemory()
+	if err != nil {
+		return Result{Status: Skip, Message: fmt.Sprintf("could not read available memory: %v", err)}
+	}
+
+	message := fmt.Sprintf("%.1f GiB available", float64(available)/(1<<30))
+	hint := "close memory hungry applications or delete clusters you no longer use"
+	switch {
+	case available < MemoryFailBytes:
+		return Result{Status: Fail, Message: message, Hint: hint}
+	case available < MemoryWarnBytes:
+		return Result{Status: Warn, Message: message, Hint: hint}
+	default:
+		return Result{Status: Pass, Message: message}
+	}
+}
+
+// availableMemory returns MemAvailable from /proc/meminfo in bytes
+func availableMemory() (uint64, error) {
+	return meminfo("MemAvailable")
+}
+
+// TotalMemory returns the physical memory of a Linux host in bytes
+func TotalMemory() (uint64, error) {
+	return meminfo("MemTotal")
+}
+
+// meminfo returns the /proc/meminfo entry called key in bytes
+func meminfo(key string) (uint64, error) {
+	data, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == key+":" {
+			kb, err := strconv.ParseUint(fields[1], 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return kb << 10, nil
+		}
+	}
+	return 0, fmt.Errorf("%s not found in /proc/meminfo", key)
+}
+
+// checkPorts reports common cluster ports already taken on the host
+func checkPorts(ctx context.Context) Result {
+	var busy []string
+	for _, port := range CommonPorts {
+		if !PortAvailable(port) {
+			busy = append(busy, strconv.Itoa(port))
+		}
+	}
+
+	if len(busy) > 0 {
+		return Result{
+			Status:  Warn,
+			Message: "ports in use: " + strings.Join(busy, ", "),
+			Hint:    "stop the process using them or map different host ports for ingress and the API server",
+		}
+	}
+	return Result{Status: Pass, Message: "ports available: " + joinInts(CommonPorts)}
+}
+
+// PortAvailable reports whether port can be bound on the host
+func PortAvailable(port int) bool {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	_ = l.Close()
+	return true
+}
+
+// checkKubeconfig verifies the providers can write cluster credentials
+func checkKubeconfig(ctx context.Context) Result {
+	path := KubeconfigPath()
+	if path == "" {
+		return Result{Status: Fail, Message: "could not determine kubeconfig path", Hint: "set KUBECONFIG"}
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			return Result{
+				Status:  Fail,
+				Message: fmt.Sprintf("%s is not writable: %v", path, err),
+				Hint:    "sudo chown $(id -u):$(id -g) " + path,
+			}
+		}
+		_ = f.Close()
+		return Result{Status: Pass, Message: path + " is writable"}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return Result{Status: Fail, Message: err.Error()}
+	}
+
+	// Probe the closest existing parent without creating anything
+	dir := filepath.Dir(path)
+	for {
+		if _, err := os.Stat(dir); err == nil || filepath.Dir(dir) == dir {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	f, err := os.CreateTemp(dir, ".blitzctl-doctor-")
+	if err != nil {
+		return Result{Status: Fail, Message: fmt.Sprintf("%s is not writable: %v", dir, err), Hint: "sudo chown $(id -u):$(id -g) " + dir}
+	}
+	_ = f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		return Result{Status: Warn, Message: fmt.Sprintf("%s will be created, but the probe file %s was left behind: %v", path, f.Name(), err)}
+	}
+	return Result{Status: Pass, Message: path + " will be created"}
+}
+
+// KubeconfigPath returns the kubeconfig the providers write to: the first
+// entry of $KUBECONFIG or ~/.kube/config
+func KubeconfigPath() string {
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		for _, path := range filepath.SplitList(env) {
+			if path != "" {
+				return path
+			}
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".kube", "config")
+}
+
+func readSysctl(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+func firstLine(output string, err error) string {
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return err.Error()
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ", ")
+}
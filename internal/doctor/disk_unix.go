@@ -0,0 +1,17 @@
+//go:build !windows
+
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package doctor
+
+import "syscall"
+
+// freeBytes returns the space available to unprivileged users at path
+func freeBytes(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
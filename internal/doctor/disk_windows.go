@@ -0,0 +1,11 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package doctor
+
+import "errors"
+
+// freeBytes is not implemented on Windows, which blitzctl doesn't support
+func freeBytes(path string) (uint64, error) {
+	return 0, errors.New("not supported on windows")
+}
@@ -0,0 +1,109 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package doctor
+
+import (
+	"context"
+	"time"
+)
+
+// Status is the outcome of a check
+type Status string
+
+const (
+	Pass Status = "pass"
+	Warn Status = "warn"
+	Fail Status = "fail"
+	Skip Status = "skip"
+)
+
+// Result is the outcome of a single check with a remediation hint
+type Result struct {
+	Check   string `json:"check"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// Check is a single environment diagnostic
+type Check interface {
+	Name() string
+	Run(ctx context.Context) Result
+}
+
+// CheckFunc adapts a function to the Check interface
+type CheckFunc struct {
+	CheckName string
+	Fn        func(ctx context.Context) Result
+}
+
+// Name implements Check
+func (c CheckFunc) Name() string {
+	return c.CheckName
+}
+
+// Run implements Check
+func (c CheckFunc) Run(ctx context.Context) Result {
+	result := c.Fn(ctx)
+	result.Check = c.CheckName
+	return result
+}
+
+// NewCheck returns a Check running fn
+func NewCheck(name string, fn func(ctx context.Context) Result) Check {
+	return CheckFunc{CheckName: name, Fn: fn}
+}
+
+// CheckTimeout bounds how long a single check may run
+var CheckTimeout = 15 * time.Second
+
+var registry []Check
+
+// Register adds checks to the default set
+func Register(checks ...Check) {
+	registry = append(registry, checks...)
+}
+
+// Checks returns the registered checks in registration order
+func Checks() []Check {
+	return append([]Check(nil), registry...)
+}
+
+// Lookup returns the registered check called name
+func Lookup(name string) (Check, bool) {
+	for _, check := range registry {
+		if check.Name() == name {
+			return check, true
+		}
+	}
+	return nil, false
+}
+
+// Run executes checks sequentially and returns their results, only those
+// of the checks that finished once ctx is done
+func Run(ctx context.Context, checks []Check) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, CheckTimeout)
+		result := check.Run(checkCtx)
+		cancel()
+		if ctx.Err() != nil {
+			break
+		}
+		if result.Check == "" {
+			result.Check = check.Name()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Summary counts results by status
+func Summary(results []Result) map[Status]int {
+	summary := map[Status]int{}
+	for _, r := range results {
+		summary[r.Status]++
+	}
+	return summary
+}
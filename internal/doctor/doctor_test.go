@@ -0,0 +1,99 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package doctor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// passing returns a check that passes at once
+func passing(name string) Check {
+	return NewCheck(name, func(ctx context.Context) Result {
+		return Result{Status: Pass}
+	})
+}
+
+// blocking returns a check that runs until its context is done
+func blocking(name string) Check {
+	return NewCheck(name, func(ctx context.Context) Result {
+		<-ctx.Done()
+		return Result{Status: Fail, Message: ctx.Err().Error()}
+	})
+}
+
+func TestRun(t *testing.T) {
+	timeout := CheckTimeout
+	CheckTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { CheckTimeout = timeout })
+
+	tests := []struct {
+		name   string
+		checks []Check
+		// cancelAfter cancels the run after that many checks, 0 never does
+		cancelAfter int
+		want        []string
+		wantSummary map[Status]int
+	}{
+		{
+			name:        "every check runs in order",
+			checks:      []Check{passing("a"), passing("b"), passing("c")},
+			want:        []string{"a", "b", "c"},
+			wantSummary: map[Status]int{Pass: 3},
+		},
+		{
+			name:        "slow check times out on its own",
+			checks:      []Check{blocking("slow"), passing("next")},
+			want:        []string{"slow", "next"},
+			wantSummary: map[Status]int{Fail: 1, Pass: 1},
+		},
+		{
+			name:        "canceled run stops",
+			checks:      []Check{passing("a"), blocking("interrupted"), passing("skipped")},
+			cancelAfter: 1,
+			want:        []string{"a"},
+			wantSummary: map[Status]int{Pass: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			checks := tt.checks
+			if tt.cancelAfter > 0 {
+				// The check after cancelAfter cancels the whole run
+				checks = append([]Check(nil), tt.checks...)
+				next := checks[tt.cancelAfter]
+				checks[tt.cancelAfter] = NewCheck(next.Name(), func(checkCtx context.Context) Result {
+					cancel()
+					return next.Run(checkCtx)
+				})
+			}
+
+			results := Run(ctx, checks)
+			var got []string
+			for _, result := range results {
+				got = append(got, result.Check)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Run() ran %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Run() ran %v, want %v", got, tt.want)
+					break
+				}
+			}
+
+			summary := Summary(results)
+			for status, want := range tt.wantSummary {
+				if summary[status] != want {
+					t.Errorf("Summary()[%s] = %d, want %d", status, summary[status], want)
+				}
+			}
+		})
+	}
+}
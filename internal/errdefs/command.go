@@ -0,0 +1,50 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package errdefs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandError reports an external command (kind, minikube, docker, ...)
+// that could not be started or exited with a non-zero code
+type CommandError struct {
+	Args []string
+	// ExitCode is -1 when the command could not be started
+	ExitCode int
+	// Stderr holds the tail of what the command wrote to stderr
+	Stderr string
+	Err    error
+}
+
+func (e *CommandError) Error() string {
+	name := "command"
+	if len(e.Args) > 0 {
+		name = e.Args[0]
+	}
+
+	message := fmt.Sprintf("%s failed: %v", name, e.Err)
+	if e.ExitCode >= 0 {
+		message = fmt.Sprintf("%s exited with code %d", name, e.ExitCode)
+	}
+	if line := lastLine(e.Stderr); line != "" {
+		message += ": " + line
+	}
+	return message
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorKind returns KindExternalCommandFailed
+func (e *CommandError) ErrorKind() Kind {
+	return KindExternalCommandFailed
+}
+
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
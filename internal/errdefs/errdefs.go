@@ -0,0 +1,193 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+
+// Package errdefs defines the categories of blitzctl failures and the exit
+// code each one maps to, so scripts can branch on why a command failed.
+//
+//	0  success
+//	1  unknown or unexpected failure
+//	2  invalid flags, arguments or values
+//	3  a cluster, tool, key or release was not found
+//	4  a cluster or resource already exists
+//	5  an operation the provider or platform does not support
+//	6  a required tool (kind, minikube, docker, ...) is not installed
+//	7  an external command (kind, minikube, ...) failed
+//	8  preflight checks failed
+//	9  the configuration file could not be read or written
+//	10 timed out waiting for a cluster to become ready
+//	130 interrupted by Ctrl-C (SIGINT) or SIGTERM
+package errdefs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kind is the category of a failure
+type Kind string
+
+const (
+	KindUnknown               Kind = "unknown"
+	KindInvalid               Kind = "invalid"
+	KindNotFound              Kind = "not-found"
+	KindAlreadyExists         Kind = "already-exists"
+	KindUnsupported           Kind = "unsupported"
+	KindToolMissing           Kind = "tool-missing"
+	KindExternalCommandFailed Kind = "external-command-failed"
+	KindPreflightFailed       Kind = "preflight-failed"
+	KindConfig                Kind = "config"
+	KindTimeout               Kind = "timeout"
+	KindInterrupted           Kind = "interrupted"
+)
+
+// exitCodes maps each kind to its documented exit code
+var exitCodes = map[Kind]int{
+	KindUnknown:               1,
+	KindInvalid:               2,
+	KindNotFound:              3,
+	KindAlreadyExists:         4,
+	KindUnsupported:           5,
+	KindToolMissing:           6,
+	KindExternalCommandFailed: 7,
+	KindPreflightFailed:       8,
+	KindConfig:                9,
+	KindTimeout:               10,
+	KindInterrupted:           130,
+}
+
+// Error is a categorized failure with an optional remediation hint
+type Error struct {
+	Kind    Kind
+	Message string
+	Hint    string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	if e.Err != nil {
+		if message == "" {
+			message = e.Err.Error()
+		} else {
+			message += ": " + e.Err.Error()
+		}
+	}
+	if e.Hint != "" {
+		message += hintSeparator + e.Hint
+	}
+	return message
+}
+
+// hintSeparator puts the hint on a line of its own under the message
+const hintSeparator = "\n   💡 "
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrorKind returns the category of the failure
+func (e *Error) ErrorKind() Kind {
+	return e.Kind
+}
+
+// WithHint sets the remediation hint shown under the message
+func (e *Error) WithHint(format string, args ...any) *Error {
+	e.Hint = fmt.Sprintf(format, args...)
+	return e
+}
+
+// New returns an error of the given kind
+func New(kind Kind, format string, args ...any) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap returns an error of the given kind caused by err
+func Wrap(kind Kind, err error, format string, args ...any) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// Invalid reports an invalid flag, argument or value
+func Invalid(format string, args ...any) *Error {
+	return New(KindInvalid, format, args...)
+}
+
+// NotFound reports a missing cluster, tool, key or release
+func NotFound(format string, args ...any) *Error {
+	return New(KindNotFound, format, args...)
+}
+
+// AlreadyExists reports a cluster or resource that already exists
+func AlreadyExists(format string, args ...any) *Error {
+	return New(KindAlreadyExists, format, args...)
+}
+
+// Unsupported reports an operation the provider or platform does not support
+func Unsupported(format string, args ...any) *Error {
+	return New(KindUnsupported, format, args...)
+}
+
+// ToolMissing reports a required tool that is not installed
+func ToolMissing(format string, args ...any) *Error {
+	return New(KindToolMissing, format, args...)
+}
+
+// KindOf returns the category of err, looking through wrapped and joined errors
+func KindOf(err error) Kind {
+	if err == nil {
+		return ""
+	}
+	var categorized interface{ ErrorKind() Kind }
+	if errors.As(err, &categorized) {
+		return categorized.ErrorKind()
+	}
+	if errors.Is(err, context.Canceled) {
+		return KindInterrupted
+	}
+	return KindUnknown
+}
+
+// Message returns the text of err without the hints Error shows under it,
+// for output that reports the hint on its own
+func Message(err error) string {
+	message := err.Error()
+	var categorized *Error
+	for errors.As(err, &categorized) {
+		if categorized.Hint != "" {
+			message = strings.Replace(message, hintSeparator+categorized.Hint, "", 1)
+		}
+		err = categorized.Err
+	}
+	return message
+}
+
+// Hint returns the remediation hint of err, the outermost one when several
+// errors it wraps have one
+func Hint(err error) string {
+	var categorized *Error
+	for errors.As(err, &categorized) {
+		if categorized.Hint != "" {
+			return categorized.Hint
+		}
+		err = categorized.Err
+	}
+	return ""
+}
+
+// Is reports whether err is of the given kind
+func Is(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
+
+// ExitCode returns the documented exit code for err, 0 when err is nil
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if code, ok := exitCodes[KindOf(err)]; ok {
+		return code
+	}
+	return exitCodes[KindUnknown]
+}
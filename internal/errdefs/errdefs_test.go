@@ -0,0 +1,102 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package errdefs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", want: 0},
+		{name: "plain error", err: errors.New("boom"), want: 1},
+		{name: "invalid", err: Invalid("bad flag"), want: 2},
+		{name: "not found", err: NotFound("no cluster"), want: 3},
+		{name: "already exists", err: AlreadyExists("cluster exists"), want: 4},
+		{name: "unsupported", err: Unsupported("no snapshots"), want: 5},
+		{name: "tool missing", err: ToolMissing("kind is not installed"), want: 6},
+		{name: "external command", err: New(KindExternalCommandFailed, "kind failed"), want: 7},
+		{name: "preflight", err: New(KindPreflightFailed, "checks failed"), want: 8},
+		{name: "config", err: New(KindConfig, "bad file"), want: 9},
+		{name: "timeout", err: New(KindTimeout, "not ready"), want: 10},
+		{name: "interrupted", err: New(KindInterrupted, "stopped"), want: 130},
+		{name: "canceled context", err: fmt.Errorf("create: %w", context.Canceled), want: 130},
+		{name: "wrapped by fmt", err: fmt.Errorf("create: %w", NotFound("no cluster")), want: 3},
+		{name: "outermost kind wins", err: Wrap(KindConfig, NotFound("no file"), "bad config"), want: 9},
+		{name: "joined", err: errors.Join(errors.New("first"), ToolMissing("docker")), want: 6},
+		{name: "unknown kind", err: New(Kind("other"), "odd"), want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "message", err: Invalid("bad flag"), want: "bad flag"},
+		{name: "cause", err: Wrap(KindConfig, errors.New("no such file"), "failed to read"), want: "failed to read: no such file"},
+		{name: "cause only", err: &Error{Kind: KindConfig, Err: errors.New("no such file")}, want: "no such file"},
+		{name: "hint", err: ToolMissing("kind is not installed").WithHint("blitzctl tool install kind"), want: "kind is not installed\n   💡 blitzctl tool install kind"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageAndHint(t *testing.T) {
+	missing := ToolMissing("❌ Kind is not installed").WithHint("blitzctl install cluster --provider kind")
+
+	tests := []struct {
+		name        string
+		err         error
+		wantMessage string
+		wantHint    string
+	}{
+		{name: "plain error", err: errors.New("boom"), wantMessage: "boom"},
+		{name: "without hint", err: Invalid("bad flag"), wantMessage: "bad flag"},
+		{name: "hint", err: missing, wantMessage: "❌ Kind is not installed", wantHint: "blitzctl install cluster --provider kind"},
+		{name: "wrapped by fmt", err: fmt.Errorf("doctor: %w", missing), wantMessage: "doctor: ❌ Kind is not installed", wantHint: "blitzctl install cluster --provider kind"},
+		{
+			name:        "hint of the cause",
+			err:         Wrap(KindConfig, New(KindConfig, "unsupported apiVersion").WithHint("blitzctl self-update"), "bad config"),
+			wantMessage: "bad config: unsupported apiVersion",
+			wantHint:    "blitzctl self-update",
+		},
+		{
+			name:        "outermost hint wins",
+			err:         Wrap(KindConfig, New(KindConfig, "inner").WithHint("inner hint"), "outer").WithHint("outer hint"),
+			wantMessage: "outer: inner",
+			wantHint:    "outer hint",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Message(tt.err); got != tt.wantMessage {
+				t.Errorf("Message() = %q, want %q", got, tt.wantMessage)
+			}
+			if got := Hint(tt.err); got != tt.wantHint {
+				t.Errorf("Hint() = %q, want %q", got, tt.wantHint)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package helm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// Release is a chart installed into a cluster
+type Release struct {
+	Name      string
+	Namespace string
+	// Repo is the chart repository URL, empty when Chart is a local path or
+	// an oci:// reference
+	Repo    string
+	Chart   string
+	Version string
+	Values  map[string]interface{}
+}
+
+// Upgrade installs r into the cluster of kubeContext, or upgrades the
+// release of the same name, and waits up to timeout for its resources to be
+// ready. It returns the revision of the release, 1 for a fresh install.
+func Upgrade(ctx context.Context, kubeContext string, r Release, timeout time.Duration) (int, error) {
+	settings, cfg, err := configure(kubeContext, r.Namespace)
+	if err != nil {
+		return 0, err
+	}
+	registryClient, err := registry.NewClient(registry.ClientOptCredentialsFile(settings.RegistryConfig))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create the registry client: %w", err)
+	}
+
+	_, err = action.NewHistory(cfg).Run(r.Name)
+	if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+		return 0, fmt.Errorf("failed to read the history of release %s: %w", r.Name, err)
+	}
+	exists := err == nil
+
+	install := action.NewInstall(cfg)
+	install.SetRegistryClient(registryClient)
+	install.RepoURL = r.Repo
+	install.Version = r.Version
+	path, err := install.LocateChart(r.Chart, settings)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download chart %s: %w", r.Chart, err)
+	}
+	chart, err := loader.Load(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load chart %s: %w", r.Chart, err)
+	}
+
+	if exists {
+		upgrade := action.NewUpgrade(cfg)
+		upgrade.Namespace = r.Namespace
+		upgrade.Wait = true
+		upgrade.Timeout = timeout
+		release, err := upgrade.RunWithContext(ctx, r.Name, chart, r.Values)
+		if err != nil {
+			return 0, fmt.Errorf("failed to upgrade release %s: %w", r.Name, err)
+		}
+		return release.Version, nil
+	}
+
+	install.ReleaseName = r.Name
+	install.Namespace = r.Namespace
+	install.CreateNamespace = true
+	install.Wait = true
+	install.Timeout = timeout
+	release, err := install.RunWithContext(ctx, chart, r.Values)
+	if err != nil {
+		return 0, fmt.Errorf("failed to install release %s: %w", r.Name, err)
+	}
+	return release.Version, nil
+}
+
+// Uninstall removes the release called name from the cluster of
+// kubeContext, a release that isn't installed is not an error
+func Uninstall(ctx context.Context, kubeContext, name, namespace string) error {
+	_, cfg, err := configure(kubeContext, namespace)
+	if err != nil {
+		return err
+	}
+
+	if _, err := action.NewUninstall(cfg).Run(name); err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+		return fmt.Errorf("failed to uninstall release %s: %w", name, err)
+	}
+	return nil
+}
+
+// ReadValues reads a YAML file of chart values
+func ReadValues(path string) (map[string]interface{}, error) {
+	values, err := chartutil.ReadValuesFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read values file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// configure returns the Helm settings and action configuration for the
+// namespace of the cluster of kubeContext, HELM_* variables still apply
+func configure(kubeContext, namespace string) (*cli.EnvSettings, *action.Configuration, error) {
+	settings := cli.New()
+	settings.KubeContext = kubeContext
+	settings.SetNamespace(namespace)
+
+	cfg := new(action.Configuration)
+	debug := func(format string, v ...interface{}) {
+		slog.Debug(fmt.Sprintf(format, v...), "component", "helm")
+	}
+	if err := cfg.Init(settings.RESTClientGetter(), namespace, os.Getenv("HELM_DRIVER"), debug); err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize helm for context %s: %w", kubeContext, err)
+	}
+	return settings, cfg, nil
+}
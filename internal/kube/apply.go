@@ -0,0 +1,199 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package kube
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// FieldManager owns the fields blitzctl applies
+const FieldManager = "blitzctl"
+
+// DecodeManifests splits a multi-document YAML or JSON stream into objects,
+// skipping empty documents and expanding v1 Lists
+func DecodeManifests(data []byte) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, fmt.Errorf("failed to decode manifest: %w", err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %w", obj.GetKind(), err)
+			}
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+			continue
+		}
+		objects = append(objects, obj)
+	}
+}
+
+// Describe names obj in messages, e.g. "Deployment kube-system/coredns"
+func Describe(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() != "" {
+		return fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	}
+	return fmt.Sprintf("%s %s", obj.GetKind(), obj.GetName())
+}
+
+// Applier server-side applies and deletes arbitrary objects
+type Applier struct {
+	client dynamic.Interface
+	mapper *restmapper.DeferredDiscoveryRESTMapper
+}
+
+// NewApplier returns an Applier for the cluster of kubeContext
+func NewApplier(kubeContext string) (*Applier, error) {
+	restConfig, err := RESTConfig(kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Applier{
+		client: client,
+		mapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+	}, nil
+}
+
+// Apply server-side applies obj, taking over fields other managers own.
+// Namespaced objects without a namespace go to "default".
+func (a *Applier) Apply(ctx context.Context, obj *unstructured.Unstructured) error {
+	resource, err := a.resource(obj)
+	if err != nil {
+		return err
+	}
+
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", Describe(obj), err)
+	}
+	force := true
+	_, err = resource.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &force,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply %s: %w", Describe(obj), err)
+	}
+	return nil
+}
+
+// Delete deletes obj and what it owns, an object that is already gone is
+// not an error
+func (a *Applier) Delete(ctx context.Context, obj *unstructured.Unstructured) error {
+	resource, err := a.resource(obj)
+	if meta.IsNoMatchError(err) {
+		// The CRD defining it is gone, so is the object
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	err = resource.Delete(ctx, obj.GetName(), metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s: %w", Describe(obj), err)
+	}
+	return nil
+}
+
+// crdResource is the resource of CustomResourceDefinitions
+var crdResource = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// IsCRD reports whether obj is a CustomResourceDefinition
+func IsCRD(obj *unstructured.Unstructured) bool {
+	return obj.GroupVersionKind().GroupKind() == schema.GroupKind{Group: crdResource.Group, Kind: "CustomResourceDefinition"}
+}
+
+// WaitEstablished waits up to timeout for the CustomResourceDefinition
+// called name to be established, so objects of its kind can be applied
+func (a *Applier) WaitEstablished(ctx context.Context, name string, timeout time.Duration) error {
+	err := wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		crd, err := a.client.Resource(crdResource).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			slog.Debug("CRD not readable yet", "crd", name, "error", err)
+			return false, nil
+		}
+		conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+		for _, c := range conditions {
+			condition, _ := c.(map[string]interface{})
+			if condition["type"] == "Established" && condition["status"] == "True" {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		if ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return ctx.Err()
+		}
+		return fmt.Errorf("CustomResourceDefinition %s not established after %s", name, timeout)
+	}
+	return nil
+}
+
+// resource returns the client for the resource of obj. Discovery is
+// refreshed once for kinds it doesn't know, which were just defined by a
+// CRD applied earlier.
+func (a *Applier) resource(obj *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		a.mapper.Reset()
+		mapping, err = a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the resource of %s: %w", Describe(obj), err)
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return a.client.Resource(mapping.Resource), nil
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(metav1.NamespaceDefault)
+	}
+	return a.client.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package kube
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ContextName returns the kubeconfig context a provider writes for a cluster
+func ContextName(provider, clusterName string) string {
+	if provider == "kind" {
+		return "kind-" + clusterName
+	}
+	return clusterName
+}
+
+// KubeconfigPath returns the kubeconfig the providers write to, $KUBECONFIG
+// or ~/.kube/config
+func KubeconfigPath() string {
+	if path := os.Getenv(clientcmd.RecommendedConfigPathEnvVar); path != "" {
+		return path
+	}
+	return clientcmd.RecommendedHomeFile
+}
+
+// RESTConfig loads the client configuration of kubeContext from the default
+// kubeconfig ($KUBECONFIG or ~/.kube/config)
+func RESTConfig(kubeContext string) (*rest.Config, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig context %s: %w", kubeContext, err)
+	}
+	return restConfig, nil
+}
+
+// NewClient returns a clientset for kubeContext
+func NewClient(kubeContext string) (kubernetes.Interface, error) {
+	restConfig, err := RESTConfig(kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultPollInterval is how often the API server is polled while waiting
+var DefaultPollInterval = 2 * time.Second
+
+// ErrNotReady is returned when the cluster is not ready before the deadline
+var ErrNotReady = errors.New("cluster not ready")
+
+// Readiness summarizes how far a cluster is from being ready
+type Readiness struct {
+	// APIServer is false until the API server answers
+	APIServer       bool
+	Nodes           int
+	ReadyNodes      int
+	Workloads       int
+	ReadyWorkloads  int
+	PendingWorkload string
+}
+
+// Ready reports whether every node is Ready and every kube-system workload available
+func (r Readiness) Ready() bool {
+	return r.APIServer && r.Nodes > 0 && r.ReadyNodes == r.Nodes && r.ReadyWorkloads == r.Workloads
+}
+
+func (r Readiness) String() string {
+	if !r.APIServer {
+		return "waiting for the API server"
+	}
+	status := fmt.Sprintf("nodes %d/%d Ready, kube-system workloads %d/%d available",
+		r.ReadyNodes, r.Nodes, r.ReadyWorkloads, r.Workloads)
+	if r.PendingWorkload != "" {
+		status += " (waiting for " + r.PendingWorkload + ")"
+	}
+	return status
+}
+
+// WaitReady polls the cluster until all nodes are Ready and all kube-system
+// deployments and daemonsets are available, printing progress to out. It
+// returns an error wrapping ErrNotReady when timeout passes first.
+func WaitReady(ctx context.Context, client kubernetes.Interface, timeout time.Duration, out io.Writer) error {
+	var last Readiness
+	printed := ""
+
+	err := wait.PollUntilContextTimeout(ctx, DefaultPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		readiness, err := Check(ctx, client)
+		if err != nil {
+			// The API server is usually still coming up, keep polling
+			slog.Debug("readiness check failed", "error", err)
+		}
+		last = readiness
+
+		if status := readiness.String(); status != printed {
+			_, _ = fmt.Fprintf(out, "⏳ %s\n", status)
+			printed = status
+		}
+		return readiness.Ready(), nil
+	})
+
+	if err != nil {
+		if ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return ctx.Err()
+		}
+		return fmt.Errorf("%w after %s: %s", ErrNotReady, timeout, last)
+	}
+	return nil
+}
+
+// Check returns the current readiness of the cluster
+func Check(ctx context.Context, client kubernetes.Interface) (Readiness, error) {
+	var readiness Readiness
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return readiness, err
+	}
+	readiness.APIServer = true
+	readiness.Nodes = len(nodes.Items)
+	for _, node := range nodes.Items {
+		if nodeReady(node) {
+			readiness.ReadyNodes++
+		}
+	}
+
+	deployments, err := client.AppsV1().Deployments(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return readiness, err
+	}
+	for _, d := range deployments.Items {
+		readiness.Workloads++
+		replicas := int32(1)
+		if d.Spec.Replicas != nil {
+			replicas = *d.Spec.Replicas
+		}
+		if d.Status.ObservedGeneration >= d.Generation && d.Status.AvailableReplicas >= replicas {
+			readiness.ReadyWorkloads++
+		} else if readiness.PendingWorkload == "" {
+			readiness.PendingWorkload = "deployment/" + d.Name
+		}
+	}
+
+	daemonSets, err := client.AppsV1().DaemonSets(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return readiness, err
+	}
+	for _, ds := range daemonSets.Items {
+		readiness.Workloads++
+		if ds.Status.ObservedGeneration >= ds.Generation && ds.Status.NumberAvailable >= ds.Status.DesiredNumberScheduled {
+			readiness.ReadyWorkloads++
+		} else if readiness.PendingWorkload == "" {
+			readiness.PendingWorkload = "daemonset/" + ds.Name
+		}
+	}
+
+	return readiness, nil
+}
+
+func nodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package kube
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// node returns a node whose Ready condition is ready
+func node(name string, ready bool) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}}},
+	}
+}
+
+// deployment returns a kube-system deployment with available of replicas up
+func deployment(name string, replicas, available int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{AvailableReplicas: available},
+	}
+}
+
+// daemonSet returns a kube-system daemonset with available of desired pods up
+func daemonSet(name string, desired, available int32) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem},
+		Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: desired, NumberAvailable: available},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		want        Readiness
+		wantReady   bool
+		wantPending string
+	}{
+		{
+			name:      "ready",
+			objects:   []runtime.Object{node("control-plane", true), deployment("coredns", 2, 2), daemonSet("kindnet", 1, 1)},
+			want:      Readiness{APIServer: true, Nodes: 1, ReadyNodes: 1, Workloads: 2, ReadyWorkloads: 2},
+			wantReady: true,
+		},
+		{
+			name:    "no nodes yet",
+			objects: []runtime.Object{deployment("coredns", 2, 2)},
+			want:    Readiness{APIServer: true, Workloads: 1, ReadyWorkloads: 1},
+		},
+		{
+			name:    "node not ready",
+			objects: []runtime.Object{node("control-plane", true), node("worker", false)},
+			want:    Readiness{APIServer: true, Nodes: 2, ReadyNodes: 1},
+		},
+		{
+			name:    "deployment unavailable",
+			objects: []runtime.Object{node("control-plane", true), deployment("coredns", 2, 1), daemonSet("kube-proxy", 1, 0)},
+			want:    Readiness{APIServer: true, Nodes: 1, ReadyNodes: 1, Workloads: 2, PendingWorkload: "deployment/coredns"},
+		},
+		{
+			name:    "daemonset unavailable",
+			objects: []runtime.Object{node("control-plane", true), deployment("coredns", 1, 1), daemonSet("kube-proxy", 2, 1)},
+			want:    Readiness{APIServer: true, Nodes: 1, ReadyNodes: 1, Workloads: 2, ReadyWorkloads: 1, PendingWorkload: "daemonset/kube-proxy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Check(context.Background(), fake.NewClientset(tt.objects...))
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Check() = %+v, want %+v", got, tt.want)
+			}
+			if got.Ready() != tt.wantReady {
+				t.Errorf("Ready() = %v, want %v", got.Ready(), tt.wantReady)
+			}
+		})
+	}
+}
+
+func TestWaitReady(t *testing.T) {
+	interval := DefaultPollInterval
+	DefaultPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { DefaultPollInterval = interval })
+
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		canceled bool
+		wantErr  error
+	}{
+		{name: "ready at once", objects: []runtime.Object{node("control-plane", true)}},
+		{name: "times out", objects: []runtime.Object{node("control-plane", false)}, wantErr: ErrNotReady},
+		{name: "canceled", objects: []runtime.Object{node("control-plane", false)}, canceled: true, wantErr: context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.canceled {
+				cancel()
+			}
+
+			err := WaitReady(ctx, fake.NewClientset(tt.objects...), 50*time.Millisecond, io.Discard)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("WaitReady() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContextName(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     string
+	}{
+		{provider: "kind", want: "kind-dev"},
+		{provider: "minikube", want: "dev"},
+	}
+	for _, tt := range tests {
+		if got := ContextName(tt.provider, "dev"); got != tt.want {
+			t.Errorf("ContextName(%s, dev) = %s, want %s", tt.provider, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// ConsoleHandler writes records as single human friendly lines using the
+// emoji prefixes blitzctl prints elsewhere, e.g.
+//
+//	⚠️ Warning: Failed to save cluster information: permission denied
+//	🐛 running command args="[kind get clusters]"
+type ConsoleHandler struct {
+	mu    *sync.Mutex
+	out   io.Writer
+	level slog.Leveler
+	attrs []slog.Attr
+	group string
+}
+
+// NewConsoleHandler returns a handler writing records at or above level to out
+func NewConsoleHandler(out io.Writer, level slog.Leveler) *ConsoleHandler {
+	return &ConsoleHandler{mu: &sync.Mutex{}, out: out, level: level}
+}
+
+// Enabled implements slog.Handler
+func (h *ConsoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle implements slog.Handler
+func (h *ConsoleHandler) Handle(_ context.Context, record slog.Record) error {
+	var b strings.Builder
+	b.WriteString(prefix(record.Level))
+	b.WriteString(record.Message)
+
+	var errText string
+	write := func(attr slog.Attr, group string) {
+		attr.Value = attr.Value.Resolve()
+		if attr.Equal(slog.Attr{}) {
+			return
+		}
+		if attr.Key == "error" && errText == "" {
+			errText = attr.Value.String()
+			return
+		}
+		key := attr.Key
+		if group != "" {
+			key = group + "." + key
+		}
+		value := attr.Value.String()
+		if strings.ContainsAny(value, " \t\"") {
+			value = fmt.Sprintf("%q", value)
+		}
+		fmt.Fprintf(&b, " %s=%s", key, value)
+	}
+
+	// Attributes from WithAttrs already carry their group in the key
+	for _, attr := range h.attrs {
+		write(attr, "")
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		write(attr, h.group)
+		return true
+	})
+
+	line := b.String()
+	if errText != "" {
+		// Keep the familiar "message: error" shape before the other attributes
+		head := prefix(record.Level) + record.Message
+		line = head + ": " + errText + strings.TrimPrefix(line, head)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := fmt.Fprintln(h.out, line)
+	return err
+}
+
+// WithAttrs implements slog.Handler
+func (h *ConsoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append([]slog.Attr(nil), h.attrs...)
+	for _, attr := range attrs {
+		if h.group != "" {
+			attr.Key = h.group + "." + attr.Key
+		}
+		clone.attrs = append(clone.attrs, attr)
+	}
+	return &clone
+}
+
+// WithGroup implements slog.Handler
+func (h *ConsoleHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	if clone.group != "" {
+		name = clone.group + "." + name
+	}
+	clone.group = name
+	return &clone
+}
+
+func prefix(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "❌ "
+	case level >= slog.LevelWarn:
+		return "⚠️ Warning: "
+	case level >= slog.LevelInfo:
+		return ""
+	default:
+		return "🐛 "
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// Supported log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options configure the process-wide logger
+type Options struct {
+	// Level is the minimum level logged: debug, info, warn or error
+	Level string
+	// Verbosity is the number of -v flags, any of them enables debug logs
+	Verbosity int
+	// Format is text (human friendly on a terminal) or json
+	Format string
+	// File receives the logs instead of stderr when set
+	File string
+}
+
+// logFile is the file opened by Setup for --log-file, Close closes it
+var logFile *os.File
+
+// Setup installs the default slog logger described by opts. Logs never go to
+// stdout, which is kept for command output. A log file opened by an earlier
+// Setup is closed.
+func Setup(opts Options) error {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return err
+	}
+	if opts.Verbosity > 0 {
+		level = slog.LevelDebug
+	}
+	format := strings.ToLower(opts.Format)
+	if format != "" && format != FormatText && format != FormatJSON {
+		return errdefs.Invalid("❌ Unsupported log format: %s (supported: text, json)", opts.Format)
+	}
+
+	var out io.Writer = os.Stderr
+	var f *os.File
+	if opts.File != "" {
+		f, err = os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return errdefs.Wrap(errdefs.KindInvalid, err, "❌ Error opening log file")
+		}
+		out = f
+	}
+
+	handlerOptions := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch {
+	case format == FormatJSON:
+		handler = slog.NewJSONHandler(out, handlerOptions)
+	case f != nil:
+		// Files get timestamps, the terminal gets the familiar emoji output
+		handler = slog.NewTextHandler(out, handlerOptions)
+	default:
+		handler = NewConsoleHandler(out, level)
+	}
+
+	previous := logFile
+	logFile = f
+	slog.SetDefault(slog.New(handler))
+	if previous != nil {
+		_ = previous.Close()
+	}
+	return nil
+}
+
+// Close flushes the log file opened by Setup to disk and closes it, logs go
+// to stderr again. It does nothing when logs already go to stderr.
+func Close() error {
+	if logFile == nil {
+		return nil
+	}
+	f := logFile
+	logFile = nil
+	slog.SetDefault(slog.New(NewConsoleHandler(os.Stderr, slog.LevelInfo)))
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to flush log file %s: %w", f.Name(), err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close log file %s: %w", f.Name(), err)
+	}
+	return nil
+}
+
+// ParseLevel converts a level name into a slog level, empty means info
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, errdefs.Invalid("❌ Unsupported log level: %s (supported: debug, info, warn, error)", name)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    slog.Level
+		wantErr bool
+	}{
+		{name: "", want: slog.LevelInfo},
+		{name: "debug", want: slog.LevelDebug},
+		{name: "INFO", want: slog.LevelInfo},
+		{name: "warn", want: slog.LevelWarn},
+		{name: "warning", want: slog.LevelWarn},
+		{name: "error", want: slog.LevelError},
+		{name: "trace", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLevel(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLevel(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetup(t *testing.T) {
+	t.Cleanup(func() { _ = Close() })
+
+	tests := []struct {
+		name     string
+		opts     Options
+		wantErr  bool
+		wantLogs []string
+		skipLogs []string
+	}{
+		{
+			name:     "text file",
+			opts:     Options{Level: "warn"},
+			wantLogs: []string{"level=WARN", `msg="disk almost full"`},
+			skipLogs: []string{"starting"},
+		},
+		{
+			name:     "verbose enables debug",
+			opts:     Options{Level: "error", Verbosity: 1},
+			wantLogs: []string{"level=DEBUG", "level=WARN"},
+		},
+		{
+			name:     "json file",
+			opts:     Options{Format: "JSON"},
+			wantLogs: []string{`"level":"WARN"`, `"msg":"disk almost full"`},
+			skipLogs: []string{"starting"},
+		},
+		{name: "unknown level", opts: Options{Level: "trace"}, wantErr: true},
+		{name: "unknown format", opts: Options{Format: "xml"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "blitzctl.log")
+			tt.opts.File = path
+
+			err := Setup(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Setup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errdefs.Is(err, errdefs.KindInvalid) {
+					t.Errorf("Setup() error kind = %s, want %s", errdefs.KindOf(err), errdefs.KindInvalid)
+				}
+				if _, statErr := os.Stat(path); !errors.Is(statErr, os.ErrNotExist) {
+					t.Errorf("log file created despite the error, stat error = %v", statErr)
+				}
+				return
+			}
+
+			slog.Debug("starting")
+			slog.Warn("disk almost full")
+			if err := Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			logs := string(data)
+			for _, want := range tt.wantLogs {
+				if !strings.Contains(logs, want) {
+					t.Errorf("log file lacks %q:\n%s", want, logs)
+				}
+			}
+			for _, skip := range tt.skipLogs {
+				if strings.Contains(logs, skip) {
+					t.Errorf("log file holds %q:\n%s", skip, logs)
+				}
+			}
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blitzctl.log")
+	if err := Setup(Options{File: path}); err != nil {
+		t.Fatal(err)
+	}
+	first := logFile
+
+	// A second Setup closes the file of the first
+	if err := Setup(Options{File: path}); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Close(); err == nil {
+		t.Error("Setup() left the previous log file open")
+	}
+
+	for range 2 {
+		if err := Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	}
+	if logFile != nil {
+		t.Error("Close() kept the log file")
+	}
+}
+
+func TestConsoleHandler(t *testing.T) {
+	tests := []struct {
+		name  string
+		level slog.Level
+		log   func(logger *slog.Logger)
+		want  string
+	}{
+		{name: "info", level: slog.LevelInfo, log: func(l *slog.Logger) { l.Info("cluster ready", "nodes", 2) }, want: "cluster ready nodes=2\n"},
+		{name: "debug hidden", level: slog.LevelInfo, log: func(l *slog.Logger) { l.Debug("running command") }},
+		{name: "debug", level: slog.LevelDebug, log: func(l *slog.Logger) { l.Debug("running command", "args", "kind get clusters") }, want: "🐛 running command args=\"kind get clusters\"\n"},
+		{
+			name:  "error attribute first",
+			level: slog.LevelInfo,
+			log: func(l *slog.Logger) {
+				l.Warn("Failed to save", "cluster", "dev", "error", errors.New("permission denied"))
+			},
+			want: "⚠️ Warning: Failed to save: permission denied cluster=dev\n",
+		},
+		{name: "error", level: slog.LevelInfo, log: func(l *slog.Logger) { l.Error("boom") }, want: "❌ boom\n"},
+		{name: "group", level: slog.LevelInfo, log: func(l *slog.Logger) { l.WithGroup("kind").With("cluster", "dev").Info("created") }, want: "created kind.cluster=dev\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			tt.log(slog.New(NewConsoleHandler(&out, tt.level)))
+			if got := out.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Interactive reports whether both stdin and stdout are terminals, the only
+// case in which blitzctl asks questions
+func Interactive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Prompter asks questions on out and reads the answers from in, one per line
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// New returns a Prompter reading answers from in
+func New(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{in: bufio.NewReader(in), out: out}
+}
+
+// Ask asks question until validate accepts the answer, an empty answer
+// selects def
+func (p *Prompter) Ask(question, def string, validate func(answer string) error) (string, error) {
+	for {
+		if def != "" {
+			_, _ = fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+		} else {
+			_, _ = fmt.Fprintf(p.out, "%s: ", question)
+		}
+
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = def
+		}
+
+		if validate == nil {
+			return answer, nil
+		}
+		if err := validate(answer); err != nil {
+			_, _ = fmt.Fprintf(p.out, "  ❌ %v\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// Choose asks to pick one of options, by name or by number. Answers that
+// aren't an option are accepted when validate allows them, e.g. a path.
+func (p *Prompter) Choose(question string, options []string, def string, validate func(answer string) error) (string, error) {
+	_, _ = fmt.Fprintln(p.out, question)
+	for i, option := range options {
+		marker := " "
+		if option == def {
+			marker = "*"
+		}
+		_, _ = fmt.Fprintf(p.out, "  %s %d) %s\n", marker, i+1, option)
+	}
+
+	choice := func(answer string) (string, bool) {
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return options[n-1], true
+		}
+		return answer, false
+	}
+
+	answer, err := p.Ask("Choice", def, func(answer string) error {
+		if _, ok := choice(answer); ok {
+			return nil
+		}
+		if validate != nil {
+			return validate(answer)
+		}
+		for _, option := range options {
+			if answer == option {
+				return nil
+			}
+		}
+		return fmt.Errorf("pick a number between 1 and %d", len(options))
+	})
+	if err != nil {
+		return "", err
+	}
+	answer, _ = choice(answer)
+	return answer, nil
+}
+
+// Confirm asks a yes/no question, an empty answer selects def
+func (p *Prompter) Confirm(question string, def bool) (bool, error) {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+
+	for {
+		_, _ = fmt.Fprintf(p.out, "%s [%s]: ", question, choices)
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		_, _ = fmt.Fprintln(p.out, "  ❌ answer yes or no")
+	}
+}
+
+// readLine returns the next answer without surrounding spaces, io.EOF when
+// the input is closed before one is given
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
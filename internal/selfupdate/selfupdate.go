@@ -0,0 +1,256 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package selfupdate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
+	"github.com/OneideLuizSchneider/blitzctl/internal/updater"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+)
+
+const (
+	// DefaultReleaseURL is the GitHub releases index of blitzctl
+	DefaultReleaseURL = "https://api.github.com/repos/OneideLuizSchneider/blitzctl/releases?per_page=100"
+	// ReleaseURLEnv overrides the release index URL
+	ReleaseURLEnv = "BLITZCTL_RELEASE_URL"
+	// ChecksumsAsset is the release asset listing the sha256 of every binary
+	ChecksumsAsset = "checksums.txt"
+	// BinaryName is the name used for release assets
+	BinaryName = "blitzctl"
+)
+
+// Release is an entry of the release index. The format matches the GitHub
+// releases API so any server returning the same JSON can stand in for it.
+type Release struct {
+	TagName    string  `json:"tag_name"`
+	Draft      bool    `json:"draft"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset is a downloadable file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Client talks to a release index
+type Client struct {
+	IndexURL string
+	HTTP     *http.Client
+	Out      io.Writer
+}
+
+// NewClient returns a client for indexURL, falling back to $BLITZCTL_RELEASE_URL
+// and then to DefaultReleaseURL
+func NewClient(indexURL string) *Client {
+	if indexURL == "" {
+		indexURL = os.Getenv(ReleaseURLEnv)
+	}
+	if indexURL == "" {
+		indexURL = DefaultReleaseURL
+	}
+	return &Client{
+		IndexURL: indexURL,
+		HTTP:     &http.Client{Timeout: 5 * time.Minute},
+		Out:      os.Stdout,
+	}
+}
+
+// AssetName returns the release asset for an OS/arch, matching scripts/blitzctl.sh
+// and the release workflow, which adds .exe on Windows
+func AssetName(goos, goarch string) string {
+	name := fmt.Sprintf("%s_%s_%s", BinaryName, goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Releases returns the published releases, following the pages of the index
+// through its Link header the way the GitHub API paginates them
+func (c *Client) Releases(ctx context.Context) ([]Release, error) {
+	var releases []Release
+	for url := c.IndexURL; url != ""; {
+		page, next, err := c.releasePage(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, page...)
+		url = next
+	}
+	return releases, nil
+}
+
+// releasePage returns the releases of one page of the index at url and the
+// URL of the next page, empty on the last one
+func (c *Client) releasePage(ctx context.Context, url string) ([]Release, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch release index %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to fetch release index %s: %s", url, resp.Status)
+	}
+
+	var releases []Release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, "", fmt.Errorf("failed to parse release index: %w", err)
+	}
+	return releases, nextPage(resp.Header.Get("Link")), nil
+}
+
+// nextPage returns the rel="next" target of a Link header such as
+// `<https://api.github.com/...&page=2>; rel="next", <...>; rel="last"`
+func nextPage(link string) string {
+	for _, entry := range strings.Split(link, ",") {
+		target, params, ok := strings.Cut(entry, ";")
+		if !ok {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(target), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// Find returns the release tagged version, or the newest stable release
+// when version is empty
+func (c *Client) Find(ctx context.Context, version string) (*Release, error) {
+	releases, err := c.Releases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Release
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft {
+			continue
+		}
+		if version != "" {
+			if toolchain.NormalizeVersion(r.TagName) == toolchain.NormalizeVersion(version) {
+				return r, nil
+			}
+			continue
+		}
+		if r.Prerelease {
+			continue
+		}
+		if found == nil || Compare(r.TagName, found.TagName) > 0 {
+			found = r
+		}
+	}
+
+	if found == nil {
+		if version != "" {
+			return nil, fmt.Errorf("release %s not found", version)
+		}
+		return nil, fmt.Errorf("no releases found")
+	}
+	return found, nil
+}
+
+// Compare compares two release versions, returning -1, 0 or 1. Versions that
+// aren't valid semver (e.g. "dev") sort before every release.
+func Compare(a, b string) int {
+	va, errA := utilversion.ParseSemantic(toolchain.NormalizeVersion(a))
+	vb, errB := utilversion.ParseSemantic(toolchain.NormalizeVersion(b))
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+	result, _ := va.Compare(vb.String())
+	return result
+}
+
+// IsRelease reports whether version is a released semver version rather
+// than a development build
+func IsRelease(version string) bool {
+	_, err := utilversion.ParseSemantic(toolchain.NormalizeVersion(version))
+	return err == nil
+}
+
+// Apply downloads the release asset for the current platform, verifies it
+// against the release checksums and atomically replaces target, keeping a
+// backup. It returns the backup path.
+func (c *Client) Apply(ctx context.Context, release *Release, target string) (string, error) {
+	name := AssetName(runtime.GOOS, runtime.GOARCH)
+	asset := release.asset(name)
+	if asset == nil {
+		return "", fmt.Errorf("release %s has no %s asset", release.TagName, name)
+	}
+	checksums := release.asset(ChecksumsAsset)
+	if checksums == nil {
+		return "", fmt.Errorf("release %s has no %s, refusing to install an unverified binary", release.TagName, ChecksumsAsset)
+	}
+
+	// Download next to the target so the final rename is atomic
+	tmpDir, err := os.MkdirTemp(filepath.Dir(target), ".blitzctl-update-")
+	if err != nil {
+		return "", fmt.Errorf("failed to write to %s: %w (re-run with sudo)", filepath.Dir(target), err)
+	}
+	defer toolchain.RemoveTempDir(tmpDir)
+
+	_, _ = fmt.Fprintf(c.Out, "⬇️ Downloading %s %s...\n", name, release.TagName)
+	binary := filepath.Join(tmpDir, name)
+	digest, err := toolchain.DownloadFile(ctx, c.HTTP, asset.URL, binary)
+	if err != nil {
+		return "", err
+	}
+
+	expected, err := toolchain.FetchChecksum(ctx, c.HTTP, checksums.URL, asset.URL)
+	if err != nil {
+		return "", fmt.Errorf("failed to get checksum: %w", err)
+	}
+	if digest != expected {
+		return "", fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, expected, digest)
+	}
+	_, _ = fmt.Fprintf(c.Out, "🔐 Checksum verified (sha256:%s)\n", digest)
+
+	return updater.Replace(target, binary)
+}
+
+// Executable returns the resolved path of the running binary
+func Executable() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(exe)
+}
+
+func (r *Release) asset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
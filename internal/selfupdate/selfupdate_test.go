@@ -0,0 +1,242 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/updater"
+)
+
+// newBinary is the content of the binary every test release ships
+const newBinary = "#!/bin/sh\necho blitzctl v1.1.0\n"
+
+// releaseServer serves a release index in two pages linked like the GitHub
+// API links them, the binaries of its releases and their checksums.txt,
+// which lists checksum for the binary
+type releaseServer struct {
+	*httptest.Server
+	checksum string
+}
+
+func newReleaseServer(t *testing.T) *releaseServer {
+	t.Helper()
+	sum := sha256.Sum256([]byte(newBinary))
+	s := &releaseServer{checksum: hex.EncodeToString(sum[:])}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/releases", func(w http.ResponseWriter, r *http.Request) {
+		asset := func(tag, name string) Asset {
+			return Asset{Name: name, URL: s.URL + "/download/" + tag + "/" + name}
+		}
+		platform := AssetName(runtime.GOOS, runtime.GOARCH)
+		releases := []Release{
+			{TagName: "v1.0.0", Assets: []Asset{asset("v1.0.0", platform), asset("v1.0.0", ChecksumsAsset)}},
+			{TagName: "v1.2.0-rc.1", Prerelease: true, Assets: []Asset{asset("v1.2.0-rc.1", platform), asset("v1.2.0-rc.1", ChecksumsAsset)}},
+			{TagName: "v2.0.0", Draft: true},
+		}
+		if r.URL.Query().Get("page") == "2" {
+			releases = []Release{
+				{TagName: "v1.1.0", Assets: []Asset{asset("v1.1.0", platform), asset("v1.1.0", ChecksumsAsset)}},
+				// Built for another platform only
+				{TagName: "v0.9.0", Assets: []Asset{asset("v0.9.0", AssetName("plan9", "mips")), asset("v0.9.0", ChecksumsAsset)}},
+				{TagName: "v0.8.0", Assets: []Asset{asset("v0.8.0", platform)}},
+			}
+		} else {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/releases?page=2>; rel="next", <%s/releases?page=2>; rel="last"`, s.URL, s.URL))
+		}
+		_ = json.NewEncoder(w).Encode(releases)
+	})
+	mux.HandleFunc("/download/{tag}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("name") == ChecksumsAsset {
+			_, _ = fmt.Fprintf(w, "%s  %s\n", s.checksum, AssetName(runtime.GOOS, runtime.GOARCH))
+			return
+		}
+		_, _ = io.WriteString(w, newBinary)
+	})
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *releaseServer) client() *Client {
+	c := NewClient(s.URL + "/releases")
+	c.HTTP = s.Client()
+	c.Out = io.Discard
+	return c
+}
+
+func TestFind(t *testing.T) {
+	s := newReleaseServer(t)
+
+	tests := []struct {
+		name    string
+		version string
+		want    string
+		wantErr bool
+	}{
+		{name: "newest stable release on a later page", want: "v1.1.0"},
+		{name: "pinned version", version: "1.0.0", want: "v1.0.0"},
+		{name: "pinned version on a later page", version: "v1.1.0", want: "v1.1.0"},
+		{name: "pinned prerelease", version: "v1.2.0-rc.1", want: "v1.2.0-rc.1"},
+		{name: "draft is never found", version: "v2.0.0", wantErr: true},
+		{name: "unknown version", version: "v9.9.9", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release, err := s.client().Find(context.Background(), tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Find(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			}
+			if err == nil && release.TagName != tt.want {
+				t.Errorf("Find(%q) = %s, want %s", tt.version, release.TagName, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	const oldBinary = "#!/bin/sh\necho blitzctl v1.0.0\n"
+
+	tests := []struct {
+		name        string
+		version     string
+		badChecksum bool
+		wantErr     string
+	}{
+		{name: "good download", version: "v1.1.0"},
+		{name: "checksum mismatch", version: "v1.1.0", badChecksum: true, wantErr: "checksum mismatch"},
+		{name: "missing asset", version: "v0.9.0", wantErr: "has no " + AssetName(runtime.GOOS, runtime.GOARCH)},
+		{name: "missing checksums", version: "v0.8.0", wantErr: "has no " + ChecksumsAsset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newReleaseServer(t)
+			if tt.badChecksum {
+				s.checksum = strings.Repeat("0", 64)
+			}
+			c := s.client()
+
+			dir := t.TempDir()
+			target := filepath.Join(dir, BinaryName)
+			if err := os.WriteFile(target, []byte(oldBinary), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			release, err := c.Find(context.Background(), tt.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			backup, err := c.Apply(context.Background(), release, target)
+
+			got, readErr := os.ReadFile(target)
+			if readErr != nil {
+				t.Fatal(readErr)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Apply() error = %v, want %q", err, tt.wantErr)
+				}
+				if string(got) != oldBinary {
+					t.Errorf("binary replaced despite the error, got %q", got)
+				}
+				// Neither a backup nor the download is left behind
+				entries, _ := os.ReadDir(dir)
+				if len(entries) != 1 {
+					t.Errorf("files left next to the binary: %v", entries)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if string(got) != newBinary {
+				t.Errorf("binary = %q, want the release %q", got, newBinary)
+			}
+			if backup != target+updater.BackupSuffix {
+				t.Fatalf("backup = %q, want %q", backup, target+updater.BackupSuffix)
+			}
+			if old, _ := os.ReadFile(backup); string(old) != oldBinary {
+				t.Errorf("backup = %q, want the previous binary", old)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.1.0", "v1.0.0", 1},
+		{"1.0.0", "v1.0.0", 0},
+		{"v1.2.0-rc.1", "v1.2.0", -1},
+		{"v1.10.0", "v1.9.0", 1},
+		{"dev", "v0.1.0", -1},
+		{"v0.1.0", "dev", 1},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestAssetName(t *testing.T) {
+	tests := []struct {
+		goos, goarch string
+		want         string
+	}{
+		{"linux", "amd64", "blitzctl_linux_amd64"},
+		{"darwin", "arm64", "blitzctl_darwin_arm64"},
+		{"windows", "amd64", "blitzctl_windows_amd64.exe"},
+	}
+	for _, tt := range tests {
+		if got := AssetName(tt.goos, tt.goarch); got != tt.want {
+			t.Errorf("AssetName(%s, %s) = %s, want %s", tt.goos, tt.goarch, got, tt.want)
+		}
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{name: "no header"},
+		{
+			name: "first page",
+			link: `<https://api.github.com/repositories/1/releases?page=2>; rel="next", <https://api.github.com/repositories/1/releases?page=4>; rel="last"`,
+			want: "https://api.github.com/repositories/1/releases?page=2",
+		},
+		{
+			name: "middle page",
+			link: `<https://example.com/r?page=1>; rel="prev", <https://example.com/r?page=3>; rel="next"`,
+			want: "https://example.com/r?page=3",
+		},
+		{name: "last page", link: `<https://example.com/r?page=1>; rel="first", <https://example.com/r?page=3>; rel="prev"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextPage(tt.link); got != tt.want {
+				t.Errorf("nextPage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
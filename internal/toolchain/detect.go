@@ -0,0 +1,132 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package toolchain
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/internal/command"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+)
+
+// Status of a detected tool
+type Status string
+
+const (
+	StatusOK           Status = "ok"
+	StatusMissing      Status = "missing"
+	StatusOutdated     Status = "outdated"
+	StatusIncompatible Status = "incompatible"
+	StatusUnknown      Status = "unknown"
+)
+
+// Dependency describes how to detect an external CLI blitzctl relies on
+type Dependency struct {
+	Name string
+	Args []string
+	// Minimum is the oldest supported version
+	Minimum string
+}
+
+// Dependencies are the external CLIs reported by 'blitzctl version --client-tools'
+var Dependencies = []Dependency{
+	{Name: "kind", Args: []string{"version"}, Minimum: "0.20.0"},
+	{Name: "minikube", Args: []string{"version", "--short"}, Minimum: "1.30.0"},
+	{Name: "docker", Args: []string{"--version"}, Minimum: "20.10.0"},
+	{Name: "podman", Args: []string{"--version"}, Minimum: "4.0.0"},
+	{Name: "helm", Args: []string{"version", "--short"}, Minimum: "3.10.0"},
+	{Name: "kubectl", Args: []string{"version", "--client"}, Minimum: "1.28.0"},
+}
+
+// Detected is the result of probing a dependency
+type Detected struct {
+	Name    string `json:"name"`
+	Path    string `json:"path,omitempty"`
+	Version string `json:"version,omitempty"`
+	Minimum string `json:"minimum"`
+	Status  Status `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+var versionPattern = regexp.MustCompile(`v?(\d+\.\d+\.\d+)`)
+
+// Detect probes every dependency and checks kubectl against the kubectl
+// version skew policy for k8sVersion
+func Detect(k8sVersion string) []Detected {
+	results := make([]Detected, 0, len(Dependencies))
+	for _, dep := range Dependencies {
+		result := dep.Detect()
+		if dep.Name == "kubectl" && result.Status == StatusOK {
+			checkKubectlSkew(&result, k8sVersion)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Detect probes the dependency
+func (d Dependency) Detect() Detected {
+	result := Detected{Name: d.Name, Minimum: d.Minimum}
+
+	path, err := exec.LookPath(d.Name)
+	if err != nil {
+		result.Status = StatusMissing
+		result.Message = "not found on PATH"
+		return result
+	}
+	result.Path = path
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	output, err := command.CombinedOutput(exec.CommandContext(ctx, path, d.Args...))
+	match := versionPattern.FindStringSubmatch(string(output))
+	if match == nil {
+		result.Status = StatusUnknown
+		result.Message = "could not determine version"
+		if err != nil {
+			result.Message = fmt.Sprintf("could not determine version: %v", err)
+		}
+		return result
+	}
+	result.Version = match[1]
+
+	current, err := utilversion.ParseGeneric(result.Version)
+	if err != nil {
+		result.Status = StatusUnknown
+		result.Message = err.Error()
+		return result
+	}
+	if current.LessThan(utilversion.MustParseGeneric(d.Minimum)) {
+		result.Status = StatusOutdated
+		result.Message = fmt.Sprintf("older than the minimum supported %s", d.Minimum)
+		return result
+	}
+
+	result.Status = StatusOK
+	return result
+}
+
+// checkKubectlSkew flags kubectl when it is more than one minor version away
+// from the cluster version (https://kubernetes.io/releases/version-skew-policy/#kubectl)
+func checkKubectlSkew(result *Detected, k8sVersion string) {
+	client, err := utilversion.ParseGeneric(result.Version)
+	if err != nil {
+		return
+	}
+	server, err := utilversion.ParseGeneric(NormalizeVersion(k8sVersion))
+	if err != nil {
+		return
+	}
+
+	skew := int(client.Minor()) - int(server.Minor())
+	if client.Major() != server.Major() || skew > 1 || skew < -1 {
+		result.Status = StatusIncompatible
+		result.Message = fmt.Sprintf("outside the supported skew of Kubernetes %s (±1 minor)", k8sVersion)
+	}
+}
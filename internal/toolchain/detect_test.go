@@ -0,0 +1,83 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package toolchain
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// fakeTool puts an executable named name on PATH that prints output
+func fakeTool(t *testing.T, name, output string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake tools are shell scripts")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho '" + output + "'\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+}
+
+func TestDependencyDetect(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		missing    bool
+		wantStatus Status
+		want       string
+	}{
+		{name: "supported", output: "kind v0.30.0 go1.24.6 linux/amd64", wantStatus: StatusOK, want: "0.30.0"},
+		{name: "minimum", output: "kind v0.20.0", wantStatus: StatusOK, want: "0.20.0"},
+		{name: "outdated", output: "kind v0.17.0", wantStatus: StatusOutdated, want: "0.17.0"},
+		{name: "no version printed", output: "kind", wantStatus: StatusUnknown},
+		{name: "missing", missing: true, wantStatus: StatusMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.missing {
+				t.Setenv("PATH", t.TempDir())
+			} else {
+				fakeTool(t, "kind", tt.output)
+			}
+
+			got := Dependency{Name: "kind", Args: []string{"version"}, Minimum: "0.20.0"}.Detect()
+			if got.Status != tt.wantStatus || got.Version != tt.want {
+				t.Errorf("Detect() = %s %q, want %s %q", got.Status, got.Version, tt.wantStatus, tt.want)
+			}
+			if got.Status != StatusOK && got.Message == "" {
+				t.Errorf("Detect() status %s without a message", got.Status)
+			}
+		})
+	}
+}
+
+func TestCheckKubectlSkew(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     string
+		k8sVersion string
+		want       Status
+	}{
+		{name: "same minor", client: "1.34.1", k8sVersion: "1.34.0", want: StatusOK},
+		{name: "one minor newer", client: "1.35.0", k8sVersion: "v1.34.0", want: StatusOK},
+		{name: "one minor older", client: "1.33.2", k8sVersion: "1.34.0", want: StatusOK},
+		{name: "two minors newer", client: "1.36.0", k8sVersion: "1.34.0", want: StatusIncompatible},
+		{name: "two minors older", client: "1.32.0", k8sVersion: "1.34.0", want: StatusIncompatible},
+		{name: "unparsable cluster version", client: "1.36.0", k8sVersion: "latest", want: StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Detected{Name: "kubectl", Version: tt.client, Status: StatusOK}
+			checkKubectlSkew(&result, tt.k8sVersion)
+			if result.Status != tt.want {
+				t.Errorf("status = %s (%s), want %s", result.Status, result.Message, tt.want)
+			}
+		})
+	}
+}
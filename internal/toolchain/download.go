@@ -0,0 +1,136 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package toolchain
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+// DownloadFile streams url into dst and returns the sha256 of the content
+func DownloadFile(ctx context.Context, client *http.Client, url, dst string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, hash), resp.Body); err != nil {
+		_ = out.Close()
+		return "", fmt.Errorf("failed to download %s: %w", url, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), out.Close()
+}
+
+// FetchChecksum downloads a checksum file and returns the digest for the
+// artifact published at artifactURL
+func FetchChecksum(ctx context.Context, client *http.Client, checksumURL, artifactURL string) (string, error) {
+	body, err := fetch(ctx, client, checksumURL)
+	if err != nil {
+		return "", err
+	}
+	return parseChecksum(string(body), fileNameFromURL(artifactURL))
+}
+
+// parseChecksum extracts the digest for fileName from a checksum document.
+// It accepts a bare digest or the "<digest>  <file>" format of sha256sum.
+func parseChecksum(content, fileName string) (string, error) {
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 1 && len(lines) == 1:
+			return strings.ToLower(fields[0]), nil
+		case len(fields) >= 2 && strings.TrimPrefix(fields[1], "*") == fileName:
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+
+	return "", fmt.Errorf("checksum for %s not found", fileName)
+}
+
+func fileNameFromURL(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		if unescaped, err := url.PathUnescape(path.Base(u.EscapedPath())); err == nil {
+			return path.Base(unescaped)
+		}
+	}
+	return path.Base(rawURL)
+}
+
+// RemoveTempDir deletes a temporary download directory, a failure only
+// leaves it behind
+func RemoveTempDir(dir string) {
+	if err := os.RemoveAll(dir); err != nil {
+		slog.Warn("Failed to remove temporary directory", "dir", dir, "error", err)
+	}
+}
+
+// extractTarGz copies the member named member of the archive at src into dst
+func extractTarGz(src, member, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s not found in archive", member)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || path.Clean(hdr.Name) != path.Clean(member) {
+			continue
+		}
+
+		out, err := os.Create(dst)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, tr); err != nil {
+			_ = out.Close()
+			return fmt.Errorf("failed to extract %s: %w", member, err)
+		}
+		return out.Close()
+	}
+}
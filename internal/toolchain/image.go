@@ -0,0 +1,76 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package toolchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// KindNodeRepository is the Docker Hub repository of kind node images
+const KindNodeRepository = "kindest/node"
+
+// Docker Hub endpoints used to resolve image digests
+var (
+	RegistryAuthURL = "https://auth.docker.io/token"
+	RegistryURL     = "https://registry-1.docker.io"
+)
+
+// ResolveKindNodeImage returns the digest-pinned kind node image for k8sVersion
+func (i *Installer) ResolveKindNodeImage(ctx context.Context, k8sVersion string) (string, error) {
+	tag := "v" + NormalizeVersion(k8sVersion)
+	digest, err := i.resolveImageDigest(ctx, KindNodeRepository, tag)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s:%s: %w", KindNodeRepository, tag, err)
+	}
+	return fmt.Sprintf("%s:%s@%s", KindNodeRepository, tag, digest), nil
+}
+
+// resolveImageDigest asks the registry for the manifest digest of repo:tag
+func (i *Installer) resolveImageDigest(ctx context.Context, repo, tag string) (string, error) {
+	tokenURL := fmt.Sprintf("%s?service=registry.docker.io&scope=%s", RegistryAuthURL,
+		url.QueryEscape("repository:"+repo+":pull"))
+	body, err := fetch(ctx, i.Client, tokenURL)
+	if err != nil {
+		return "", err
+	}
+
+	var auth struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(body, &auth); err != nil {
+		return "", fmt.Errorf("failed to parse registry token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, fmt.Sprintf("%s/v2/%s/manifests/%s", RegistryURL, repo, tag), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+auth.Token)
+	req.Header.Add("Accept", "application/vnd.oci.image.index.v1+json")
+	req.Header.Add("Accept", "application/vnd.docker.distribution.manifest.list.v2+json")
+	req.Header.Add("Accept", "application/vnd.docker.distribution.manifest.v2+json")
+
+	resp, err := i.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: registry returned %s", ErrNotFound, resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry returned %s", resp.Status)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("registry did not return a digest")
+	}
+	return digest, nil
+}
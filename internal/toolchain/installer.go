@@ -0,0 +1,303 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package toolchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+const (
+	// BinDirName is the directory, inside the config directory, holding managed tools
+	BinDirName = "bin"
+	// receiptsFileName records what blitzctl installed into a bin directory
+	receiptsFileName = ".tools.json"
+)
+
+// ErrNotFound is returned when a release or image does not exist upstream
+var ErrNotFound error = errdefs.NotFound("not found")
+
+// Receipt records a tool installed by blitzctl
+type Receipt struct {
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	SHA256      string    `json:"sha256"`
+	Path        string    `json:"path"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// Installer downloads, verifies and installs tools into a bin directory
+type Installer struct {
+	BinDir string
+	Client *http.Client
+	Out    io.Writer
+}
+
+// DefaultBinDir returns ~/.blitzctl/bin
+func DefaultBinDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, config.ConfigDirName, BinDirName), nil
+}
+
+// NewInstaller creates an installer targeting binDir
+func NewInstaller(binDir string) *Installer {
+	return &Installer{
+		BinDir: binDir,
+		Client: &http.Client{Timeout: 10 * time.Minute},
+		Out:    os.Stdout,
+	}
+}
+
+// ResolveVersion returns the version to install: the explicit one, "latest"
+// resolved through the tool's version source, or the configured pin
+func (i *Installer) ResolveVersion(ctx context.Context, tool *Tool, requested string, defaults config.Defaults) (string, error) {
+	switch requested {
+	case "":
+		if pinned := NormalizeVersion(tool.Pinned(defaults)); pinned != "" {
+			return pinned, nil
+		}
+		fallthrough
+	case "latest":
+		version, err := tool.Source.Latest(ctx, i.Client)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve latest %s version: %w", tool.Name, err)
+		}
+		return version, nil
+	default:
+		return NormalizeVersion(requested), nil
+	}
+}
+
+// Install downloads version of tool for the current platform, verifies it
+// against the published checksum and places it in the bin directory
+func (i *Installer) Install(ctx context.Context, tool *Tool, version string) (*Receipt, error) {
+	return i.InstallVerified(ctx, tool, version, "")
+}
+
+// InstallVerified is like Install but verifies the download against the
+// expected sha256 instead of the published one when expected is not empty
+func (i *Installer) InstallVerified(ctx context.Context, tool *Tool, version, expected string) (*Receipt, error) {
+	if err := os.MkdirAll(i.BinDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", i.BinDir, err)
+	}
+
+	tmpDir, err := os.MkdirTemp(i.BinDir, ".download-")
+	if err != nil {
+		return nil, err
+	}
+	defer RemoveTempDir(tmpDir)
+
+	binary, digest, err := i.Download(ctx, tool, version, expected, tmpDir)
+	if err != nil {
+		return nil, err
+	}
+
+	target := filepath.Join(i.BinDir, tool.FileName(runtime.GOOS))
+	if err := os.Rename(binary, target); err != nil {
+		return nil, fmt.Errorf("failed to install %s: %w", target, err)
+	}
+
+	receipt := Receipt{
+		Name:        tool.Name,
+		Version:     NormalizeVersion(version),
+		SHA256:      digest,
+		Path:        target,
+		InstalledAt: time.Now(),
+	}
+	if err := i.saveReceipt(receipt); err != nil {
+		return nil, err
+	}
+
+	return &receipt, nil
+}
+
+// Download fetches version of tool for the current platform into dir,
+// verifies it and returns the path of the executable and the sha256 of the
+// downloaded artifact. The published checksum is used when expected is empty.
+func (i *Installer) Download(ctx context.Context, tool *Tool, version, expected, dir string) (string, string, error) {
+	platform := CurrentPlatform(version)
+
+	downloadURL, err := tool.DownloadURL(platform)
+	if err != nil {
+		return "", "", err
+	}
+	checksumURL, err := tool.ChecksumFileURL(platform)
+	if err != nil {
+		return "", "", err
+	}
+	member, err := tool.BinaryPath(platform)
+	if err != nil {
+		return "", "", err
+	}
+
+	_, _ = fmt.Fprintf(i.Out, "⬇️ Downloading %s %s (%s/%s)...\n", tool.Name, platform.Version, platform.OS, platform.Arch)
+	artifact := filepath.Join(dir, fileNameFromURL(downloadURL))
+	digest, err := DownloadFile(ctx, i.Client, downloadURL, artifact)
+	if err != nil {
+		return "", "", err
+	}
+
+	expected = strings.ToLower(expected)
+	if expected == "" {
+		expected, err = FetchChecksum(ctx, i.Client, checksumURL, downloadURL)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get checksum for %s: %w", tool.Name, err)
+		}
+	}
+	if digest != expected {
+		return "", "", fmt.Errorf("checksum mismatch for %s: expected %s, got %s", tool.Name, expected, digest)
+	}
+	_, _ = fmt.Fprintf(i.Out, "🔐 Checksum verified (sha256:%s)\n", digest)
+
+	binary := artifact
+	if tool.Archive == TarGz {
+		_, _ = fmt.Fprintf(i.Out, "📦 Extracting %s...\n", member)
+		binary = filepath.Join(dir, tool.FileName(runtime.GOOS))
+		if err := extractTarGz(artifact, member, binary); err != nil {
+			return "", "", err
+		}
+	}
+
+	if err := os.Chmod(binary, 0755); err != nil {
+		return "", "", err
+	}
+	return binary, digest, nil
+}
+
+// PublishedChecksum returns the published sha256 of version of tool for platform
+func (i *Installer) PublishedChecksum(ctx context.Context, tool *Tool, platform Platform) (string, error) {
+	downloadURL, err := tool.DownloadURL(platform)
+	if err != nil {
+		return "", err
+	}
+	checksumURL, err := tool.ChecksumFileURL(platform)
+	if err != nil {
+		return "", err
+	}
+	return FetchChecksum(ctx, i.Client, checksumURL, downloadURL)
+}
+
+// Available checks that version of tool is published for the current
+// platform, returning ErrNotFound when the download does not exist
+func (i *Installer) Available(ctx context.Context, tool *Tool, version string) error {
+	downloadURL, err := tool.DownloadURL(CurrentPlatform(version))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, downloadURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := i.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %s %s", ErrNotFound, tool.Name, NormalizeVersion(version))
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("failed to check %s: %s", downloadURL, resp.Status)
+	}
+	return nil
+}
+
+// Remove deletes a tool installed by blitzctl
+func (i *Installer) Remove(tool *Tool) error {
+	receipts, err := i.Receipts()
+	if err != nil {
+		return err
+	}
+
+	receipt, ok := receipts[tool.Name]
+	if !ok {
+		return errdefs.NotFound("%s is not installed by blitzctl in %s", tool.Name, i.BinDir)
+	}
+
+	if err := os.Remove(receipt.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove %s: %w", receipt.Path, err)
+	}
+
+	delete(receipts, tool.Name)
+	return i.writeReceipts(receipts)
+}
+
+// Which returns the path of the tool that would be used: the managed copy
+// when installed, otherwise the first match on PATH
+func (i *Installer) Which(tool *Tool) (string, bool, error) {
+	receipts, err := i.Receipts()
+	if err != nil {
+		return "", false, err
+	}
+	if receipt, ok := receipts[tool.Name]; ok {
+		if _, err := os.Stat(receipt.Path); err == nil {
+			return receipt.Path, true, nil
+		}
+	}
+
+	path, err := exec.LookPath(tool.Name)
+	if err != nil {
+		return "", false, errdefs.ToolMissing("%s not found in %s or on PATH", tool.Name, i.BinDir).WithHint("blitzctl tool install %s", tool.Name)
+	}
+	return path, false, nil
+}
+
+// Receipts returns the tools installed in the bin directory keyed by name
+func (i *Installer) Receipts() (map[string]Receipt, error) {
+	receipts := map[string]Receipt{}
+
+	data, err := os.ReadFile(filepath.Join(i.BinDir, receiptsFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return receipts, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &receipts); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", receiptsFileName, err)
+	}
+	return receipts, nil
+}
+
+func (i *Installer) saveReceipt(receipt Receipt) error {
+	receipts, err := i.Receipts()
+	if err != nil {
+		return err
+	}
+	receipts[receipt.Name] = receipt
+	return i.writeReceipts(receipts)
+}
+
+func (i *Installer) writeReceipts(receipts map[string]Receipt) error {
+	data, err := json.MarshalIndent(receipts, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(i.BinDir, receiptsFileName+".tmp")
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(i.BinDir, receiptsFileName))
+}
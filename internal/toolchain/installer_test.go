@@ -0,0 +1,208 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package toolchain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+)
+
+const digest = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestParseChecksum(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		fileName string
+		want     string
+		wantErr  bool
+	}{
+		{name: "bare digest", content: digest + "\n", fileName: "kind-linux-amd64", want: digest},
+		{name: "bare digest upper case", content: "0123456789ABCDEF0123456789ABCDEF0123456789ABCDEF0123456789ABCDEF", fileName: "kind", want: digest},
+		{
+			name:     "sha256sum listing",
+			content:  "ffff  helm-v3.19.0-darwin-arm64.tar.gz\n" + digest + "  helm-v3.19.0-linux-amd64.tar.gz\n",
+			fileName: "helm-v3.19.0-linux-amd64.tar.gz",
+			want:     digest,
+		},
+		{name: "binary mode marker", content: digest + " *k9s_Linux_amd64.tar.gz\n", fileName: "k9s_Linux_amd64.tar.gz", want: digest},
+		{name: "file not listed", content: digest + "  other.tar.gz\nffff  another.tar.gz\n", fileName: "kind", wantErr: true},
+		{name: "empty document", content: "", fileName: "kind", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseChecksum(tt.content, tt.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseChecksum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseChecksum() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileNameFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://get.helm.sh/helm-v3.19.0-linux-amd64.tar.gz", want: "helm-v3.19.0-linux-amd64.tar.gz"},
+		{url: "https://dl.k8s.io/release/v1.34.0/bin/linux/amd64/kubectl?mirror=1", want: "kubectl"},
+		{url: "https://example.com/download/kustomize%2Fv5.7.1/kustomize_v5.7.1_linux_amd64.tar.gz", want: "kustomize_v5.7.1_linux_amd64.tar.gz"},
+		{url: "https://example.com/files/my%20tool", want: "my tool"},
+	}
+	for _, tt := range tests {
+		if got := fileNameFromURL(tt.url); got != tt.want {
+			t.Errorf("fileNameFromURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestReceipts(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "no receipts file", want: map[string]string{}},
+		{
+			name:    "recorded tools",
+			content: `{"kind": {"name": "kind", "version": "0.30.0"}, "helm": {"name": "helm", "version": "3.19.0"}}`,
+			want:    map[string]string{"kind": "0.30.0", "helm": "3.19.0"},
+		},
+		{name: "corrupt file", content: `{"kind": `, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := NewInstaller(t.TempDir())
+			if tt.content != "" {
+				if err := os.WriteFile(filepath.Join(i.BinDir, receiptsFileName), []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			receipts, err := i.Receipts()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Receipts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(receipts) != len(tt.want) {
+				t.Errorf("Receipts() = %v, want %v", receipts, tt.want)
+			}
+			for name, version := range tt.want {
+				if receipts[name].Version != version {
+					t.Errorf("%s version = %q, want %q", name, receipts[name].Version, version)
+				}
+			}
+		})
+	}
+}
+
+func TestSaveReceipt(t *testing.T) {
+	i := NewInstaller(t.TempDir())
+	installed := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, receipt := range []Receipt{
+		{Name: "kind", Version: "0.29.0", SHA256: digest, Path: filepath.Join(i.BinDir, "kind"), InstalledAt: installed},
+		{Name: "helm", Version: "3.19.0", InstalledAt: installed},
+		{Name: "kind", Version: "0.30.0", SHA256: digest, Path: filepath.Join(i.BinDir, "kind"), InstalledAt: installed},
+	} {
+		if err := i.saveReceipt(receipt); err != nil {
+			t.Fatalf("saveReceipt(%s) error = %v", receipt.Name, err)
+		}
+	}
+
+	receipts, err := i.Receipts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(receipts) != 2 {
+		t.Fatalf("Receipts() = %v, want kind and helm", receipts)
+	}
+	kind := receipts["kind"]
+	if kind.Version != "0.30.0" || kind.SHA256 != digest || !kind.InstalledAt.Equal(installed) {
+		t.Errorf("kind receipt = %+v, want the last one saved", kind)
+	}
+	if _, err := os.Stat(filepath.Join(i.BinDir, receiptsFileName+".tmp")); !os.IsNotExist(err) {
+		t.Errorf("temporary receipts file left behind, stat error = %v", err)
+	}
+}
+
+// fakeSource is a VersionSource answering latest, or failing without one
+type fakeSource struct {
+	latest string
+}
+
+// Latest implements VersionSource
+func (s fakeSource) Latest(ctx context.Context, client *http.Client) (string, error) {
+	if s.latest == "" {
+		return "", errors.New("offline")
+	}
+	return s.latest, nil
+}
+
+func TestResolveVersion(t *testing.T) {
+	tool := &Tool{
+		Name:   "kind",
+		Source: fakeSource{latest: "0.30.0"},
+		Pinned: func(d config.Defaults) string { return d.KindVersion },
+	}
+	offline := &Tool{Name: "kind", Source: fakeSource{}, Pinned: tool.Pinned}
+
+	tests := []struct {
+		name      string
+		tool      *Tool
+		requested string
+		pinned    string
+		want      string
+		wantErr   bool
+	}{
+		{name: "explicit version", tool: tool, requested: "v0.27.0", pinned: "0.29.0", want: "0.27.0"},
+		{name: "pinned version", tool: tool, pinned: "v0.29.0", want: "0.29.0"},
+		{name: "latest without a pin", tool: tool, want: "0.30.0"},
+		{name: "latest despite a pin", tool: tool, requested: "latest", pinned: "0.29.0", want: "0.30.0"},
+		{name: "pin needs no lookup", tool: offline, pinned: "0.29.0", want: "0.29.0"},
+		{name: "latest lookup fails", tool: offline, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := NewInstaller(t.TempDir())
+			got, err := i.ResolveVersion(context.Background(), tt.tool, tt.requested, config.Defaults{KindVersion: tt.pinned})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tool := &Tool{Name: "kind"}
+	tests := []struct {
+		goos string
+		want string
+	}{
+		{goos: "linux", want: "kind"},
+		{goos: "darwin", want: "kind"},
+		{goos: "windows", want: "kind.exe"},
+	}
+	for _, tt := range tests {
+		if got := tool.FileName(tt.goos); got != tt.want {
+			t.Errorf("FileName(%s) = %s, want %s", tt.goos, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package toolchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"gopkg.in/yaml.v3"
+)
+
+// LockFileName is the name of the project lockfile inside ./.blitzctl
+const LockFileName = "blitzctl.lock"
+
+// LockPlatforms are the platforms recorded in a lockfile
+var LockPlatforms = []Platform{
+	{OS: "darwin", Arch: "amd64"},
+	{OS: "darwin", Arch: "arm64"},
+	{OS: "linux", Arch: "amd64"},
+	{OS: "linux", Arch: "arm64"},
+}
+
+// Lockfile records the exact toolchain of a project
+type Lockfile struct {
+	Tools map[string]LockedTool `yaml:"tools"`
+	// KindNodeImage is the digest-pinned kind node image, e.g.
+	// kindest/node:v1.36.2@sha256:...
+	KindNodeImage string `yaml:"kind_node_image,omitempty"`
+}
+
+// LockedTool is a tool version with its sha256 per "os/arch"
+type LockedTool struct {
+	Version   string            `yaml:"version"`
+	Checksums map[string]string `yaml:"checksums"`
+}
+
+// PlatformKey returns the "os/arch" key used in lockfiles
+func (p Platform) PlatformKey() string {
+	return p.OS + "/" + p.Arch
+}
+
+// ProjectLockPath returns ./.blitzctl/blitzctl.lock
+func ProjectLockPath() (string, error) {
+	dir, err := config.ProjectConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, LockFileName), nil
+}
+
+// LoadLockfile reads a lockfile from path
+func LoadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &Lockfile{}
+	if err := yaml.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return lock, nil
+}
+
+// LoadProjectLockfile reads the project lockfile, returning nil when the
+// project has none
+func LoadProjectLockfile() (*Lockfile, error) {
+	path, err := ProjectLockPath()
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := LoadLockfile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return lock, err
+}
+
+// Save writes the lockfile to path
+func (l *Lockfile) Save(path string) error {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	header := "# Generated by 'blitzctl tool lock'. Do not edit by hand.\n"
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append([]byte(header), data...), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// ToolNames returns the locked tool names sorted alphabetically
+func (l *Lockfile) ToolNames() []string {
+	names := make([]string, 0, len(l.Tools))
+	for name := range l.Tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// KindImage returns the locked kind node image for k8sVersion, or an empty
+// string when the lock pins a different version
+func (l *Lockfile) KindImage(k8sVersion string) string {
+	if l == nil || l.KindNodeImage == "" {
+		return ""
+	}
+	if !strings.HasPrefix(l.KindNodeImage, KindNodeRepository+":v"+NormalizeVersion(k8sVersion)+"@") {
+		return ""
+	}
+	return l.KindNodeImage
+}
+
+// Lock resolves the published checksums of version of tool for every
+// platform in LockPlatforms
+func (i *Installer) Lock(ctx context.Context, tool *Tool, version string) (LockedTool, error) {
+	locked := LockedTool{
+		Version:   NormalizeVersion(version),
+		Checksums: map[string]string{},
+	}
+
+	for _, p := range LockPlatforms {
+		p.Version = locked.Version
+		digest, err := i.PublishedChecksum(ctx, tool, p)
+		if err != nil {
+			return locked, fmt.Errorf("failed to lock %s %s for %s: %w", tool.Name, locked.Version, p.PlatformKey(), err)
+		}
+		locked.Checksums[p.PlatformKey()] = digest
+	}
+	return locked, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package toolchain
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+)
+
+// ProjectBinDir returns ./.blitzctl/bin, where 'tool sync' installs the
+// locked toolchain
+func ProjectBinDir() (string, error) {
+	dir, err := config.ProjectConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, BinDirName), nil
+}
+
+// IgnoreProjectBinDir makes git ignore the project bin directory, adding
+// bin/ to ./.blitzctl/.gitignore unless it is already listed, so the
+// synced binaries aren't committed along with the lockfile
+func IgnoreProjectBinDir() error {
+	dir, err := config.ProjectConfigDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, ".gitignore")
+	entry := BinDirName + "/"
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		switch strings.TrimSpace(line) {
+		case entry, BinDirName, "/" + entry, "/" + BinDirName:
+			return nil
+		}
+	}
+
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		data = append(data, '\n')
+	}
+	data = append(data, entry+"\n"...)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// BinDirs returns the existing managed bin directories in lookup order:
+// the project one first, then the user one
+func BinDirs() []string {
+	var dirs []string
+	if dir, err := ProjectBinDir(); err == nil {
+		dirs = append(dirs, dir)
+	}
+	if dir, err := DefaultBinDir(); err == nil {
+		dirs = append(dirs, dir)
+	}
+
+	existing := dirs[:0]
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			existing = append(existing, dir)
+		}
+	}
+	return existing
+}
+
+// ActivateBinDirs prepends the managed bin directories to PATH so the
+// commands blitzctl runs pick up the managed tools
+func ActivateBinDirs() error {
+	dirs := BinDirs()
+	if len(dirs) == 0 {
+		return nil
+	}
+	return os.Setenv("PATH", PrependPath(os.Getenv("PATH"), dirs...))
+}
+
+// PrependPath returns path with dirs in front, dropping duplicates
+func PrependPath(path string, dirs ...string) string {
+	seen := map[string]bool{}
+	var entries []string
+	for _, dir := range dirs {
+		if !seen[dir] {
+			seen[dir] = true
+			entries = append(entries, dir)
+		}
+	}
+	for _, entry := range filepath.SplitList(path) {
+		if entry != "" && !seen[entry] {
+			seen[entry] = true
+			entries = append(entries, entry)
+		}
+	}
+	return strings.Join(entries, string(os.PathListSeparator))
+}
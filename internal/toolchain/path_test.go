@@ -0,0 +1,52 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package toolchain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+)
+
+func TestIgnoreProjectBinDir(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "no .gitignore", want: "bin/\n"},
+		{name: "other entries", content: "*.bak", want: "*.bak\nbin/\n"},
+		{name: "already ignored", content: "*.bak\nbin/\n", want: "*.bak\nbin/\n"},
+		{name: "ignored from the root", content: "/bin\n", want: "/bin\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			path := filepath.Join(config.ConfigDirName, ".gitignore")
+			if tt.content != "" {
+				if err := os.MkdirAll(config.ConfigDirName, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for range 2 {
+				if err := IgnoreProjectBinDir(); err != nil {
+					t.Fatalf("IgnoreProjectBinDir() error = %v", err)
+				}
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf(".gitignore = %q, want %q", data, tt.want)
+			}
+		})
+	}
+}
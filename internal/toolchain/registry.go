@@ -0,0 +1,215 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package toolchain
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/errdefs"
+)
+
+// ArchiveFormat describes how a tool is packaged for download
+type ArchiveFormat string
+
+const (
+	// Binary means the download is the executable itself
+	Binary ArchiveFormat = "binary"
+	// TarGz means the executable lives inside a gzipped tarball
+	TarGz ArchiveFormat = "tar.gz"
+)
+
+// Tool is a declarative description of a downloadable CLI tool
+//
+// URL, ChecksumURL and ArchivePath are text/template strings rendered with
+// Platform, so a single entry covers every supported OS/arch.
+type Tool struct {
+	Name        string
+	Description string
+	// Source is used to resolve the latest released version
+	Source VersionSource
+	// URL is the download URL template
+	URL string
+	// ChecksumURL is the template for a sha256 file, either a bare digest or
+	// a "<digest>  <file>" list as produced by sha256sum
+	ChecksumURL string
+	Archive     ArchiveFormat
+	// ArchivePath is the path of the executable inside the archive
+	ArchivePath string
+	// Pinned returns the version pinned in the configuration defaults
+	Pinned func(d config.Defaults) string
+}
+
+// Platform holds the values available to the URL templates
+type Platform struct {
+	Version string
+	OS      string
+	Arch    string
+}
+
+// CurrentPlatform returns the platform blitzctl is running on
+func CurrentPlatform(version string) Platform {
+	return Platform{
+		Version: NormalizeVersion(version),
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+	}
+}
+
+// NormalizeVersion strips a leading "v" so "v3.18.6" and "3.18.6" are equivalent
+func NormalizeVersion(version string) string {
+	return strings.TrimPrefix(strings.TrimSpace(version), "v")
+}
+
+var templateFuncs = template.FuncMap{
+	"title": func(s string) string {
+		if s == "" {
+			return s
+		}
+		return strings.ToUpper(s[:1]) + s[1:]
+	},
+}
+
+func render(name, text string, p Platform) (string, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template for %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, p); err != nil {
+		return "", fmt.Errorf("failed to render template for %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// DownloadURL renders the download URL for the given platform
+func (t *Tool) DownloadURL(p Platform) (string, error) {
+	return render(t.Name, t.URL, p)
+}
+
+// ChecksumFileURL renders the checksum URL for the given platform
+func (t *Tool) ChecksumFileURL(p Platform) (string, error) {
+	return render(t.Name, t.ChecksumURL, p)
+}
+
+// FileName returns the name of the tool's executable on goos, Windows only
+// runs it with the .exe suffix
+func (t *Tool) FileName(goos string) string {
+	if goos == "windows" {
+		return t.Name + ".exe"
+	}
+	return t.Name
+}
+
+// BinaryPath renders the path of the executable inside the archive
+func (t *Tool) BinaryPath(p Platform) (string, error) {
+	if t.Archive == Binary {
+		return t.Name, nil
+	}
+	return render(t.Name, t.ArchivePath, p)
+}
+
+// builtinTools is the registry of tools blitzctl knows how to manage
+var builtinTools = []*Tool{
+	{
+		Name:        "kubectl",
+		Description: "Kubernetes command-line tool",
+		Source:      URLSource("https://dl.k8s.io/release/stable.txt"),
+		URL:         "https://dl.k8s.io/release/v{{.Version}}/bin/{{.OS}}/{{.Arch}}/kubectl",
+		ChecksumURL: "https://dl.k8s.io/release/v{{.Version}}/bin/{{.OS}}/{{.Arch}}/kubectl.sha256",
+		Archive:     Binary,
+		Pinned:      func(d config.Defaults) string { return d.KubectlVersion },
+	},
+	{
+		Name:        "kind",
+		Description: "Kubernetes IN Docker",
+		Source:      GitHubSource("kubernetes-sigs/kind"),
+		URL:         "https://github.com/kubernetes-sigs/kind/releases/download/v{{.Version}}/kind-{{.OS}}-{{.Arch}}",
+		ChecksumURL: "https://github.com/kubernetes-sigs/kind/releases/download/v{{.Version}}/kind-{{.OS}}-{{.Arch}}.sha256sum",
+		Archive:     Binary,
+		Pinned:      func(d config.Defaults) string { return d.KindVersion },
+	},
+	{
+		Name:        "minikube",
+		Description: "Local Kubernetes in a VM or container",
+		Source:      GitHubSource("kubernetes/minikube"),
+		URL:         "https://github.com/kubernetes/minikube/releases/download/v{{.Version}}/minikube-{{.OS}}-{{.Arch}}",
+		ChecksumURL: "https://github.com/kubernetes/minikube/releases/download/v{{.Version}}/minikube-{{.OS}}-{{.Arch}}.sha256",
+		Archive:     Binary,
+		Pinned:      func(d config.Defaults) string { return d.MinikubeVersion },
+	},
+	{
+		Name:        "helm",
+		Description: "The Kubernetes package manager",
+		Source:      GitHubSource("helm/helm"),
+		URL:         "https://get.helm.sh/helm-v{{.Version}}-{{.OS}}-{{.Arch}}.tar.gz",
+		ChecksumURL: "https://get.helm.sh/helm-v{{.Version}}-{{.OS}}-{{.Arch}}.tar.gz.sha256sum",
+		Archive:     TarGz,
+		ArchivePath: "{{.OS}}-{{.Arch}}/helm",
+		Pinned:      func(d config.Defaults) string { return d.HelmVersion },
+	},
+	{
+		Name:        "k9s",
+		Description: "Terminal UI for Kubernetes clusters",
+		Source:      GitHubSource("derailed/k9s"),
+		URL:         "https://github.com/derailed/k9s/releases/download/v{{.Version}}/k9s_{{title .OS}}_{{.Arch}}.tar.gz",
+		ChecksumURL: "https://github.com/derailed/k9s/releases/download/v{{.Version}}/checksums.sha256",
+		Archive:     TarGz,
+		ArchivePath: "k9s",
+		Pinned:      func(d config.Defaults) string { return d.K9sVersion },
+	},
+	{
+		Name:        "kustomize",
+		Description: "Template-free Kubernetes manifest customization",
+		Source:      GitHubSource("kubernetes-sigs/kustomize").WithTagPrefix("kustomize/"),
+		URL:         "https://github.com/kubernetes-sigs/kustomize/releases/download/kustomize%2Fv{{.Version}}/kustomize_v{{.Version}}_{{.OS}}_{{.Arch}}.tar.gz",
+		ChecksumURL: "https://github.com/kubernetes-sigs/kustomize/releases/download/kustomize%2Fv{{.Version}}/checksums.txt",
+		Archive:     TarGz,
+		ArchivePath: "kustomize",
+		Pinned:      func(d config.Defaults) string { return d.KustomizeVersion },
+	},
+	{
+		Name:        "stern",
+		Description: "Multi pod and container log tailing",
+		Source:      GitHubSource("stern/stern"),
+		URL:         "https://github.com/stern/stern/releases/download/v{{.Version}}/stern_{{.Version}}_{{.OS}}_{{.Arch}}.tar.gz",
+		ChecksumURL: "https://github.com/stern/stern/releases/download/v{{.Version}}/checksums.txt",
+		Archive:     TarGz,
+		ArchivePath: "stern",
+		Pinned:      func(d config.Defaults) string { return d.SternVersion },
+	},
+}
+
+// Tools returns every registered tool sorted by name
+func Tools() []*Tool {
+	tools := make([]*Tool, len(builtinTools))
+	copy(tools, builtinTools)
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return tools
+}
+
+// Names returns the names of every registered tool
+func Names() []string {
+	names := make([]string, 0, len(builtinTools))
+	for _, t := range Tools() {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// Lookup returns the tool registered under name
+func Lookup(name string) (*Tool, error) {
+	normalized := strings.TrimSpace(strings.ToLower(name))
+	for _, t := range builtinTools {
+		if t.Name == normalized {
+			return t, nil
+		}
+	}
+	return nil, errdefs.NotFound("unknown tool: %s (supported: %s)", name, strings.Join(Names(), ", "))
+}
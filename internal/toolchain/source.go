@@ -0,0 +1,118 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package toolchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// VersionSource resolves the latest released version of a tool
+type VersionSource interface {
+	Latest(ctx context.Context, client *http.Client) (string, error)
+}
+
+// URLSource reads the latest version from a plain text document such as
+// https://dl.k8s.io/release/stable.txt
+type URLSource string
+
+// Latest implements VersionSource
+func (s URLSource) Latest(ctx context.Context, client *http.Client) (string, error) {
+	body, err := fetch(ctx, client, string(s))
+	if err != nil {
+		return "", err
+	}
+	return NormalizeVersion(string(body)), nil
+}
+
+// GitHubRelease resolves the latest release of a GitHub repository
+type GitHubRelease struct {
+	Repo      string
+	TagPrefix string
+}
+
+// GitHubSource returns a version source backed by GitHub releases
+func GitHubSource(repo string) GitHubRelease {
+	return GitHubRelease{Repo: repo}
+}
+
+// WithTagPrefix strips prefix from release tags (e.g. "kustomize/v5.4.3")
+func (s GitHubRelease) WithTagPrefix(prefix string) GitHubRelease {
+	s.TagPrefix = prefix
+	return s
+}
+
+// GitHubAPIURL is the base URL of the GitHub REST API
+var GitHubAPIURL = "https://api.github.com"
+
+// Latest implements VersionSource
+func (s GitHubRelease) Latest(ctx context.Context, client *http.Client) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases", GitHubAPIURL, s.Repo)
+	if s.TagPrefix == "" {
+		url += "/latest"
+	}
+
+	body, err := fetch(ctx, client, url)
+	if err != nil {
+		return "", err
+	}
+
+	// Repositories that release several components only have a meaningful
+	// "latest" when filtered by tag prefix
+	if s.TagPrefix != "" {
+		var releases []struct {
+			TagName    string `json:"tag_name"`
+			Prerelease bool   `json:"prerelease"`
+			Draft      bool   `json:"draft"`
+		}
+		if err := json.Unmarshal(body, &releases); err != nil {
+			return "", fmt.Errorf("failed to parse releases of %s: %w", s.Repo, err)
+		}
+		for _, r := range releases {
+			if !r.Draft && !r.Prerelease && strings.HasPrefix(r.TagName, s.TagPrefix) {
+				return NormalizeVersion(strings.TrimPrefix(r.TagName, s.TagPrefix)), nil
+			}
+		}
+		return "", fmt.Errorf("no release of %s matches %q", s.Repo, s.TagPrefix)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.Unmarshal(body, &release); err != nil {
+		return "", fmt.Errorf("failed to parse latest release of %s: %w", s.Repo, err)
+	}
+	if release.TagName == "" {
+		return "", fmt.Errorf("no release found for %s", s.Repo)
+	}
+	return NormalizeVersion(release.TagName), nil
+}
+
+// fetch performs a GET request and returns the body of a 2xx response
+func fetch(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	return body, nil
+}
@@ -0,0 +1,252 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/OneideLuizSchneider/blitzctl/config"
+	"github.com/OneideLuizSchneider/blitzctl/internal/toolchain"
+)
+
+// BackupSuffix is appended to a binary's path to name its backup
+const BackupSuffix = ".bak"
+
+// Options controls an install or upgrade
+type Options struct {
+	// Version to install; empty uses the version pinned in the configuration,
+	// or the latest release when none is, and "latest" the latest release
+	Version string
+	// BinDir overrides where the binary is installed
+	BinDir string
+}
+
+// Result describes a completed update
+type Result struct {
+	Path    string
+	Version string
+	SHA256  string
+	Backup  string
+}
+
+// Updater installs and upgrades a tool binary in place: it resolves the
+// target version, downloads it for the current OS/arch, verifies its
+// checksum and atomically replaces the existing binary, keeping a backup
+// that Rollback can restore.
+type Updater struct {
+	Tool      *toolchain.Tool
+	Installer *toolchain.Installer
+}
+
+// New returns an updater for the tool registered under name
+func New(name string) (*Updater, error) {
+	tool, err := toolchain.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	binDir, err := toolchain.DefaultBinDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Updater{
+		Tool:      tool,
+		Installer: toolchain.NewInstaller(binDir),
+	}, nil
+}
+
+// Installed returns the path of the tool on PATH, if any
+func (u *Updater) Installed() (string, bool) {
+	path, err := exec.LookPath(u.Tool.Name)
+	return path, err == nil
+}
+
+// Target returns the path that Update will write: the binary in BinDir
+// when set, the existing binary when installed, otherwise a binary in
+// DefaultInstallDir
+func (u *Updater) Target(opts Options) (string, error) {
+	if opts.BinDir != "" {
+		return filepath.Join(opts.BinDir, u.Tool.FileName(runtime.GOOS)), nil
+	}
+	if path, ok := u.Installed(); ok {
+		resolved, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return "", err
+		}
+		if isPackageManaged(resolved) {
+			return "", fmt.Errorf("%s at %s is managed by Homebrew; run 'brew upgrade %s' or pass --bin-dir",
+				u.Tool.Name, path, u.Tool.Name)
+		}
+		return resolved, nil
+	}
+	return filepath.Join(DefaultInstallDir(), u.Tool.FileName(runtime.GOOS)), nil
+}
+
+// Update installs opts.Version of the tool, replacing the existing binary
+func (u *Updater) Update(ctx context.Context, opts Options) (*Result, error) {
+	target, err := u.Target(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := u.Installer.ResolveVersion(ctx, u.Tool, opts.Version, config.GetManager().GetDefaults())
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(target)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, permissionHint(fmt.Errorf("failed to create %s: %w", dir, err))
+	}
+
+	// Download next to the target so the final rename is atomic
+	tmpDir, err := os.MkdirTemp(dir, ".blitzctl-"+u.Tool.Name+"-")
+	if err != nil {
+		return nil, permissionHint(fmt.Errorf("failed to write to %s: %w", dir, err))
+	}
+	defer toolchain.RemoveTempDir(tmpDir)
+
+	binary, digest, err := u.Installer.Download(ctx, u.Tool, version, "", tmpDir)
+	if err != nil {
+		return nil, err
+	}
+
+	backup, err := Replace(target, binary)
+	if err != nil {
+		return nil, permissionHint(err)
+	}
+
+	return &Result{
+		Path:    target,
+		Version: version,
+		SHA256:  digest,
+		Backup:  backup,
+	}, nil
+}
+
+// Rollback restores the backup taken by the last update of the tool
+func (u *Updater) Rollback(opts Options) (string, error) {
+	target, err := u.Target(opts)
+	if err != nil {
+		return "", err
+	}
+	if err := Rollback(target); err != nil {
+		return "", permissionHint(err)
+	}
+	return target, nil
+}
+
+// Replace atomically moves src over target, first copying the current
+// target to target+BackupSuffix. It returns the backup path, or an empty
+// string when there was nothing to back up. src must be on the same
+// filesystem as target.
+func Replace(target, src string) (string, error) {
+	backup := ""
+	if _, err := os.Stat(target); err == nil {
+		backup = target + BackupSuffix
+		if err := copyFile(target, backup); err != nil {
+			return "", fmt.Errorf("failed to back up %s: %w", target, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	if err := os.Chmod(src, 0755); err != nil {
+		return "", err
+	}
+	if err := os.Rename(src, target); err != nil {
+		return "", fmt.Errorf("failed to replace %s: %w", target, err)
+	}
+	return backup, nil
+}
+
+// Rollback atomically restores target from target+BackupSuffix
+func Rollback(target string) error {
+	backup := target + BackupSuffix
+	if _, err := os.Stat(backup); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no backup found at %s", backup)
+		}
+		return err
+	}
+	if err := os.Rename(backup, target); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", target, err)
+	}
+	return nil
+}
+
+// DefaultInstallDir returns /usr/local/bin when writable, otherwise the
+// managed tools directory ~/.blitzctl/bin, which blitzctl puts first on PATH
+// for the commands it runs. Unlike scripts/blitzctl.sh, which installs
+// blitzctl itself, it doesn't fall back to ~/.local/bin.
+func DefaultInstallDir() string {
+	if isWritableDir("/usr/local/bin") {
+		return "/usr/local/bin"
+	}
+	if dir, err := toolchain.DefaultBinDir(); err == nil {
+		return dir
+	}
+	return "."
+}
+
+func isWritableDir(dir string) bool {
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+	f, err := os.CreateTemp(dir, ".blitzctl-write-test-")
+	if err != nil {
+		return false
+	}
+	name := f.Name()
+	_ = f.Close()
+	return os.Remove(name) == nil
+}
+
+func isPackageManaged(path string) bool {
+	return strings.Contains(path, "/Cellar/") || strings.Contains(path, "/homebrew/")
+}
+
+func permissionHint(err error) error {
+	if errors.Is(err, os.ErrPermission) {
+		return fmt.Errorf("%w (re-run with sudo or pass --bin-dir)", err)
+	}
+	return err
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	tmp := dst + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
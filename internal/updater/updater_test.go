@@ -0,0 +1,156 @@
+/*
+Copyright © 2026 Oneide Luiz Schneider
+*/
+package updater
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string, mode os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestReplace(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   string
+		wantBackup bool
+	}{
+		{name: "existing binary is backed up", existing: "v1", wantBackup: true},
+		{name: "fresh install has no backup"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			target := filepath.Join(dir, "kind")
+			if tt.existing != "" {
+				writeFile(t, target, tt.existing, 0700)
+			}
+			src := filepath.Join(dir, "download")
+			writeFile(t, src, "v2", 0600)
+
+			backup, err := Replace(target, src)
+			if err != nil {
+				t.Fatalf("Replace() error = %v", err)
+			}
+
+			if got := readFile(t, target); got != "v2" {
+				t.Errorf("target content = %q, want %q", got, "v2")
+			}
+			info, err := os.Stat(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != 0755 {
+				t.Errorf("target mode = %v, want 0755", info.Mode().Perm())
+			}
+			if _, err := os.Stat(src); !os.IsNotExist(err) {
+				t.Errorf("src still exists after Replace, stat error = %v", err)
+			}
+
+			if !tt.wantBackup {
+				if backup != "" {
+					t.Errorf("backup = %q, want none", backup)
+				}
+				return
+			}
+			if backup != target+BackupSuffix {
+				t.Fatalf("backup = %q, want %q", backup, target+BackupSuffix)
+			}
+			if got := readFile(t, backup); got != tt.existing {
+				t.Errorf("backup content = %q, want %q", got, tt.existing)
+			}
+			info, err = os.Stat(backup)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != 0700 {
+				t.Errorf("backup mode = %v, want the original 0700", info.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestReplaceOverwritesOldBackup(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "kind")
+	writeFile(t, target, "v2", 0755)
+	writeFile(t, target+BackupSuffix, "v1", 0755)
+	src := filepath.Join(dir, "download")
+	writeFile(t, src, "v3", 0755)
+
+	if _, err := Replace(target, src); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if got := readFile(t, target+BackupSuffix); got != "v2" {
+		t.Errorf("backup content = %q, want the replaced %q", got, "v2")
+	}
+	if _, err := os.Stat(target + BackupSuffix + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary backup left behind, stat error = %v", err)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	tests := []struct {
+		name    string
+		backup  string
+		want    string
+		wantErr bool
+	}{
+		{name: "backup is restored", backup: "v1", want: "v1"},
+		{name: "missing backup fails", want: "v2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := filepath.Join(t.TempDir(), "kind")
+			writeFile(t, target, "v2", 0755)
+			if tt.backup != "" {
+				writeFile(t, target+BackupSuffix, tt.backup, 0755)
+			}
+
+			err := Rollback(target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Rollback() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := readFile(t, target); got != tt.want {
+				t.Errorf("target content = %q, want %q", got, tt.want)
+			}
+			if _, err := os.Stat(target + BackupSuffix); !os.IsNotExist(err) {
+				t.Errorf("backup still exists after Rollback, stat error = %v", err)
+			}
+		})
+	}
+}
+
+func TestReplaceThenRollback(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "minikube")
+	writeFile(t, target, "v1", 0755)
+	src := filepath.Join(dir, "download")
+	writeFile(t, src, "v2", 0755)
+
+	if _, err := Replace(target, src); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if err := Rollback(target); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if got := readFile(t, target); got != "v1" {
+		t.Errorf("target content = %q, want %q", got, "v1")
+	}
+}
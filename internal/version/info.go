@@ -0,0 +1,58 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Info describes the running blitzctl binary.
+type Info struct {
+	Version   string            `json:"version"`
+	GitCommit string            `json:"gitCommit"`
+	BuildDate string            `json:"buildDate"`
+	GoVersion string            `json:"goVersion"`
+	Platform  string            `json:"platform"`
+	Module    string            `json:"module,omitempty"`
+	Build     map[string]string `json:"build,omitempty"`
+}
+
+// Get returns the version information of the running binary. Values not
+// injected through -ldflags are filled from the Go build info when available.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	info.Module = buildInfo.Main.Path
+	info.Build = map[string]string{}
+	for _, setting := range buildInfo.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.GitCommit == "unknown" && len(setting.Value) >= 7 {
+				info.GitCommit = setting.Value[:7]
+			}
+		case "vcs.time":
+			if info.BuildDate == "unknown" {
+				info.BuildDate = setting.Value
+			}
+		}
+		switch setting.Key {
+		case "vcs.revision", "vcs.time", "vcs.modified", "-trimpath", "CGO_ENABLED", "GOARCH", "GOOS", "GOAMD64", "GOARM64":
+			info.Build[setting.Key] = setting.Value
+		}
+	}
+	if info.Version == "dev" && buildInfo.Main.Version != "" && buildInfo.Main.Version != "(devel)" {
+		info.Version = buildInfo.Main.Version
+	}
+
+	return info
+}
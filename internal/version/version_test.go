@@ -0,0 +1,42 @@
+package version
+
+import "testing"
+
+func TestString(t *testing.T) {
+	version, commit, date := Version, GitCommit, BuildDate
+	t.Cleanup(func() { Version, GitCommit, BuildDate = version, commit, date })
+
+	tests := []struct {
+		name      string
+		commit    string
+		buildDate string
+		want      string
+	}{
+		{name: "no metadata", commit: "unknown", buildDate: "unknown", want: "v1.2.0"},
+		{name: "commit only", commit: "abc1234", buildDate: "unknown", want: "v1.2.0 (commit: abc1234)"},
+		{name: "build date only", commit: "unknown", buildDate: "2026-10-01", want: "v1.2.0 (built: 2026-10-01)"},
+		{name: "both", commit: "abc1234", buildDate: "2026-10-01", want: "v1.2.0 (commit: abc1234, built: 2026-10-01)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Version, GitCommit, BuildDate = "v1.2.0", tt.commit, tt.buildDate
+			if got := String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	version, commit, date := Version, GitCommit, BuildDate
+	t.Cleanup(func() { Version, GitCommit, BuildDate = version, commit, date })
+
+	Version, GitCommit, BuildDate = "v1.2.0", "abc1234", "2026-10-01"
+	info := Get()
+	if info.Version != "v1.2.0" || info.GitCommit != "abc1234" || info.BuildDate != "2026-10-01" {
+		t.Errorf("Get() = %+v, want the values set through -ldflags", info)
+	}
+	if info.GoVersion == "" || info.Platform == "" {
+		t.Errorf("Get() = %+v, want the Go version and platform", info)
+	}
+}
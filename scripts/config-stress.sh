@@ -0,0 +1,70 @@
+#!/usr/bin/env sh
+# Concurrency check for the config and state files
+# - Creates clusters from many blitzctl processes at once against fake
+#   kind/docker binaries, while others change defaults
+# - Fails if any cluster or default is missing from the final files
+#
+# Usage: scripts/config-stress.sh [processes]   (default: 20)
+
+set -eu
+
+PROCESSES="${1:-20}"
+
+log() { printf "%s\n" "$*" 1>&2; }
+err() { log "ERROR: $*"; exit 1; }
+
+ROOT=$(cd "$(dirname "$0")/.." && pwd)
+WORK=$(mktemp -d)
+trap 'rm -rf "$WORK"' EXIT INT TERM
+
+# Build before HOME moves, so the usual Go module and build caches are used
+mkdir -p "$WORK/bin"
+log "Building blitzctl..."
+(cd "$ROOT" && go build -o "$WORK/bin/blitzctl" ./cmd/app)
+
+export HOME="$WORK/home"
+export XDG_STATE_HOME="$WORK/state"
+mkdir -p "$HOME"
+
+# Fake providers so no cluster is actually created
+cat > "$WORK/bin/kind" <<'FAKE'
+#!/usr/bin/env sh
+exit 0
+FAKE
+cat > "$WORK/bin/docker" <<'FAKE'
+#!/usr/bin/env sh
+exit 0
+FAKE
+chmod +x "$WORK/bin/kind" "$WORK/bin/docker"
+export PATH="$WORK/bin:$PATH"
+
+log "Creating $PROCESSES clusters concurrently..."
+pids=""
+i=1
+while [ "$i" -le "$PROCESSES" ]; do
+  blitzctl create cluster --provider kind --cluster-name "stress-$i" --cni kindnet --skip-preflight >/dev/null &
+  pids="$pids $!"
+  i=$((i + 1))
+done
+blitzctl config set helm_version 9.9.9 >/dev/null &
+pids="$pids $!"
+blitzctl config set stern_version 8.8.8 >/dev/null &
+pids="$pids $!"
+
+failed=0
+for pid in $pids; do
+  wait "$pid" || failed=$((failed + 1))
+done
+[ "$failed" -eq 0 ] || err "$failed blitzctl processes failed"
+
+CONFIG="$HOME/.blitzctl/config.yaml"
+STATE="$XDG_STATE_HOME/blitzctl/state.json"
+i=1
+while [ "$i" -le "$PROCESSES" ]; do
+  grep -q "\"name\": \"stress-$i\"" "$STATE" || err "cluster stress-$i is missing from $STATE"
+  i=$((i + 1))
+done
+grep -q "helm_version: 9.9.9" "$CONFIG" || err "helm_version update is missing from $CONFIG"
+grep -q "stern_version: 8.8.8" "$CONFIG" || err "stern_version update is missing from $CONFIG"
+
+log "OK: $PROCESSES concurrent creates and 2 config updates all persisted"